@@ -5,6 +5,7 @@ package admin
 
 import (
 	"context"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/database/rpcdb"
@@ -25,6 +26,8 @@ type Client interface {
 	Alias(ctx context.Context, endpoint string, alias string, options ...rpc.Option) error
 	AliasChain(ctx context.Context, chainID string, alias string, options ...rpc.Option) error
 	GetChainAliases(ctx context.Context, chainID string, options ...rpc.Option) ([]string, error)
+	Bench(ctx context.Context, chain string, nodeID ids.NodeID, duration time.Duration, options ...rpc.Option) error
+	Unbench(ctx context.Context, chain string, nodeID ids.NodeID, options ...rpc.Option) error
 	Stacktrace(context.Context, ...rpc.Option) error
 	LoadVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, map[ids.ID]string, error)
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
@@ -83,6 +86,21 @@ func (c *client) GetChainAliases(ctx context.Context, chain string, options ...r
 	return res.Aliases, err
 }
 
+func (c *client) Bench(ctx context.Context, chain string, nodeID ids.NodeID, duration time.Duration, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.bench", &BenchArgs{
+		Chain:    chain,
+		NodeID:   nodeID,
+		Duration: duration.String(),
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) Unbench(ctx context.Context, chain string, nodeID ids.NodeID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.unbench", &UnbenchArgs{
+		Chain:  chain,
+		NodeID: nodeID,
+	}, &api.EmptyReply{}, options...)
+}
+
 func (c *client) Stacktrace(ctx context.Context, options ...rpc.Option) error {
 	return c.requester.SendRequest(ctx, "admin.stacktrace", struct{}{}, &api.EmptyReply{}, options...)
 }
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"path"
 	"sync"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 	"go.uber.org/zap"
@@ -18,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/rpcdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -53,6 +55,7 @@ type Config struct {
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+	Benchlist    benchlist.Manager
 }
 
 // Admin is the API service for node admin management
@@ -214,6 +217,62 @@ func (a *Admin) GetChainAliases(_ *http.Request, args *GetChainAliasesArgs, repl
 	return err
 }
 
+// BenchArgs are the arguments for calling Bench
+type BenchArgs struct {
+	// Alias of the chain
+	// Can also be the string representation of the chain's ID
+	Chain  string     `json:"chain"`
+	NodeID ids.NodeID `json:"nodeID"`
+	// How long the node should be benched for, formatted as a Go duration
+	// string (e.g. "10m")
+	Duration string `json:"duration"`
+}
+
+// Bench manually benches a node on a chain
+func (a *Admin) Bench(_ *http.Request, args *BenchArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "bench"),
+		logging.UserString("chain", args.Chain),
+		zap.Stringer("nodeID", args.NodeID),
+		logging.UserString("duration", args.Duration),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	duration, err := time.ParseDuration(args.Duration)
+	if err != nil {
+		return err
+	}
+	return a.Benchlist.Bench(chainID, args.NodeID, duration)
+}
+
+// UnbenchArgs are the arguments for calling Unbench
+type UnbenchArgs struct {
+	// Alias of the chain
+	// Can also be the string representation of the chain's ID
+	Chain  string     `json:"chain"`
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// Unbench manually removes a node from the benchlist of a chain
+func (a *Admin) Unbench(_ *http.Request, args *UnbenchArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "unbench"),
+		logging.UserString("chain", args.Chain),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	return a.Benchlist.Unbench(chainID, args.NodeID)
+}
+
 // Stacktrace returns the current global stacktrace
 func (a *Admin) Stacktrace(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
 	a.Log.Debug("API called",
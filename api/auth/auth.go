@@ -0,0 +1,321 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package auth authenticates and authorizes the calls made to the node's APIs.
+//
+// Every call is described by the actions it performs. A JSON-RPC call performs
+// the methods it invokes at the endpoint it was sent to, e.g.
+// "/ext/bc/P:platform.getHeight". Any other request performs its URL path, e.g.
+// "/ext/metrics". Actions are allowed by scopes, which are patterns in the
+// syntax of [path.Match]. Scopes with a ":" match the endpoint and the method
+// of JSON-RPC calls separately, and other scopes match paths. For example,
+// "/ext/admin:admin.*" allows every method of the admin API and
+// "/ext/health/*" allows the GET endpoints of the health API.
+package auth
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	bearerPrefix = "Bearer "
+
+	defaultMaxRequestBodySize = 16 * units.MiB
+)
+
+var (
+	errMissingTokenName   = errors.New("missing token name")
+	errMissingToken       = errors.New("missing token")
+	errDuplicateTokenName = errors.New("duplicate token name")
+	errMissingJWTSecret   = errors.New("missing JWT secret")
+	errUnknownToken       = errors.New("unknown token")
+	errInvalidScope       = errors.New("scope must start with /")
+	errNegativeBodySize   = errors.New("maxRequestBodySize can't be negative")
+)
+
+// Config is the format of the file that configures API authentication.
+type Config struct {
+	// Public are the scopes that are granted to every call, including the
+	// calls that aren't authenticated.
+	Public []string `json:"public"`
+	// Tokens are the static bearer tokens that are accepted.
+	Tokens []Token `json:"tokens"`
+	// JWT, if provided, enables authentication with bearer JWTs. The scopes of
+	// a JWT are read from its "scopes" claim.
+	JWT *JWTConfig `json:"jwt"`
+	// MaxRequestBodySize is the maximum size, in bytes, of the body of a
+	// JSON-RPC request, which is read before the request is authorized. If 0,
+	// the size is limited to 16 MiB.
+	MaxRequestBodySize int64 `json:"maxRequestBodySize"`
+}
+
+type Token struct {
+	// Name identifies the token in the audit logs.
+	Name   string   `json:"name"`
+	Token  string   `json:"token"`
+	Scopes []string `json:"scopes"`
+}
+
+type JWTConfig struct {
+	// Secret used to sign the JWTs with HS256.
+	Secret string `json:"secret"`
+	// Issuer, if non-empty, must be the "iss" claim of the JWTs.
+	Issuer string `json:"issuer"`
+	// Audience, if non-empty, must be one of the "aud" claims of the JWTs.
+	Audience string `json:"audience"`
+}
+
+// ParseConfig parses [b] as a Config and verifies it.
+func ParseConfig(b []byte) (*Config, error) {
+	config := &Config{}
+	if err := json.Unmarshal(b, config); err != nil {
+		return nil, fmt.Errorf("couldn't parse auth config: %w", err)
+	}
+	return config, config.Verify()
+}
+
+func (c *Config) Verify() error {
+	if err := verifyScopes(c.Public); err != nil {
+		return fmt.Errorf("invalid public scopes: %w", err)
+	}
+
+	var names set.Set[string]
+	for _, token := range c.Tokens {
+		switch {
+		case token.Name == "":
+			return errMissingTokenName
+		case token.Token == "":
+			return fmt.Errorf("%w for %q", errMissingToken, token.Name)
+		case names.Contains(token.Name):
+			return fmt.Errorf("%w: %q", errDuplicateTokenName, token.Name)
+		}
+		names.Add(token.Name)
+
+		if err := verifyScopes(token.Scopes); err != nil {
+			return fmt.Errorf("invalid scopes for %q: %w", token.Name, err)
+		}
+	}
+
+	if c.JWT != nil && c.JWT.Secret == "" {
+		return errMissingJWTSecret
+	}
+	if c.MaxRequestBodySize < 0 {
+		return errNegativeBodySize
+	}
+	return nil
+}
+
+func verifyScopes(scopes []string) error {
+	for _, scope := range scopes {
+		if !strings.HasPrefix(scope, "/") {
+			return fmt.Errorf("%w: %q", errInvalidScope, scope)
+		}
+		pathPattern, methodPattern, _ := strings.Cut(scope, ":")
+		if _, err := path.Match(pathPattern, ""); err != nil {
+			return fmt.Errorf("%q: %w", scope, err)
+		}
+		if _, err := path.Match(methodPattern, ""); err != nil {
+			return fmt.Errorf("%q: %w", scope, err)
+		}
+	}
+	return nil
+}
+
+// action is performed by an API call. If method is empty, the action is the
+// request of path, otherwise it's the JSON-RPC call of method at path.
+type action struct {
+	path   string
+	method string
+}
+
+// newActions returns the actions performed by [r], which made [calls].
+func newActions(r *http.Request, calls []api.Call) []action {
+	urlPath := path.Clean(r.URL.Path)
+	if len(calls) == 0 {
+		return []action{{path: urlPath}}
+	}
+	actions := make([]action, len(calls))
+	for i, call := range calls {
+		actions[i] = action{
+			path:   urlPath,
+			method: call.Method,
+		}
+	}
+	return actions
+}
+
+func (a action) String() string {
+	if a.method == "" {
+		return a.path
+	}
+	return a.path + ":" + a.method
+}
+
+// scopesAllow returns true if every action is matched by one of the scopes.
+//
+// Assumes [scopes] were verified.
+func scopesAllow(scopes []string, actions []action) bool {
+	for _, action := range actions {
+		if !scopesAllowAction(scopes, action) {
+			return false
+		}
+	}
+	return true
+}
+
+func scopesAllowAction(scopes []string, action action) bool {
+	isMethod := action.method != ""
+	for _, scope := range scopes {
+		pathPattern, methodPattern, isMethodScope := strings.Cut(scope, ":")
+		if isMethodScope != isMethod {
+			continue
+		}
+		if matched, _ := path.Match(pathPattern, action.path); !matched {
+			continue
+		}
+		if matched, _ := path.Match(methodPattern, action.method); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// identity is the authenticated caller of an API.
+type identity struct {
+	name   string
+	scopes []string
+}
+
+// Auth wraps the node's APIs to require that their calls are authorized.
+type Auth struct {
+	log                logging.Logger
+	config             *Config
+	maxRequestBodySize int64
+	now                func() time.Time
+}
+
+// New returns an Auth that enforces [config].
+func New(log logging.Logger, config *Config) (*Auth, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	maxRequestBodySize := config.MaxRequestBodySize
+	if maxRequestBodySize == 0 {
+		maxRequestBodySize = defaultMaxRequestBodySize
+	}
+	return &Auth{
+		log:                log,
+		config:             config,
+		maxRequestBodySize: maxRequestBodySize,
+		now:                time.Now,
+	}, nil
+}
+
+// Wrap returns a handler that only passes the calls that are authorized to
+// [handler].
+func (a *Auth) Wrap(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The body of a JSON-RPC request is read before the request is
+		// authorized, so its size must be limited.
+		if api.IsJSONRequest(r) {
+			r.Body = http.MaxBytesReader(w, r.Body, a.maxRequestBodySize)
+		}
+		calls, _, err := api.ReadCalls(r)
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesErr):
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		case errors.Is(err, api.ErrInvalidCalls):
+			// The request can't be authorized by its path, because it may
+			// still be dispatched as a JSON-RPC call.
+			api.WriteInvalidCallsError(w, err)
+			return
+		case err != nil:
+			http.Error(w, "couldn't read request", http.StatusBadRequest)
+			return
+		}
+		actions := newActions(r, calls)
+
+		if scopesAllow(a.config.Public, actions) {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), bearerPrefix)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "authentication required", http.StatusUnauthorized)
+			return
+		}
+		id, err := a.authenticate(token)
+		if err != nil {
+			a.log.Debug("rejecting API call",
+				zap.String("reason", "invalid token"),
+				zap.String("remoteAddr", r.RemoteAddr),
+				zap.Stringers("actions", actions),
+				zap.Error(err),
+			)
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		if !scopesAllow(id.scopes, actions) {
+			a.log.Info("denied API call",
+				zap.String("reason", "insufficient scopes"),
+				zap.String("identity", id.name),
+				zap.String("remoteAddr", r.RemoteAddr),
+				zap.Stringers("actions", actions),
+			)
+			http.Error(w, "insufficient scopes", http.StatusForbidden)
+			return
+		}
+
+		a.log.Info("authorized API call",
+			zap.String("identity", id.name),
+			zap.String("remoteAddr", r.RemoteAddr),
+			zap.Stringers("actions", actions),
+		)
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func (a *Auth) authenticate(token string) (*identity, error) {
+	for _, t := range a.config.Tokens {
+		if subtle.ConstantTimeCompare([]byte(t.Token), []byte(token)) == 1 {
+			return &identity{
+				name:   t.Name,
+				scopes: t.Scopes,
+			}, nil
+		}
+	}
+	if a.config.JWT == nil {
+		return nil, errUnknownToken
+	}
+
+	claims, err := verifyJWT(a.config.JWT, token, a.now())
+	if err != nil {
+		return nil, err
+	}
+	// Invalid scopes would never match an action, so they don't need to be
+	// rejected.
+	return &identity{
+		name:   "jwt:" + claims.Subject,
+		scopes: claims.Scopes,
+	}, nil
+}
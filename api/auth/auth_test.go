@@ -0,0 +1,348 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			config: `{
+				"public": ["/ext/bc/P:platform.get*", "/ext/health/*"],
+				"tokens": [{"name": "admin", "token": "secret", "scopes": ["/ext/admin:admin.*"]}],
+				"jwt": {"secret": "secret"}
+			}`,
+		},
+		{
+			name:        "invalid path scope",
+			config:      `{"public": ["/ext/[bc"]}`,
+			expectedErr: path.ErrBadPattern,
+		},
+		{
+			name:        "invalid method scope",
+			config:      `{"public": ["/ext/bc/P:platform.[get"]}`,
+			expectedErr: path.ErrBadPattern,
+		},
+		{
+			name:        "method scope without endpoint",
+			config:      `{"public": ["platform.get*"]}`,
+			expectedErr: errInvalidScope,
+		},
+		{
+			name:        "negative body size",
+			config:      `{"maxRequestBodySize": -1}`,
+			expectedErr: errNegativeBodySize,
+		},
+		{
+			name:        "missing token name",
+			config:      `{"tokens": [{"token": "secret"}]}`,
+			expectedErr: errMissingTokenName,
+		},
+		{
+			name:        "missing token",
+			config:      `{"tokens": [{"name": "admin"}]}`,
+			expectedErr: errMissingToken,
+		},
+		{
+			name: "duplicate token name",
+			config: `{"tokens": [
+				{"name": "admin", "token": "secret0"},
+				{"name": "admin", "token": "secret1"}
+			]}`,
+			expectedErr: errDuplicateTokenName,
+		},
+		{
+			name:        "missing JWT secret",
+			config:      `{"jwt": {"issuer": "issuer"}}`,
+			expectedErr: errMissingJWTSecret,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(test.config))
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestScopesAllow(t *testing.T) {
+	var (
+		getHeight = action{path: "/ext/bc/P", method: "platform.getHeight"}
+		metrics   = action{path: "/ext/metrics"}
+	)
+	tests := []struct {
+		name     string
+		scopes   []string
+		actions  []action
+		expected bool
+	}{
+		{
+			name:     "method",
+			scopes:   []string{"/ext/bc/P:platform.get*"},
+			actions:  []action{getHeight},
+			expected: true,
+		},
+		{
+			name:     "other method",
+			scopes:   []string{"/ext/bc/P:platform.get*"},
+			actions:  []action{{path: "/ext/bc/P", method: "platform.issueTx"}},
+			expected: false,
+		},
+		{
+			name:     "method at other endpoint",
+			scopes:   []string{"/ext/bc/X:avm.*"},
+			actions:  []action{{path: "/ext/bc/other", method: "avm.getTx"}},
+			expected: false,
+		},
+		{
+			name:     "method at any endpoint",
+			scopes:   []string{"/ext/bc/*:avm.*"},
+			actions:  []action{{path: "/ext/bc/other", method: "avm.getTx"}},
+			expected: true,
+		},
+		{
+			name:     "method scopes don't match paths",
+			scopes:   []string{"/ext/metrics:*"},
+			actions:  []action{metrics},
+			expected: false,
+		},
+		{
+			name:     "path",
+			scopes:   []string{"/ext/health/*"},
+			actions:  []action{{path: "/ext/health/readiness"}},
+			expected: true,
+		},
+		{
+			name:     "path scopes don't match methods",
+			scopes:   []string{"/ext/*"},
+			actions:  []action{{path: "/ext/admin", method: "admin.lockProfile"}},
+			expected: false,
+		},
+		{
+			name:   "every action must be allowed",
+			scopes: []string{"/ext/bc/C/rpc:eth_*"},
+			actions: []action{
+				{path: "/ext/bc/C/rpc", method: "eth_blockNumber"},
+				{path: "/ext/bc/C/rpc", method: "debug_traceTransaction"},
+			},
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, scopesAllow(test.scopes, test.actions))
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	var (
+		now    = time.Now()
+		config = &Config{
+			Public: []string{"/ext/bc/P:platform.get*", "/ext/health/*"},
+			Tokens: []Token{
+				{
+					Name:   "operator",
+					Token:  "operator-token",
+					Scopes: []string{"/ext/admin:admin.*", "/ext/bc/P:platform.*"},
+				},
+				{
+					Name:   "paths",
+					Token:  "paths-token",
+					Scopes: []string{"/ext/*"},
+				},
+			},
+			JWT: &JWTConfig{
+				Secret: "secret",
+			},
+			MaxRequestBodySize: 64,
+		}
+		handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+	)
+	a, err := New(logging.NoLog{}, config)
+	require.NoError(t, err)
+	a.now = func() time.Time {
+		return now
+	}
+	wrapped := a.Wrap(handler)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		contentType    string
+		body           string
+		token          func(t *testing.T) string
+		expectedStatus int
+	}{
+		{
+			name:           "public path",
+			method:         http.MethodGet,
+			path:           "/ext/health/liveness",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "public method",
+			method:         http.MethodPost,
+			path:           "/ext/bc/P",
+			body:           `{"method": "platform.getHeight"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing token",
+			method:         http.MethodPost,
+			path:           "/ext/admin",
+			body:           `{"method": "admin.lockProfile"}`,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "unknown token",
+			method: http.MethodPost,
+			path:   "/ext/admin",
+			body:   `{"method": "admin.lockProfile"}`,
+			token: func(*testing.T) string {
+				return "unknown"
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "static token",
+			method: http.MethodPost,
+			path:   "/ext/admin",
+			body:   `{"method": "admin.lockProfile"}`,
+			token: func(*testing.T) string {
+				return "operator-token"
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "insufficient scopes",
+			method: http.MethodGet,
+			path:   "/ext/metrics",
+			token: func(*testing.T) string {
+				return "operator-token"
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "JWT",
+			method: http.MethodGet,
+			path:   "/ext/metrics",
+			token: func(t *testing.T) string {
+				return newJWT(t, "secret", jwtAlgorithm, map[string]interface{}{
+					"sub":    "monitoring",
+					"exp":    now.Unix() + 60,
+					"scopes": []string{"/ext/metrics"},
+				})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "expired JWT",
+			method: http.MethodGet,
+			path:   "/ext/metrics",
+			token: func(t *testing.T) string {
+				return newJWT(t, "secret", jwtAlgorithm, map[string]interface{}{
+					"sub":    "monitoring",
+					"exp":    now.Unix() - 60,
+					"scopes": []string{"/ext/metrics"},
+				})
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "path scope",
+			method:      http.MethodPost,
+			path:        "/ext/admin",
+			contentType: "text/plain",
+			body:        `{"method": "admin.lockProfile"}`,
+			token: func(*testing.T) string {
+				return "paths-token"
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "path scope with content type parameter",
+			method:      http.MethodPost,
+			path:        "/ext/admin",
+			contentType: "Application/JSON;foo",
+			body:        `{"method": "admin.lockProfile"}`,
+			token: func(*testing.T) string {
+				return "paths-token"
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "method scope at other endpoint",
+			method: http.MethodPost,
+			path:   "/ext/bc/X",
+			body:   `{"method": "platform.getHeight"}`,
+			token: func(*testing.T) string {
+				return "operator-token"
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "body too large",
+			method:         http.MethodPost,
+			path:           "/ext/bc/P",
+			body:           `{"method": "platform.getHeight", "params": {"padding": "` + strings.Repeat("0", 64) + `"}}`,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:   "invalid JSON-RPC",
+			method: http.MethodPost,
+			path:   "/ext/admin",
+			body:   `{"method": 1}`,
+			token: func(*testing.T) string {
+				return "paths-token"
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid JSON-RPC batch",
+			method: http.MethodPost,
+			path:   "/ext/admin",
+			body:   `[{"method": "admin.lockProfile"}, 1]`,
+			token: func(*testing.T) string {
+				return "paths-token"
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(test.method, test.path, strings.NewReader(test.body))
+			contentType := test.contentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			r.Header.Set("Content-Type", contentType)
+			if test.token != nil {
+				r.Header.Set("Authorization", bearerPrefix+test.token(t))
+			}
+			w := httptest.NewRecorder()
+			wrapped.ServeHTTP(w, r)
+			require.Equal(t, test.expectedStatus, w.Code)
+		})
+	}
+}
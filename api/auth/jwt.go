@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const jwtAlgorithm = "HS256"
+
+var (
+	errMalformedJWT        = errors.New("malformed JWT")
+	errUnsupportedJWTAlg   = errors.New("unsupported JWT algorithm")
+	errInvalidJWTSignature = errors.New("invalid JWT signature")
+	errMissingJWTExpiry    = errors.New("JWT has no expiry")
+	errExpiredJWT          = errors.New("expired JWT")
+	errJWTNotYetValid      = errors.New("JWT not yet valid")
+	errWrongJWTIssuer      = errors.New("wrong JWT issuer")
+	errWrongJWTAudience    = errors.New("wrong JWT audience")
+)
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+}
+
+// jwtClaims are the claims of a JWT that are used to authenticate requests.
+type jwtClaims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt *int64   `json:"exp"`
+	NotBefore *int64   `json:"nbf"`
+	Scopes    []string `json:"scopes"`
+}
+
+// audience is either a single string or an array of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	return json.Unmarshal(b, (*[]string)(a))
+}
+
+// verifyJWT returns the claims of [token] if it is a valid JWT that was signed
+// with [config.Secret] and that hasn't expired at time [now].
+func verifyJWT(config *JWTConfig, token string, now time.Time) (*jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: expected 3 parts but got %d", errMalformedJWT, len(parts))
+	}
+
+	var header jwtHeader
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header.Algorithm != jwtAlgorithm {
+		return nil, fmt.Errorf("%w: %q", errUnsupportedJWTAlg, header.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errMalformedJWT, err)
+	}
+	mac := hmac.New(sha256.New, []byte(config.Secret))
+	_, _ = mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errInvalidJWTSignature
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	// JWTs can't be revoked, so they must expire.
+	if claims.ExpiresAt == nil {
+		return nil, errMissingJWTExpiry
+	}
+	unixNow := now.Unix()
+	if unixNow >= *claims.ExpiresAt {
+		return nil, errExpiredJWT
+	}
+	if claims.NotBefore != nil && unixNow < *claims.NotBefore {
+		return nil, errJWTNotYetValid
+	}
+	if config.Issuer != "" && claims.Issuer != config.Issuer {
+		return nil, fmt.Errorf("%w: %q", errWrongJWTIssuer, claims.Issuer)
+	}
+	if config.Audience != "" && !slices.Contains(claims.Audience, config.Audience) {
+		return nil, errWrongJWTAudience
+	}
+	return &claims, nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errMalformedJWT, err)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("%w: %w", errMalformedJWT, err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newJWT(t *testing.T, secret string, alg string, claims map[string]interface{}) string {
+	require := require.New(t)
+
+	header, err := json.Marshal(map[string]string{
+		"alg": alg,
+		"typ": "JWT",
+	})
+	require.NoError(err)
+	payload, err := json.Marshal(claims)
+	require.NoError(err)
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyJWT(t *testing.T) {
+	var (
+		now    = time.Unix(1_000_000, 0)
+		config = &JWTConfig{
+			Secret:   "secret",
+			Issuer:   "issuer",
+			Audience: "node",
+		}
+		validClaims = func() map[string]interface{} {
+			return map[string]interface{}{
+				"sub":    "monitoring",
+				"iss":    "issuer",
+				"aud":    []string{"other", "node"},
+				"exp":    now.Unix() + 1,
+				"nbf":    now.Unix(),
+				"scopes": []string{"/ext/info:info.*"},
+			}
+		}
+	)
+	tests := []struct {
+		name        string
+		token       func(t *testing.T) string
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			token: func(t *testing.T) string {
+				return newJWT(t, config.Secret, jwtAlgorithm, validClaims())
+			},
+		},
+		{
+			name: "single audience",
+			token: func(t *testing.T) string {
+				claims := validClaims()
+				claims["aud"] = "node"
+				return newJWT(t, config.Secret, jwtAlgorithm, claims)
+			},
+		},
+		{
+			name: "malformed",
+			token: func(*testing.T) string {
+				return "not.a-jwt"
+			},
+			expectedErr: errMalformedJWT,
+		},
+		{
+			name: "unsupported algorithm",
+			token: func(t *testing.T) string {
+				return newJWT(t, config.Secret, "none", validClaims())
+			},
+			expectedErr: errUnsupportedJWTAlg,
+		},
+		{
+			name: "wrong secret",
+			token: func(t *testing.T) string {
+				return newJWT(t, "wrong", jwtAlgorithm, validClaims())
+			},
+			expectedErr: errInvalidJWTSignature,
+		},
+		{
+			name: "no expiry",
+			token: func(t *testing.T) string {
+				claims := validClaims()
+				delete(claims, "exp")
+				return newJWT(t, config.Secret, jwtAlgorithm, claims)
+			},
+			expectedErr: errMissingJWTExpiry,
+		},
+		{
+			name: "expired",
+			token: func(t *testing.T) string {
+				claims := validClaims()
+				claims["exp"] = now.Unix()
+				return newJWT(t, config.Secret, jwtAlgorithm, claims)
+			},
+			expectedErr: errExpiredJWT,
+		},
+		{
+			name: "not yet valid",
+			token: func(t *testing.T) string {
+				claims := validClaims()
+				claims["nbf"] = now.Unix() + 1
+				return newJWT(t, config.Secret, jwtAlgorithm, claims)
+			},
+			expectedErr: errJWTNotYetValid,
+		},
+		{
+			name: "wrong issuer",
+			token: func(t *testing.T) string {
+				claims := validClaims()
+				claims["iss"] = "other"
+				return newJWT(t, config.Secret, jwtAlgorithm, claims)
+			},
+			expectedErr: errWrongJWTIssuer,
+		},
+		{
+			name: "wrong audience",
+			token: func(t *testing.T) string {
+				claims := validClaims()
+				claims["aud"] = "other"
+				return newJWT(t, config.Secret, jwtAlgorithm, claims)
+			},
+			expectedErr: errWrongJWTAudience,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			claims, err := verifyJWT(config, test.token(t), now)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal("monitoring", claims.Subject)
+			require.Equal([]string{"/ext/info:info.*"}, claims.Scopes)
+		})
+	}
+}
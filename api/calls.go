@@ -0,0 +1,154 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// parseErrorCode is the JSON-RPC error code of requests that aren't valid
+// JSON.
+const parseErrorCode = -32700
+
+var (
+	ErrInvalidCalls = errors.New("invalid JSON-RPC request")
+
+	errEmptyBatch    = errors.New("empty batch")
+	errMissingMethod = errors.New("missing method")
+)
+
+// Call is the part of a JSON-RPC call that identifies it.
+type Call struct {
+	Method string          `json:"method"`
+	ID     json.RawMessage `json:"id,omitempty"`
+}
+
+// IsJSONRequest returns true if [r] could be a JSON-RPC request. JSON-RPC
+// servers only accept POST requests with a JSON content type.
+//
+// The content type is classified the way the JSON-RPC servers classify it,
+// by the case-insensitive text before the first ";", so that every request
+// that could be dispatched as a JSON-RPC call is treated as one. A request
+// without a content type is also treated as one, because servers with a single
+// codec default to it.
+func IsJSONRequest(r *http.Request) bool {
+	if r.Method != http.MethodPost || r.Body == nil {
+		return false
+	}
+	mediaType, _, _ := strings.Cut(r.Header.Get("Content-Type"), ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	return mediaType == "" || mediaType == "application/json"
+}
+
+// ReadCalls returns the JSON-RPC calls made by [r], or nil if [r] isn't a
+// JSON-RPC request. It also returns true if the calls were made in a batch,
+// even if the batch only has one call.
+//
+// If [r] is a JSON-RPC request, its body is read and replaced so that it can
+// be read again by the handler. If its body can't be parsed as JSON-RPC calls,
+// an error wrapping [ErrInvalidCalls] is returned, and the request must be
+// rejected rather than treated as a request that isn't JSON-RPC.
+func ReadCalls(r *http.Request) ([]Call, bool, error) {
+	if !IsJSONRequest(r) {
+		return nil, false, nil
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	_, calls, batch, err := ParseCalls(body)
+	return calls, batch, err
+}
+
+// ParseCalls parses [body] as a JSON-RPC request. It returns every call of the
+// request, both unparsed and parsed, and true if the calls were made in a
+// batch.
+//
+// The body is decoded the same way as the JSON-RPC servers decode it, so that
+// trailing data is ignored. Every call must be an object with a method, so
+// that the methods that are invoked are always known.
+func ParseCalls(body []byte) ([]json.RawMessage, []Call, bool, error) {
+	trimmed := bytes.TrimSpace(body)
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	batch := bytes.HasPrefix(trimmed, []byte("["))
+	if !batch {
+		var rawCall json.RawMessage
+		if err := decoder.Decode(&rawCall); err != nil {
+			return nil, nil, false, fmt.Errorf("%w: %w", ErrInvalidCalls, err)
+		}
+		call, err := parseCall(rawCall)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		return []json.RawMessage{rawCall}, []Call{call}, false, nil
+	}
+
+	var rawCalls []json.RawMessage
+	if err := decoder.Decode(&rawCalls); err != nil {
+		return nil, nil, true, fmt.Errorf("%w: %w", ErrInvalidCalls, err)
+	}
+	if len(rawCalls) == 0 {
+		return nil, nil, true, fmt.Errorf("%w: %w", ErrInvalidCalls, errEmptyBatch)
+	}
+	calls := make([]Call, len(rawCalls))
+	for i, rawCall := range rawCalls {
+		call, err := parseCall(rawCall)
+		if err != nil {
+			return nil, nil, true, fmt.Errorf("call %d: %w", i, err)
+		}
+		calls[i] = call
+	}
+	return rawCalls, calls, true, nil
+}
+
+func parseCall(rawCall json.RawMessage) (Call, error) {
+	var call Call
+	if err := json.Unmarshal(rawCall, &call); err != nil {
+		return Call{}, fmt.Errorf("%w: %w", ErrInvalidCalls, err)
+	}
+	if call.Method == "" {
+		return Call{}, fmt.Errorf("%w: %w", ErrInvalidCalls, errMissingMethod)
+	}
+	return call, nil
+}
+
+// WriteInvalidCallsError rejects a request whose calls couldn't be parsed with
+// a JSON-RPC parse error.
+func WriteInvalidCallsError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"error": map[string]interface{}{
+			"code":    parseErrorCode,
+			"message": err.Error(),
+		},
+		"id": nil,
+	})
+}
+
+// Actions returns the actions performed by [r], which made [calls]. A JSON-RPC
+// request performs the methods it invokes, e.g. "platform.getHeight". Any
+// other request, such as a GET request or a websocket upgrade, performs its URL
+// path, e.g. "/ext/metrics".
+func Actions(r *http.Request, calls []Call) []string {
+	if len(calls) == 0 {
+		return []string{path.Clean(r.URL.Path)}
+	}
+	actions := make([]string, len(calls))
+	for i, call := range calls {
+		actions[i] = call.Method
+	}
+	return actions
+}
@@ -0,0 +1,155 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCalls(t *testing.T) {
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		contentType     string
+		body            string
+		expectedActions []string
+		expectedBatch   bool
+		expectedErr     error
+	}{
+		{
+			name:            "GET",
+			method:          http.MethodGet,
+			path:            "/ext/health/liveness",
+			expectedActions: []string{"/ext/health/liveness"},
+		},
+		{
+			name:            "unclean path",
+			method:          http.MethodGet,
+			path:            "/ext/health/../metrics",
+			expectedActions: []string{"/ext/metrics"},
+		},
+		{
+			name:            "JSON-RPC",
+			method:          http.MethodPost,
+			path:            "/ext/bc/P",
+			contentType:     "application/json",
+			body:            `{"jsonrpc": "2.0", "method": "platform.getHeight", "id": 1}`,
+			expectedActions: []string{"platform.getHeight"},
+		},
+		{
+			name:            "JSON-RPC with charset",
+			method:          http.MethodPost,
+			path:            "/ext/bc/P",
+			contentType:     "application/json;charset=UTF-8",
+			body:            `{"jsonrpc": "2.0", "method": "platform.getHeight", "id": 1}`,
+			expectedActions: []string{"platform.getHeight"},
+		},
+		{
+			name:            "JSON-RPC batch",
+			method:          http.MethodPost,
+			path:            "/ext/bc/C/rpc",
+			contentType:     "application/json",
+			body:            ` [{"method": "eth_blockNumber"}, {"method": "debug_traceTransaction"}]`,
+			expectedActions: []string{"eth_blockNumber", "debug_traceTransaction"},
+			expectedBatch:   true,
+		},
+		{
+			name:            "JSON-RPC with trailing data",
+			method:          http.MethodPost,
+			path:            "/ext/admin",
+			contentType:     "application/json",
+			body:            `{"method": "admin.lockProfile"} {"method": "info.getNodeID"}`,
+			expectedActions: []string{"admin.lockProfile"},
+		},
+		{
+			name:            "not JSON",
+			method:          http.MethodPost,
+			path:            "/ext/metrics",
+			contentType:     "text/plain",
+			body:            `{"method": "info.getNodeID"}`,
+			expectedActions: []string{"/ext/metrics"},
+		},
+		{
+			name:            "JSON-RPC with invalid content type parameter",
+			method:          http.MethodPost,
+			path:            "/ext/admin",
+			contentType:     "Application/JSON;foo",
+			body:            `{"method": "admin.lockProfile"}`,
+			expectedActions: []string{"admin.lockProfile"},
+		},
+		{
+			name:            "JSON-RPC without content type",
+			method:          http.MethodPost,
+			path:            "/ext/admin",
+			body:            `{"method": "admin.lockProfile"}`,
+			expectedActions: []string{"admin.lockProfile"},
+		},
+		{
+			name:        "invalid JSON-RPC",
+			method:      http.MethodPost,
+			path:        "/ext/info",
+			contentType: "application/json",
+			body:        `{"method": 1}`,
+			expectedErr: ErrInvalidCalls,
+		},
+		{
+			name:        "JSON-RPC without method",
+			method:      http.MethodPost,
+			path:        "/ext/info",
+			contentType: "application/json",
+			body:        `{"id": 1}`,
+			expectedErr: ErrInvalidCalls,
+		},
+		{
+			name:        "invalid JSON",
+			method:      http.MethodPost,
+			path:        "/ext/info",
+			contentType: "application/json",
+			body:        `{"method": "info.getNodeID"`,
+			expectedErr: ErrInvalidCalls,
+		},
+		{
+			name:        "empty JSON-RPC batch",
+			method:      http.MethodPost,
+			path:        "/ext/admin",
+			contentType: "application/json",
+			body:        `[]`,
+			expectedErr: ErrInvalidCalls,
+		},
+		{
+			name:        "JSON-RPC batch with invalid call",
+			method:      http.MethodPost,
+			path:        "/ext/admin",
+			contentType: "application/json",
+			body:        `[{"method": "admin.lockProfile"}, 1]`,
+			expectedErr: ErrInvalidCalls,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			r := httptest.NewRequest(test.method, test.path, strings.NewReader(test.body))
+			r.Header.Set("Content-Type", test.contentType)
+			calls, batch, err := ReadCalls(r)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				require.Equal(test.expectedBatch, batch)
+				require.Equal(test.expectedActions, Actions(r, calls))
+			}
+
+			// The body must still be readable by the handler.
+			body, err := io.ReadAll(r.Body)
+			require.NoError(err)
+			require.Equal(test.body, string(body))
+		})
+	}
+}
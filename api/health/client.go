@@ -22,6 +22,8 @@ type Client interface {
 	Health(ctx context.Context, tags []string, options ...rpc.Option) (*APIReply, error)
 	// Liveness returns if the node is in need of a restart
 	Liveness(ctx context.Context, tags []string, options ...rpc.Option) (*APIReply, error)
+	// History returns when the checks started passing or failing
+	History(ctx context.Context, tags []string, options ...rpc.Option) (*HistoryReply, error)
 }
 
 // Client implementation for Avalanche Health API Endpoint
@@ -54,6 +56,12 @@ func (c *client) Liveness(ctx context.Context, tags []string, options ...rpc.Opt
 	return res, err
 }
 
+func (c *client) History(ctx context.Context, tags []string, options ...rpc.Option) (*HistoryReply, error) {
+	res := &HistoryReply{}
+	err := c.requester.SendRequest(ctx, "health.history", &APIArgs{Tags: tags}, res, options...)
+	return res, err
+}
+
 // AwaitReady polls the node every [freq] until the node reports ready.
 // Only returns an error if [ctx] returns an error.
 func AwaitReady(ctx context.Context, c Client, freq time.Duration, tags []string, options ...rpc.Option) (bool, error) {
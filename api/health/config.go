@@ -0,0 +1,59 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	DefaultConfig = Config{
+		FailureThreshold:  1,
+		SuccessThreshold:  1,
+		HistorySize:       32,
+		FlappingThreshold: 5,
+		FlappingWindow:    5 * time.Minute,
+	}
+
+	errInvalidThreshold      = errors.New("threshold must be at least 1")
+	errInvalidHistorySize    = errors.New("history size must be at least 1")
+	errInvalidFlappingWindow = errors.New("flapping window must be positive")
+)
+
+// Config describes how the results of the checks are reported.
+type Config struct {
+	// FailureThreshold is the number of consecutive failures after which a
+	// passing check is reported as failing.
+	FailureThreshold int `json:"failureThreshold"`
+	// SuccessThreshold is the number of consecutive passes after which a
+	// failing check is reported as passing.
+	SuccessThreshold int `json:"successThreshold"`
+
+	// HistorySize is the number of transitions that are remembered for each
+	// check.
+	HistorySize int `json:"historySize"`
+
+	// FlappingThreshold is the number of transitions within FlappingWindow
+	// after which a check is reported as flapping. If 0, checks are never
+	// reported as flapping.
+	FlappingThreshold int           `json:"flappingThreshold"`
+	FlappingWindow    time.Duration `json:"flappingWindow"`
+}
+
+func (c *Config) Verify() error {
+	switch {
+	case c.FailureThreshold < 1:
+		return fmt.Errorf("%w: failure threshold is %d", errInvalidThreshold, c.FailureThreshold)
+	case c.SuccessThreshold < 1:
+		return fmt.Errorf("%w: success threshold is %d", errInvalidThreshold, c.SuccessThreshold)
+	case c.HistorySize < 1:
+		return fmt.Errorf("%w: %d", errInvalidHistorySize, c.HistorySize)
+	case c.FlappingThreshold > 0 && c.FlappingWindow <= 0:
+		return fmt.Errorf("%w: %s", errInvalidFlappingWindow, c.FlappingWindow)
+	default:
+		return nil
+	}
+}
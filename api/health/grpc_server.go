@@ -0,0 +1,174 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/health"
+)
+
+var (
+	_ pb.HealthServer = (*GRPCServer)(nil)
+	_ Listener        = (*GRPCServer)(nil)
+)
+
+// GRPCServer serves the health API over gRPC.
+type GRPCServer struct {
+	pb.UnsafeHealthServer
+	log    logging.Logger
+	health Reporter
+
+	watchersLock sync.Mutex
+	// watchers are signalled whenever a health check starts passing or
+	// failing.
+	watchers set.Set[chan struct{}]
+}
+
+// NewGRPCService returns a gRPC server that serves the health API.
+func NewGRPCService(log logging.Logger, health Health) *grpc.Server {
+	s := &GRPCServer{
+		log:    log,
+		health: health,
+	}
+	health.RegisterListener(s)
+
+	server := grpc.NewServer()
+	pb.RegisterHealthServer(server, s)
+	return server
+}
+
+func (s *GRPCServer) Readiness(_ context.Context, req *pb.HealthRequest) (*pb.HealthResponse, error) {
+	s.log.Debug("gRPC called",
+		zap.String("service", "health"),
+		zap.String("method", "readiness"),
+		zap.Strings("tags", req.Tags),
+	)
+	return newHealthResponse(s.health.Readiness(req.Tags...)), nil
+}
+
+func (s *GRPCServer) Health(_ context.Context, req *pb.HealthRequest) (*pb.HealthResponse, error) {
+	s.log.Debug("gRPC called",
+		zap.String("service", "health"),
+		zap.String("method", "health"),
+		zap.Strings("tags", req.Tags),
+	)
+	return newHealthResponse(s.health.Health(req.Tags...)), nil
+}
+
+func (s *GRPCServer) Liveness(_ context.Context, req *pb.HealthRequest) (*pb.HealthResponse, error) {
+	s.log.Debug("gRPC called",
+		zap.String("service", "health"),
+		zap.String("method", "liveness"),
+		zap.Strings("tags", req.Tags),
+	)
+	return newHealthResponse(s.health.Liveness(req.Tags...)), nil
+}
+
+func (s *GRPCServer) Watch(req *pb.HealthRequest, stream pb.Health_WatchServer) error {
+	s.log.Debug("gRPC called",
+		zap.String("service", "health"),
+		zap.String("method", "watch"),
+		zap.Strings("tags", req.Tags),
+	)
+
+	changed := make(chan struct{}, 1)
+	s.watchersLock.Lock()
+	s.watchers.Add(changed)
+	s.watchersLock.Unlock()
+	defer func() {
+		s.watchersLock.Lock()
+		s.watchers.Remove(changed)
+		s.watchersLock.Unlock()
+	}()
+
+	var lastFailing set.Set[string]
+	for first := true; ; first = false {
+		results, healthy := s.health.Health(req.Tags...)
+		failing := failingChecks(results)
+		// Only the checks that started or stopped failing are reported, so
+		// changes to checks that aren't watched are skipped.
+		if first || !failing.Equals(lastFailing) {
+			if err := stream.Send(newHealthResponse(results, healthy)); err != nil {
+				return err
+			}
+			lastFailing = failing
+		}
+
+		select {
+		case <-changed:
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (s *GRPCServer) OnCheckChanged(namespace string, _ string, _ Result) {
+	if namespace != "health" {
+		return
+	}
+
+	s.watchersLock.Lock()
+	defer s.watchersLock.Unlock()
+
+	for watcher := range s.watchers {
+		select {
+		case watcher <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func failingChecks(results map[string]Result) set.Set[string] {
+	failing := set.Set[string]{}
+	for name, result := range results {
+		if result.Error != nil {
+			failing.Add(name)
+		}
+	}
+	return failing
+}
+
+func newHealthResponse(results map[string]Result, healthy bool) *pb.HealthResponse {
+	checks := make(map[string]*pb.Result, len(results))
+	for name, result := range results {
+		checks[name] = newResult(result)
+	}
+	return &pb.HealthResponse{
+		Healthy: healthy,
+		Checks:  checks,
+	}
+}
+
+func newResult(result Result) *pb.Result {
+	pbResult := &pb.Result{
+		Duration:           durationpb.New(result.Duration),
+		ContiguousFailures: result.ContiguousFailures,
+	}
+	if result.Details != nil {
+		// If the details can't be marshalled, they are omitted, just like
+		// they would fail to be reported over JSON.
+		pbResult.Details, _ = json.Marshal(result.Details)
+	}
+	if result.Error != nil {
+		pbResult.Error = *result.Error
+	}
+	if !result.Timestamp.IsZero() {
+		pbResult.Timestamp = timestamppb.New(result.Timestamp)
+	}
+	if result.TimeOfFirstFailure != nil {
+		pbResult.TimeOfFirstFailure = timestamppb.New(*result.TimeOfFirstFailure)
+	}
+	return pbResult
+}
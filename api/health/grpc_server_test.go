@@ -0,0 +1,98 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/health"
+)
+
+var _ pb.Health_WatchServer = (*testWatchServer)(nil)
+
+type testWatchServer struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses chan *pb.HealthResponse
+}
+
+func (s *testWatchServer) Send(response *pb.HealthResponse) error {
+	s.responses <- response
+	return nil
+}
+
+func (s *testWatchServer) Context() context.Context {
+	return s.ctx
+}
+
+func TestGRPCServerWatch(t *testing.T) {
+	require := require.New(t)
+
+	var failing utils.Atomic[bool]
+	check := CheckerFunc(func(context.Context) (interface{}, error) {
+		if failing.Get() {
+			return "failing", errUnhealthy
+		}
+		return "passing", nil
+	})
+
+	h, err := New(logging.NoLog{}, DefaultConfig, prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(h.RegisterHealthCheck("check", check))
+
+	s := &GRPCServer{
+		log:    logging.NoLog{},
+		health: h,
+	}
+	h.RegisterListener(s)
+
+	h.Start(context.Background(), checkFreq)
+	defer h.Stop()
+	awaitHealthy(t, h, true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &testWatchServer{
+		ctx:       ctx,
+		responses: make(chan *pb.HealthResponse),
+	}
+	done := make(chan error)
+	go func() {
+		done <- s.Watch(&pb.HealthRequest{}, stream)
+	}()
+
+	// The current health is sent immediately.
+	response := <-stream.responses
+	require.True(response.Healthy)
+	require.Contains(response.Checks, "check")
+	require.Empty(response.Checks["check"].Error)
+	require.JSONEq(`"passing"`, string(response.Checks["check"].Details))
+
+	// Then it is sent whenever a check starts failing...
+	failing.Set(true)
+	response = <-stream.responses
+	require.False(response.Healthy)
+	require.Equal(errUnhealthy.Error(), response.Checks["check"].Error)
+	require.NotNil(response.Checks["check"].TimeOfFirstFailure)
+
+	var details string
+	require.NoError(json.Unmarshal(response.Checks["check"].Details, &details))
+	require.Equal("failing", details)
+
+	// ...or stops failing.
+	failing.Set(false)
+	response = <-stream.responses
+	require.True(response.Healthy)
+
+	cancel()
+	require.NoError(<-done)
+}
@@ -42,6 +42,20 @@ type Health interface {
 	// Stop running periodic health checks. Stop should only be called after
 	// Start. Once Stop returns, no more health checks will be executed.
 	Stop()
+
+	// RegisterListener causes [listener] to be notified whenever a check
+	// starts passing or failing.
+	RegisterListener(listener Listener)
+}
+
+// Listener is notified whenever a health check starts passing or failing.
+type Listener interface {
+	// OnCheckChanged is called with the namespace of the check, which is one
+	// of "readiness", "health", or "liveness", the name of the check, and its
+	// latest result.
+	//
+	// OnCheckChanged must not block.
+	OnCheckChanged(namespace string, name string, result Result)
 }
 
 // Registerer defines how to register new components to check the health of.
@@ -56,6 +70,17 @@ type Reporter interface {
 	Readiness(tags ...string) (map[string]Result, bool)
 	Health(tags ...string) (map[string]Result, bool)
 	Liveness(tags ...string) (map[string]Result, bool)
+
+	// History returns the recorded transitions of the checks matching [tags].
+	History(tags ...string) History
+}
+
+// History is the timeline of the recorded transitions of each check, from the
+// oldest to the newest.
+type History struct {
+	Readiness map[string][]Transition `json:"readiness"`
+	Health    map[string][]Transition `json:"health"`
+	Liveness  map[string][]Transition `json:"liveness"`
 }
 
 type health struct {
@@ -65,7 +90,7 @@ type health struct {
 	liveness  *worker
 }
 
-func New(log logging.Logger, registerer prometheus.Registerer) (Health, error) {
+func New(log logging.Logger, config Config, registerer prometheus.Registerer) (Health, error) {
 	failingChecks := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "checks_failing",
@@ -75,9 +100,9 @@ func New(log logging.Logger, registerer prometheus.Registerer) (Health, error) {
 	)
 	return &health{
 		log:       log,
-		readiness: newWorker(log, "readiness", failingChecks),
-		health:    newWorker(log, "health", failingChecks),
-		liveness:  newWorker(log, "liveness", failingChecks),
+		readiness: newWorker(log, "readiness", config, failingChecks),
+		health:    newWorker(log, "health", config, failingChecks),
+		liveness:  newWorker(log, "liveness", config, failingChecks),
 	}, registerer.Register(failingChecks)
 }
 
@@ -126,12 +151,26 @@ func (h *health) Liveness(tags ...string) (map[string]Result, bool) {
 	return results, healthy
 }
 
+func (h *health) History(tags ...string) History {
+	return History{
+		Readiness: h.readiness.History(tags...),
+		Health:    h.health.History(tags...),
+		Liveness:  h.liveness.History(tags...),
+	}
+}
+
 func (h *health) Start(ctx context.Context, freq time.Duration) {
 	h.readiness.Start(ctx, freq)
 	h.health.Start(ctx, freq)
 	h.liveness.Start(ctx, freq)
 }
 
+func (h *health) RegisterListener(listener Listener) {
+	h.readiness.RegisterListener(listener)
+	h.health.RegisterListener(listener)
+	h.liveness.RegisterListener(listener)
+}
+
 func (h *health) Stop() {
 	h.readiness.Stop()
 	h.health.Stop()
@@ -54,7 +54,7 @@ func TestDuplicatedRegistations(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, DefaultConfig, prometheus.NewRegistry())
 	require.NoError(err)
 
 	require.NoError(h.RegisterReadinessCheck("check", check))
@@ -77,7 +77,7 @@ func TestDefaultFailing(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, DefaultConfig, prometheus.NewRegistry())
 	require.NoError(err)
 
 	{
@@ -118,7 +118,7 @@ func TestPassingChecks(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, DefaultConfig, prometheus.NewRegistry())
 	require.NoError(err)
 
 	require.NoError(h.RegisterReadinessCheck("check", check))
@@ -182,7 +182,7 @@ func TestPassingThenFailingChecks(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, DefaultConfig, prometheus.NewRegistry())
 	require.NoError(err)
 
 	require.NoError(h.RegisterReadinessCheck("check", check))
@@ -226,10 +226,133 @@ func TestPassingThenFailingChecks(t *testing.T) {
 	}
 }
 
+type checkChange struct {
+	namespace string
+	name      string
+	healthy   bool
+}
+
+type testListener chan checkChange
+
+func (l testListener) OnCheckChanged(namespace string, name string, result Result) {
+	l <- checkChange{
+		namespace: namespace,
+		name:      name,
+		healthy:   result.Error == nil,
+	}
+}
+
+func TestListener(t *testing.T) {
+	require := require.New(t)
+
+	var shouldCheckErr utils.Atomic[bool]
+	check := CheckerFunc(func(context.Context) (interface{}, error) {
+		if shouldCheckErr.Get() {
+			return errUnhealthy.Error(), errUnhealthy
+		}
+		return "", nil
+	})
+
+	h, err := New(logging.NoLog{}, DefaultConfig, prometheus.NewRegistry())
+	require.NoError(err)
+
+	require.NoError(h.RegisterHealthCheck("check", check))
+
+	listener := make(testListener, 3)
+	h.RegisterListener(listener)
+
+	h.Start(context.Background(), checkFreq)
+	defer h.Stop()
+
+	// Checks start out failing, so the first run of a passing check is
+	// reported.
+	require.Equal(checkChange{namespace: "health", name: "check", healthy: true}, <-listener)
+
+	shouldCheckErr.Set(true)
+	require.Equal(checkChange{namespace: "health", name: "check", healthy: false}, <-listener)
+
+	shouldCheckErr.Set(false)
+	require.Equal(checkChange{namespace: "health", name: "check", healthy: true}, <-listener)
+}
+
+func TestHysteresisAndHistory(t *testing.T) {
+	require := require.New(t)
+
+	var checkErr error
+	check := CheckerFunc(func(context.Context) (interface{}, error) {
+		return "", checkErr
+	})
+
+	failingChecks := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{},
+		[]string{CheckLabel, TagLabel},
+	)
+	w := newWorker(
+		logging.NoLog{},
+		"health",
+		Config{
+			FailureThreshold:  2,
+			SuccessThreshold:  2,
+			HistorySize:       2,
+			FlappingThreshold: 2,
+			FlappingWindow:    time.Hour,
+		},
+		failingChecks,
+	)
+	require.NoError(w.RegisterCheck("check", check, "tag"))
+
+	run := func(err error) Result {
+		checkErr = err
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		w.runCheck(context.Background(), &wg, "check", w.checks["check"])
+
+		results, _ := w.Results()
+		return results["check"]
+	}
+
+	// A check that hasn't been run yet passes immediately.
+	result := run(nil)
+	require.Nil(result.Error)
+	require.Equal([]string{"tag"}, result.Tags)
+	require.False(result.Flapping)
+
+	// A single failure isn't reported.
+	result = run(errUnhealthy)
+	require.Nil(result.Error)
+	require.Equal(int64(1), result.ContiguousFailures)
+
+	result = run(errUnhealthy)
+	require.NotNil(result.Error)
+	require.Equal(errUnhealthy.Error(), *result.Error)
+	require.Equal(int64(2), result.ContiguousFailures)
+	require.True(result.Flapping)
+
+	// A single pass isn't reported.
+	result = run(nil)
+	require.NotNil(result.Error)
+	require.Zero(result.ContiguousFailures)
+
+	result = run(nil)
+	require.Nil(result.Error)
+
+	// Only the latest transitions are remembered.
+	history := w.History()
+	require.Len(history, 1)
+	require.Len(history["check"], 2)
+	require.False(history["check"][0].Healthy)
+	require.Equal(errUnhealthy.Error(), *history["check"][0].Error)
+	require.True(history["check"][1].Healthy)
+	require.Nil(history["check"][1].Error)
+
+	require.Empty(w.History("otherTag"))
+}
+
 func TestDeadlockRegression(t *testing.T) {
 	require := require.New(t)
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, DefaultConfig, prometheus.NewRegistry())
 	require.NoError(err)
 
 	var lock sync.Mutex
@@ -259,7 +382,7 @@ func TestTags(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, DefaultConfig, prometheus.NewRegistry())
 	require.NoError(err)
 	require.NoError(h.RegisterHealthCheck("check1", check))
 	require.NoError(h.RegisterHealthCheck("check2", check, "tag1"))
@@ -365,7 +488,9 @@ func TestTags(t *testing.T) {
 		require.Contains(healthResult, "check4")
 		require.Contains(healthResult, "check5")
 		require.Contains(healthResult, "check6")
-		require.Equal(notYetRunResult, healthResult["check6"])
+		expectedResult := notYetRunResult
+		expectedResult.Tags = []string{"tag1"}
+		require.Equal(expectedResult, healthResult["check6"])
 		require.False(health)
 
 		healthResult, health = h.Health("tag2")
@@ -386,7 +511,9 @@ func TestTags(t *testing.T) {
 		require.Contains(healthResult, "check4")
 		require.Contains(healthResult, "check5")
 		require.Contains(healthResult, "check7")
-		require.Equal(notYetRunResult, healthResult["check7"])
+		expectedResult = notYetRunResult
+		expectedResult.Tags = []string{ApplicationTag}
+		require.Equal(expectedResult, healthResult["check7"])
 		require.False(health)
 	}
 }
@@ -35,4 +35,24 @@ type Result struct {
 
 	// TimeOfFirstFailure of the HealthCheck,
 	TimeOfFirstFailure *time.Time `json:"timeOfFirstFailure,omitempty"`
+
+	// Tags the HealthCheck was registered with.
+	Tags []string `json:"tags,omitempty"`
+
+	// Flapping is true if the HealthCheck has recently started passing or
+	// failing too often.
+	Flapping bool `json:"flapping,omitempty"`
+}
+
+// Transition is a change of the reported state of a HealthCheck.
+type Transition struct {
+	// Time the HealthCheck started passing or failing.
+	Time time.Time `json:"time"`
+
+	// Healthy is true if the HealthCheck started passing.
+	Healthy bool `json:"healthy"`
+
+	// Error is the string representation of the error the HealthCheck started
+	// failing with.
+	Error *string `json:"error,omitempty"`
 }
@@ -60,3 +60,19 @@ func (s *Service) Liveness(_ *http.Request, args *APIArgs, reply *APIReply) erro
 	reply.Checks, reply.Healthy = s.health.Liveness(args.Tags...)
 	return nil
 }
+
+// HistoryReply is the response for History.
+type HistoryReply struct {
+	History
+}
+
+// History returns the recorded transitions of the checks
+func (s *Service) History(_ *http.Request, args *APIArgs, reply *HistoryReply) error {
+	s.log.Debug("API called",
+		zap.String("service", "health"),
+		zap.String("method", "history"),
+		zap.Strings("tags", args.Tags),
+	)
+	reply.History = s.health.History(args.Tags...)
+	return nil
+}
@@ -22,7 +22,7 @@ func TestServiceResponses(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, DefaultConfig, prometheus.NewRegistry())
 	require.NoError(err)
 
 	s := &Service{
@@ -158,7 +158,7 @@ func TestServiceTagResponse(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			require := require.New(t)
 
-			h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+			h, err := New(logging.NoLog{}, DefaultConfig, prometheus.NewRegistry())
 			require.NoError(err)
 			require.NoError(test.register(h, "check1", check))
 			require.NoError(test.register(h, "check2", check, subnetID1.String()))
@@ -186,7 +186,9 @@ func TestServiceTagResponse(t *testing.T) {
 				require.Len(reply.Checks, 2)
 				require.Contains(reply.Checks, "check2")
 				require.Contains(reply.Checks, "check4")
-				require.Equal(notYetRunResult, reply.Checks["check2"])
+				expectedResult := notYetRunResult
+				expectedResult.Tags = []string{subnetID1.String()}
+				require.Equal(expectedResult, reply.Checks["check2"])
 				require.False(reply.Healthy)
 			}
 
@@ -216,7 +218,9 @@ func TestServiceTagResponse(t *testing.T) {
 				require.Contains(reply.Checks, "check2")
 				require.Contains(reply.Checks, "check4")
 				require.Contains(reply.Checks, "check5")
-				require.Equal(notYetRunResult, reply.Checks["check5"])
+				expectedResult := notYetRunResult
+				expectedResult.Tags = []string{subnetID1.String()}
+				require.Equal(expectedResult, reply.Checks["check5"])
 				require.False(reply.Healthy)
 			}
 		})
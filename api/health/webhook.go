@@ -0,0 +1,209 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var (
+	_ Listener = (*WebhookNotifier)(nil)
+
+	namespaces = []string{"readiness", "health", "liveness"}
+)
+
+// WebhookConfig describes where notifications are sent whenever readiness,
+// health, or liveness change.
+type WebhookConfig struct {
+	// URLs that notifications are POSTed to.
+	URLs []string `json:"urls"`
+	// Timeout of each request.
+	Timeout time.Duration `json:"timeout"`
+}
+
+// Notification is sent to the webhooks whenever the node starts or stops being
+// ready, healthy, or alive.
+type Notification struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// Namespace is one of "readiness", "health", or "liveness".
+	Namespace string    `json:"namespace"`
+	Healthy   bool      `json:"healthy"`
+	Timestamp time.Time `json:"timestamp"`
+	// FailingChecks are the latest results of the checks in the namespace that
+	// are failing, including their details and tags.
+	FailingChecks map[string]Result `json:"failingChecks"`
+}
+
+// WebhookNotifier notifies the configured webhooks whenever the node starts or
+// stops being ready, healthy, or alive.
+//
+// Notifications are sent by Dispatch, so that listening to the checks never
+// blocks them. If a namespace changes multiple times before it is notified,
+// only its latest state is sent.
+type WebhookNotifier struct {
+	log      logging.Logger
+	nodeID   ids.NodeID
+	config   WebhookConfig
+	reporter Reporter
+	client   *http.Client
+
+	lock sync.Mutex
+	// pending are the namespaces that had a check change since they were last
+	// notified.
+	pending set.Set[string]
+	// healthy is the last notified state of each namespace. Every namespace is
+	// considered to start unhealthy, as its checks haven't been run yet.
+	healthy map[string]bool
+
+	signal chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func NewWebhookNotifier(
+	log logging.Logger,
+	nodeID ids.NodeID,
+	config WebhookConfig,
+	reporter Reporter,
+) *WebhookNotifier {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WebhookNotifier{
+		log:      log,
+		nodeID:   nodeID,
+		config:   config,
+		reporter: reporter,
+		client: &http.Client{
+			Timeout: config.Timeout,
+		},
+		healthy: make(map[string]bool, len(namespaces)),
+		signal:  make(chan struct{}, 1),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+func (n *WebhookNotifier) OnCheckChanged(namespace string, _ string, _ Result) {
+	n.lock.Lock()
+	n.pending.Add(namespace)
+	n.lock.Unlock()
+
+	select {
+	case n.signal <- struct{}{}:
+	default:
+	}
+}
+
+// Dispatch sends the notifications until Stop is called.
+func (n *WebhookNotifier) Dispatch() {
+	for {
+		select {
+		case <-n.signal:
+		case <-n.ctx.Done():
+			return
+		}
+
+		n.lock.Lock()
+		pending := n.pending
+		n.pending = nil
+		n.lock.Unlock()
+
+		for _, namespace := range namespaces {
+			if pending.Contains(namespace) {
+				n.notify(namespace)
+			}
+		}
+	}
+}
+
+// Stop causes Dispatch to return and cancels any request that is in flight.
+func (n *WebhookNotifier) Stop() {
+	n.cancel()
+}
+
+func (n *WebhookNotifier) notify(namespace string) {
+	var (
+		results map[string]Result
+		healthy bool
+	)
+	switch namespace {
+	case "readiness":
+		results, healthy = n.reporter.Readiness()
+	case "health":
+		results, healthy = n.reporter.Health()
+	case "liveness":
+		results, healthy = n.reporter.Liveness()
+	default:
+		return
+	}
+
+	// Only changes of the state of the namespace are notified.
+	if n.healthy[namespace] == healthy {
+		return
+	}
+	n.healthy[namespace] = healthy
+
+	notification := Notification{
+		NodeID:        n.nodeID,
+		Namespace:     namespace,
+		Healthy:       healthy,
+		Timestamp:     time.Now(),
+		FailingChecks: make(map[string]Result),
+	}
+	for name, result := range results {
+		if result.Error != nil {
+			notification.FailingChecks[name] = result
+		}
+	}
+
+	body, err := json.Marshal(notification)
+	if err != nil {
+		n.log.Error("failed to marshal health notification",
+			zap.String("namespace", namespace),
+			zap.Error(err),
+		)
+		return
+	}
+
+	for _, url := range n.config.URLs {
+		if err := n.send(url, body); err != nil {
+			n.log.Warn("failed to send health notification",
+				zap.String("namespace", namespace),
+				zap.Bool("healthy", healthy),
+				zap.String("url", url),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+func (n *WebhookNotifier) send(url string, body []byte) error {
+	request, err := http.NewRequestWithContext(n.ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := n.client.Do(request)
+	if err != nil {
+		return err
+	}
+	_ = response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+	return nil
+}
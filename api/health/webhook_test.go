@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	require := require.New(t)
+
+	notifications := make(chan Notification, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var notification Notification
+		if err := json.NewDecoder(r.Body).Decode(&notification); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		notifications <- notification
+	}))
+	defer server.Close()
+
+	var failing utils.Atomic[bool]
+	check := CheckerFunc(func(context.Context) (interface{}, error) {
+		if failing.Get() {
+			return "failing", errUnhealthy
+		}
+		return "passing", nil
+	})
+
+	h, err := New(logging.NoLog{}, DefaultConfig, prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(h.RegisterHealthCheck("check", check, "tag"))
+
+	nodeID := ids.GenerateTestNodeID()
+	n := NewWebhookNotifier(
+		logging.NoLog{},
+		nodeID,
+		WebhookConfig{
+			URLs:    []string{server.URL},
+			Timeout: time.Minute,
+		},
+		h,
+	)
+	h.RegisterListener(n)
+	go n.Dispatch()
+	defer n.Stop()
+
+	h.Start(context.Background(), checkFreq)
+	defer h.Stop()
+
+	// The node becoming healthy is notified...
+	notification := <-notifications
+	require.Equal(nodeID, notification.NodeID)
+	require.Equal("health", notification.Namespace)
+	require.True(notification.Healthy)
+	require.Empty(notification.FailingChecks)
+
+	// ...as is the node becoming unhealthy.
+	failing.Set(true)
+	notification = <-notifications
+	require.Equal("health", notification.Namespace)
+	require.False(notification.Healthy)
+	require.Contains(notification.FailingChecks, "check")
+
+	result := notification.FailingChecks["check"]
+	require.Equal(errUnhealthy.Error(), *result.Error)
+	require.Equal("failing", result.Details)
+	require.Equal([]string{"tag"}, result.Tags)
+}
@@ -16,6 +16,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/buffer"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 )
@@ -30,15 +31,20 @@ var (
 type worker struct {
 	log           logging.Logger
 	name          string
+	config        Config
 	failingChecks *prometheus.GaugeVec
 	checksLock    sync.RWMutex
 	checks        map[string]*taggedChecker
 
 	resultsLock                 sync.RWMutex
 	results                     map[string]Result
+	states                      map[string]*checkState
 	numFailingApplicationChecks int
 	tags                        map[string]set.Set[string] // tag -> set of check names
 
+	listenersLock sync.RWMutex
+	listeners     []Listener
+
 	startOnce sync.Once
 	closeOnce sync.Once
 	wg        sync.WaitGroup
@@ -51,9 +57,20 @@ type taggedChecker struct {
 	tags               []string
 }
 
+// checkState tracks what is needed, beyond the latest result of a check, to
+// decide whether the check is passing.
+type checkState struct {
+	// contiguousPasses is the number of consecutive times the check passed.
+	contiguousPasses int
+	// transitions are the most recent transitions of the check, from the
+	// oldest to the newest.
+	transitions buffer.Deque[Transition]
+}
+
 func newWorker(
 	log logging.Logger,
 	name string,
+	config Config,
 	failingChecks *prometheus.GaugeVec,
 ) *worker {
 	// Initialize the number of failing checks to 0 for all checks
@@ -66,9 +83,11 @@ func newWorker(
 	return &worker{
 		log:           log,
 		name:          name,
+		config:        config,
 		failingChecks: failingChecks,
 		checks:        make(map[string]*taggedChecker),
 		results:       make(map[string]Result),
+		states:        make(map[string]*checkState),
 		closer:        make(chan struct{}),
 		tags:          make(map[string]set.Set[string]),
 	}
@@ -109,7 +128,12 @@ func (w *worker) RegisterCheck(name string, check Checker, tags ...string) error
 		tags:               tags,
 	}
 	w.checks[name] = tc
-	w.results[name] = notYetRunResult
+	result := notYetRunResult
+	result.Tags = tags
+	w.results[name] = result
+	w.states[name] = &checkState{
+		transitions: buffer.NewUnboundedDeque[Transition](w.config.HistorySize),
+	}
 
 	// Whenever a new check is added - it is failing
 	w.log.Info("registered new check and initialized its state to failing",
@@ -140,10 +164,49 @@ func (w *worker) RegisterMonotonicCheck(name string, checker Checker, tags ...st
 	}), tags...)
 }
 
+func (w *worker) RegisterListener(listener Listener) {
+	w.listenersLock.Lock()
+	defer w.listenersLock.Unlock()
+
+	w.listeners = append(w.listeners, listener)
+}
+
 func (w *worker) Results(tags ...string) (map[string]Result, bool) {
 	w.resultsLock.RLock()
 	defer w.resultsLock.RUnlock()
 
+	names := w.names(tags)
+	results := make(map[string]Result, names.Len())
+	healthy := true
+	for name := range names {
+		if result, ok := w.results[name]; ok {
+			results[name] = result
+			healthy = healthy && result.Error == nil
+		}
+	}
+	return results, healthy
+}
+
+// History returns the recorded transitions of the checks matching [tags],
+// from the oldest to the newest.
+func (w *worker) History(tags ...string) map[string][]Transition {
+	w.resultsLock.RLock()
+	defer w.resultsLock.RUnlock()
+
+	names := w.names(tags)
+	history := make(map[string][]Transition, names.Len())
+	for name := range names {
+		if state, ok := w.states[name]; ok {
+			history[name] = state.transitions.List()
+		}
+	}
+	return history
+}
+
+// names returns the names of the checks matching [tags].
+//
+// Assumes [w.resultsLock] is held.
+func (w *worker) names(tags []string) set.Set[string] {
 	// if no tags are specified, return all checks
 	if len(tags) == 0 {
 		tags = allTags
@@ -157,16 +220,7 @@ func (w *worker) Results(tags ...string) (map[string]Result, bool) {
 			names.Union(set)
 		}
 	}
-
-	results := make(map[string]Result, names.Len())
-	healthy := true
-	for name := range names {
-		if result, ok := w.results[name]; ok {
-			results[name] = result
-			healthy = healthy && result.Error == nil
-		}
-	}
-	return results, healthy
+	return names
 }
 
 func (w *worker) Start(ctx context.Context, freq time.Duration) {
@@ -232,15 +286,20 @@ func (w *worker) runCheck(ctx context.Context, wg *sync.WaitGroup, name string,
 		Details:   details,
 		Timestamp: end,
 		Duration:  end.Sub(start),
+		Tags:      check.tags,
 	}
 
 	w.resultsLock.Lock()
-	defer w.resultsLock.Unlock()
 	prevResult := w.results[name]
-	if err != nil {
-		errString := err.Error()
-		result.Error = &errString
+	state := w.states[name]
 
+	// A check that hasn't been run yet is reported as failing, but it
+	// shouldn't need to pass multiple times to be reported as passing.
+	notYetRun := prevResult.Timestamp.IsZero()
+	wasFailing := prevResult.Error != nil
+	isFailing := wasFailing
+	if err != nil {
+		state.contiguousPasses = 0
 		result.ContiguousFailures = prevResult.ContiguousFailures + 1
 		if prevResult.ContiguousFailures > 0 {
 			result.TimeOfFirstFailure = prevResult.TimeOfFirstFailure
@@ -248,24 +307,105 @@ func (w *worker) runCheck(ctx context.Context, wg *sync.WaitGroup, name string,
 			result.TimeOfFirstFailure = &end
 		}
 
-		if prevResult.Error == nil {
+		if result.ContiguousFailures >= int64(w.config.FailureThreshold) {
+			isFailing = true
+		}
+	} else {
+		state.contiguousPasses++
+		if notYetRun || state.contiguousPasses >= w.config.SuccessThreshold {
+			isFailing = false
+		}
+	}
+
+	switch {
+	case isFailing && err != nil:
+		errString := err.Error()
+		result.Error = &errString
+	case isFailing:
+		// The check passed, but not enough times in a row to be reported as
+		// passing.
+		result.Error = prevResult.Error
+	}
+
+	changed := isFailing != wasFailing
+	if changed {
+		if isFailing {
 			w.log.Warn("check started failing",
 				zap.String("name", w.name),
 				zap.String("name", name),
 				zap.Strings("tags", check.tags),
 				zap.Error(err),
 			)
-			w.updateMetrics(check, false /*=healthy*/, false /*=register*/)
+		} else {
+			w.log.Info("check started passing",
+				zap.String("name", w.name),
+				zap.String("name", name),
+				zap.Strings("tags", check.tags),
+			)
 		}
-	} else if prevResult.Error != nil {
-		w.log.Info("check started passing",
+		w.updateMetrics(check, !isFailing /*=healthy*/, false /*=register*/)
+
+		if state.transitions.Len() >= w.config.HistorySize {
+			_, _ = state.transitions.PopLeft()
+		}
+		state.transitions.PushRight(Transition{
+			Time:    end,
+			Healthy: !isFailing,
+			Error:   result.Error,
+		})
+	}
+
+	result.Flapping = w.isFlapping(state, end)
+	if result.Flapping && !prevResult.Flapping {
+		w.log.Warn("check is flapping",
 			zap.String("name", w.name),
 			zap.String("name", name),
 			zap.Strings("tags", check.tags),
+			zap.Int("threshold", w.config.FlappingThreshold),
+			zap.Duration("window", w.config.FlappingWindow),
 		)
-		w.updateMetrics(check, true /*=healthy*/, false /*=register*/)
 	}
 	w.results[name] = result
+	w.resultsLock.Unlock()
+
+	// Listeners are notified without holding [w.resultsLock] so that they are
+	// able to query the current results.
+	if changed {
+		w.notifyListeners(name, result)
+	}
+}
+
+// isFlapping returns true if the check has transitioned at least
+// [w.config.FlappingThreshold] times within [w.config.FlappingWindow] of
+// [now].
+//
+// Assumes [w.resultsLock] is held.
+func (w *worker) isFlapping(state *checkState, now time.Time) bool {
+	if w.config.FlappingThreshold <= 0 {
+		return false
+	}
+
+	var (
+		since          = now.Add(-w.config.FlappingWindow)
+		numTransitions int
+	)
+	for i := state.transitions.Len() - 1; i >= 0; i-- {
+		transition, _ := state.transitions.Index(i)
+		if transition.Time.Before(since) {
+			break
+		}
+		numTransitions++
+	}
+	return numTransitions >= w.config.FlappingThreshold
+}
+
+func (w *worker) notifyListeners(name string, result Result) {
+	w.listenersLock.RLock()
+	defer w.listenersLock.RUnlock()
+
+	for _, listener := range w.listeners {
+		listener.OnCheckChanged(w.name, name, result)
+	}
 }
 
 // updateMetrics updates the metrics for the given check. If [healthy] is true,
@@ -26,7 +26,9 @@ type Client interface {
 	GetNetworkName(context.Context, ...rpc.Option) (string, error)
 	GetBlockchainID(context.Context, string, ...rpc.Option) (ids.ID, error)
 	Peers(context.Context, ...rpc.Option) ([]Peer, error)
+	GetBenched(context.Context, ...rpc.Option) ([]BenchedNode, error)
 	IsBootstrapped(context.Context, string, ...rpc.Option) (bool, error)
+	GetStateSyncProgress(context.Context, string, ...rpc.Option) (*GetStateSyncProgressReply, error)
 	GetTxFee(context.Context, ...rpc.Option) (*GetTxFeeResponse, error)
 	Upgrades(context.Context, ...rpc.Option) (*upgrade.Config, error)
 	Uptime(context.Context, ...rpc.Option) (*UptimeResponse, error)
@@ -89,6 +91,12 @@ func (c *client) Peers(ctx context.Context, options ...rpc.Option) ([]Peer, erro
 	return res.Peers, err
 }
 
+func (c *client) GetBenched(ctx context.Context, options ...rpc.Option) ([]BenchedNode, error) {
+	res := &GetBenchedReply{}
+	err := c.requester.SendRequest(ctx, "info.getBenched", struct{}{}, res, options...)
+	return res.Benched, err
+}
+
 func (c *client) IsBootstrapped(ctx context.Context, chainID string, options ...rpc.Option) (bool, error) {
 	res := &IsBootstrappedResponse{}
 	err := c.requester.SendRequest(ctx, "info.isBootstrapped", &IsBootstrappedArgs{
@@ -97,6 +105,14 @@ func (c *client) IsBootstrapped(ctx context.Context, chainID string, options ...
 	return res.IsBootstrapped, err
 }
 
+func (c *client) GetStateSyncProgress(ctx context.Context, chainID string, options ...rpc.Option) (*GetStateSyncProgressReply, error) {
+	res := &GetStateSyncProgressReply{}
+	err := c.requester.SendRequest(ctx, "info.getStateSyncProgress", &GetStateSyncProgressArgs{
+		Chain: chainID,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetTxFee(ctx context.Context, options ...rpc.Option) (*GetTxFeeResponse, error) {
 	res := &GetTxFeeResponse{}
 	err := c.requester.SendRequest(ctx, "info.getTxFee", struct{}{}, res, options...)
@@ -0,0 +1,226 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"context"
+	"net/netip"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/info"
+)
+
+var _ pb.InfoServer = (*GRPCServer)(nil)
+
+// GRPCServer serves the info API over gRPC. It returns the same results as the
+// JSON-RPC API.
+type GRPCServer struct {
+	pb.UnsafeInfoServer
+	info *Info
+}
+
+// NewGRPCService returns a gRPC server that serves the info API.
+func NewGRPCService(
+	parameters Parameters,
+	log logging.Logger,
+	validators validators.Manager,
+	chainManager chains.Manager,
+	vmManager vms.Manager,
+	myIP *utils.Atomic[netip.AddrPort],
+	network network.Network,
+	benchlist benchlist.Manager,
+) *grpc.Server {
+	server := grpc.NewServer()
+	pb.RegisterInfoServer(server, &GRPCServer{
+		info: newInfo(parameters, log, validators, chainManager, vmManager, myIP, network, benchlist),
+	})
+	return server
+}
+
+func (s *GRPCServer) GetNodeVersion(context.Context, *emptypb.Empty) (*pb.GetNodeVersionResponse, error) {
+	var reply GetNodeVersionReply
+	if err := s.info.GetNodeVersion(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &pb.GetNodeVersionResponse{
+		Version:            reply.Version,
+		DatabaseVersion:    reply.DatabaseVersion,
+		RpcProtocolVersion: uint32(reply.RPCProtocolVersion),
+		GitCommit:          reply.GitCommit,
+		VmVersions:         reply.VMVersions,
+	}, nil
+}
+
+func (s *GRPCServer) GetNodeID(context.Context, *emptypb.Empty) (*pb.GetNodeIDResponse, error) {
+	var reply GetNodeIDReply
+	if err := s.info.GetNodeID(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	response := &pb.GetNodeIDResponse{
+		NodeId: reply.NodeID.Bytes(),
+	}
+	if reply.NodePOP != nil {
+		response.PublicKey = reply.NodePOP.PublicKey[:]
+		response.ProofOfPossession = reply.NodePOP.ProofOfPossession[:]
+	}
+	return response, nil
+}
+
+func (s *GRPCServer) GetNetworkID(context.Context, *emptypb.Empty) (*pb.GetNetworkIDResponse, error) {
+	var (
+		idReply   GetNetworkIDReply
+		nameReply GetNetworkNameReply
+	)
+	if err := s.info.GetNetworkID(nil, nil, &idReply); err != nil {
+		return nil, err
+	}
+	if err := s.info.GetNetworkName(nil, nil, &nameReply); err != nil {
+		return nil, err
+	}
+	return &pb.GetNetworkIDResponse{
+		NetworkId:   uint32(idReply.NetworkID),
+		NetworkName: nameReply.NetworkName,
+	}, nil
+}
+
+func (s *GRPCServer) GetBlockchainID(_ context.Context, req *pb.GetBlockchainIDRequest) (*pb.GetBlockchainIDResponse, error) {
+	var reply GetBlockchainIDReply
+	err := s.info.GetBlockchainID(
+		nil,
+		&GetBlockchainIDArgs{
+			Alias: req.Alias,
+		},
+		&reply,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetBlockchainIDResponse{
+		BlockchainId: reply.BlockchainID[:],
+	}, nil
+}
+
+func (s *GRPCServer) IsBootstrapped(_ context.Context, req *pb.IsBootstrappedRequest) (*pb.IsBootstrappedResponse, error) {
+	var reply IsBootstrappedResponse
+	err := s.info.IsBootstrapped(
+		nil,
+		&IsBootstrappedArgs{
+			Chain: req.Chain,
+		},
+		&reply,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.IsBootstrappedResponse{
+		IsBootstrapped: reply.IsBootstrapped,
+	}, nil
+}
+
+func (s *GRPCServer) Peers(_ context.Context, req *pb.PeersRequest) (*pb.PeersResponse, error) {
+	nodeIDs := make([]ids.NodeID, len(req.NodeIds))
+	for i, nodeIDBytes := range req.NodeIds {
+		nodeID, err := ids.ToNodeID(nodeIDBytes)
+		if err != nil {
+			return nil, err
+		}
+		nodeIDs[i] = nodeID
+	}
+
+	var reply PeersReply
+	err := s.info.Peers(
+		nil,
+		&PeersArgs{
+			NodeIDs: nodeIDs,
+			Verbose: req.Verbose,
+		},
+		&reply,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make([]*pb.Peer, len(reply.Peers))
+	for i, peer := range reply.Peers {
+		trackedSubnets := make([][]byte, 0, peer.TrackedSubnets.Len())
+		for subnetID := range peer.TrackedSubnets {
+			trackedSubnets = append(trackedSubnets, subnetID[:])
+		}
+		peers[i] = &pb.Peer{
+			NodeId:         peer.ID.Bytes(),
+			Ip:             addrPortString(peer.IP),
+			PublicIp:       addrPortString(peer.PublicIP),
+			Version:        peer.Version,
+			LastSent:       timestamppb.New(peer.LastSent),
+			LastReceived:   timestamppb.New(peer.LastReceived),
+			ObservedUptime: uint32(peer.ObservedUptime),
+			TrackedSubnets: trackedSubnets,
+			SupportedAcps:  peer.SupportedACPs.List(),
+			ObjectedAcps:   peer.ObjectedACPs.List(),
+			Benched:        peer.Benched,
+			Stats:          newPBPeerStats(peer.Stats),
+		}
+	}
+	return &pb.PeersResponse{
+		Peers: peers,
+	}, nil
+}
+
+// newPBPeerStats returns the protobuf representation of [stats], or nil if
+// [stats] is nil.
+func newPBPeerStats(stats *peer.Stats) *pb.PeerStats {
+	if stats == nil {
+		return nil
+	}
+
+	ops := make(map[string]*pb.OpStats, len(stats.Ops))
+	for op, opStats := range stats.Ops {
+		ops[op] = &pb.OpStats{
+			MessagesSent:     uint64(opStats.MessagesSent),
+			BytesSent:        uint64(opStats.BytesSent),
+			MessagesReceived: uint64(opStats.MessagesReceived),
+			BytesReceived:    uint64(opStats.BytesReceived),
+		}
+	}
+	pingRTTs := make([]*durationpb.Duration, len(stats.PingRTTs))
+	for i, rtt := range stats.PingRTTs {
+		pingRTTs[i] = durationpb.New(rtt)
+	}
+	return &pb.PeerStats{
+		Inbound:                  stats.Inbound,
+		ConnectedAt:              timestamppb.New(stats.ConnectedAt),
+		ConnectionAge:            durationpb.New(stats.ConnectionAge),
+		MessagesSent:             uint64(stats.MessagesSent),
+		BytesSent:                uint64(stats.BytesSent),
+		MessagesReceived:         uint64(stats.MessagesReceived),
+		BytesReceived:            uint64(stats.BytesReceived),
+		Ops:                      ops,
+		PingRtts:                 pingRTTs,
+		SendQueueLength:          uint64(stats.SendQueueLength),
+		InboundThrottlerWaitTime: durationpb.New(stats.InboundThrottlerWaitTime),
+	}
+}
+
+// addrPortString returns the string representation of [addrPort], or an empty
+// string if it isn't set.
+func addrPortString(addrPort netip.AddrPort) string {
+	if !addrPort.IsValid() {
+		return ""
+	}
+	return addrPort.String()
+}
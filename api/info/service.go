@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/netip"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 	"go.uber.org/zap"
@@ -72,20 +73,33 @@ func NewService(
 	server.RegisterCodec(codec, "application/json")
 	server.RegisterCodec(codec, "application/json;charset=UTF-8")
 	return server, server.RegisterService(
-		&Info{
-			Parameters:   parameters,
-			log:          log,
-			validators:   validators,
-			chainManager: chainManager,
-			vmManager:    vmManager,
-			myIP:         myIP,
-			networking:   network,
-			benchlist:    benchlist,
-		},
+		newInfo(parameters, log, validators, chainManager, vmManager, myIP, network, benchlist),
 		"info",
 	)
 }
 
+func newInfo(
+	parameters Parameters,
+	log logging.Logger,
+	validators validators.Manager,
+	chainManager chains.Manager,
+	vmManager vms.Manager,
+	myIP *utils.Atomic[netip.AddrPort],
+	network network.Network,
+	benchlist benchlist.Manager,
+) *Info {
+	return &Info{
+		Parameters:   parameters,
+		log:          log,
+		validators:   validators,
+		chainManager: chainManager,
+		vmManager:    vmManager,
+		myIP:         myIP,
+		networking:   network,
+		benchlist:    benchlist,
+	}
+}
+
 // GetNodeVersionReply are the results from calling GetNodeVersion
 type GetNodeVersionReply struct {
 	Version            string            `json:"version"`
@@ -206,6 +220,8 @@ func (i *Info) GetBlockchainID(_ *http.Request, args *GetBlockchainIDArgs, reply
 // PeersArgs are the arguments for calling Peers
 type PeersArgs struct {
 	NodeIDs []ids.NodeID `json:"nodeIDs"`
+	// Verbose includes the statistics of the connections to the peers.
+	Verbose bool `json:"verbose"`
 }
 
 type Peer struct {
@@ -227,11 +243,16 @@ func (i *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply) error
 	i.log.Debug("API called",
 		zap.String("service", "info"),
 		zap.String("method", "peers"),
+		zap.Bool("verbose", args.Verbose),
 	)
 
 	peers := i.networking.PeerInfo(args.NodeIDs)
 	peerInfo := make([]Peer, len(peers))
 	for index, peer := range peers {
+		if !args.Verbose {
+			peer.Stats = nil
+		}
+
 		benchedIDs := i.benchlist.GetBenched(peer.ID)
 		benchedAliases := make([]string, len(benchedIDs))
 		for idx, id := range benchedIDs {
@@ -252,6 +273,52 @@ func (i *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply) error
 	return nil
 }
 
+// BenchedNode describes a node that is benched on a chain
+type BenchedNode struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// Primary alias of the chain the node is benched on
+	Chain   string `json:"chain"`
+	ChainID ids.ID `json:"chainID"`
+	// Time the node was benched
+	StartTime time.Time `json:"startTime"`
+	// Time the node will be removed from the benchlist
+	EndTime time.Time `json:"endTime"`
+	// Number of consecutive failed queries that caused the node to be benched.
+	// Zero if the node was benched manually.
+	Failures json.Uint64 `json:"failures"`
+}
+
+// GetBenchedReply are the results from calling GetBenched
+type GetBenchedReply struct {
+	Benched []BenchedNode `json:"benched"`
+}
+
+// GetBenched returns every node that is currently benched on any chain
+func (i *Info) GetBenched(_ *http.Request, _ *struct{}, reply *GetBenchedReply) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "getBenched"),
+	)
+
+	benched := i.benchlist.GetAllBenched()
+	reply.Benched = make([]BenchedNode, len(benched))
+	for index, node := range benched {
+		alias, err := i.chainManager.PrimaryAlias(node.ChainID)
+		if err != nil {
+			return fmt.Errorf("failed to get primary alias for chain ID %s: %w", node.ChainID, err)
+		}
+		reply.Benched[index] = BenchedNode{
+			NodeID:    node.NodeID,
+			Chain:     alias,
+			ChainID:   node.ChainID,
+			StartTime: node.Start,
+			EndTime:   node.End,
+			Failures:  json.Uint64(node.Failures),
+		}
+	}
+	return nil
+}
+
 // IsBootstrappedArgs are the arguments for calling IsBootstrapped
 type IsBootstrappedArgs struct {
 	// Alias of the chain
@@ -285,6 +352,53 @@ func (i *Info) IsBootstrapped(_ *http.Request, args *IsBootstrappedArgs, reply *
 	return nil
 }
 
+// GetStateSyncProgressArgs are the arguments for calling GetStateSyncProgress
+type GetStateSyncProgressArgs struct {
+	// Alias of the chain
+	// Can also be the string representation of the chain's ID
+	Chain string `json:"chain"`
+}
+
+// GetStateSyncProgressReply are the results from calling GetStateSyncProgress
+type GetStateSyncProgressReply struct {
+	// True iff the chain is currently state syncing
+	Syncing       bool          `json:"syncing"`
+	SummaryHeight json.Uint64   `json:"summaryHeight"`
+	BytesSynced   json.Uint64   `json:"bytesSynced"`
+	LeavesSynced  json.Uint64   `json:"leavesSynced"`
+	ETA           time.Duration `json:"eta"`
+}
+
+// GetStateSyncProgress returns the progress of [args.Chain]'s state sync.
+// Returns an error if the chain doesn't exist or its VM doesn't report its
+// state sync progress.
+func (i *Info) GetStateSyncProgress(r *http.Request, args *GetStateSyncProgressArgs, reply *GetStateSyncProgressReply) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "getStateSyncProgress"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	if args.Chain == "" {
+		return errNoChainProvided
+	}
+	chainID, err := i.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return fmt.Errorf("there is no chain with alias/ID '%s'", args.Chain)
+	}
+	progress, err := i.chainManager.StateSyncProgress(r.Context(), chainID)
+	if err != nil {
+		return err
+	}
+
+	reply.Syncing = progress.Syncing
+	reply.SummaryHeight = json.Uint64(progress.SummaryHeight)
+	reply.BytesSynced = json.Uint64(progress.BytesSynced)
+	reply.LeavesSynced = json.Uint64(progress.LeavesSynced)
+	reply.ETA = progress.ETA
+	return nil
+}
+
 // Upgrades returns the upgrade schedule this node is running.
 func (i *Info) Upgrades(_ *http.Request, _ *struct{}, reply *upgrade.Config) error {
 	i.log.Debug("API called",
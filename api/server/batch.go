@@ -0,0 +1,166 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/ava-labs/avalanchego/api"
+)
+
+var _ http.ResponseWriter = (*batchEntryWriter)(nil)
+
+// batchHandler serves JSON-RPC batches by passing each call of the batch to
+// its handler as a separate request, so that handlers that only support
+// single calls, including the handlers of plugin VMs, support batches.
+//
+// The calls are made in order, and their responses are returned in the same
+// order. Requests that aren't batches are passed to the handler unchanged.
+type batchHandler struct {
+	handler      http.Handler
+	maxBatchSize int
+}
+
+func newBatchHandler(handler http.Handler, maxBatchSize int) http.Handler {
+	return &batchHandler{
+		handler:      handler,
+		maxBatchSize: maxBatchSize,
+	}
+}
+
+func (b *batchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !api.IsJSONRequest(r) {
+		b.handler.ServeHTTP(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "couldn't read request", http.StatusBadRequest)
+		return
+	}
+	trimmed := bytes.TrimSpace(body)
+	if !bytes.HasPrefix(trimmed, []byte("[")) {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		b.handler.ServeHTTP(w, r)
+		return
+	}
+
+	// The batch is parsed the same way that it was parsed when it was
+	// authorized and limited, so that every call that is made was checked.
+	calls, _, _, err := api.ParseCalls(body)
+	if err != nil {
+		code := json2.E_INVALID_REQ
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+			code = json2.E_PARSE
+		}
+		writeBatchResponse(w, newBatchError(nil, code, err.Error()))
+		return
+	}
+	if len(calls) > b.maxBatchSize {
+		writeBatchResponse(w, newBatchError(
+			nil,
+			json2.E_INVALID_REQ,
+			fmt.Sprintf("batch has %d calls but the limit is %d", len(calls), b.maxBatchSize),
+		))
+		return
+	}
+
+	responses := make([]json.RawMessage, 0, len(calls))
+	for _, call := range calls {
+		if response, ok := b.serveCall(r, call); ok {
+			responses = append(responses, response)
+		}
+	}
+	// Notifications aren't answered, so if every call was a notification,
+	// nothing is returned.
+	if len(responses) == 0 {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	writeBatchResponse(w, responses)
+}
+
+// serveCall passes [call] to the handler as a copy of [r], and returns its
+// response. Returns false if the call wasn't answered.
+func (b *batchHandler) serveCall(r *http.Request, call json.RawMessage) (json.RawMessage, bool) {
+	callRequest := r.Clone(r.Context())
+	callRequest.Body = io.NopCloser(bytes.NewReader(call))
+	callRequest.ContentLength = int64(len(call))
+
+	writer := &batchEntryWriter{
+		header: make(http.Header),
+	}
+	b.handler.ServeHTTP(writer, callRequest)
+
+	response := bytes.TrimSpace(writer.body.Bytes())
+	if len(response) == 0 {
+		return nil, false
+	}
+	if bytes.HasPrefix(response, []byte("{")) && json.Valid(response) {
+		return response, true
+	}
+
+	// The handler rejected the call without a JSON-RPC response, e.g. because
+	// its chain is bootstrapping, so its error is converted into one.
+	var id struct {
+		ID json.RawMessage `json:"id"`
+	}
+	_ = json.Unmarshal(call, &id)
+	errResponse, err := json.Marshal(newBatchError(id.ID, json2.E_INTERNAL, string(response)))
+	if err != nil {
+		return nil, false
+	}
+	return errResponse, true
+}
+
+// batchError is a JSON-RPC response with an error.
+type batchError struct {
+	Version string          `json:"jsonrpc"`
+	Error   *json2.Error    `json:"error"`
+	ID      json.RawMessage `json:"id"`
+}
+
+func newBatchError(id json.RawMessage, code json2.ErrorCode, message string) *batchError {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &batchError{
+		Version: json2.Version,
+		Error: &json2.Error{
+			Code:    code,
+			Message: message,
+		},
+		ID: id,
+	}
+}
+
+func writeBatchResponse(w http.ResponseWriter, response interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// batchEntryWriter buffers the response to a call of a batch.
+type batchEntryWriter struct {
+	header http.Header
+	body   bytes.Buffer
+}
+
+func (w *batchEntryWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *batchEntryWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (*batchEntryWriter) WriteHeader(int) {}
@@ -0,0 +1,148 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/rpc/v2"
+	"github.com/stretchr/testify/require"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+var errOdd = errors.New("odd number")
+
+type BatchTestService struct{}
+
+type BatchTestArgs struct {
+	Number int `json:"number"`
+}
+
+type BatchTestReply struct {
+	Number int `json:"number"`
+}
+
+func (*BatchTestService) Double(_ *http.Request, args *BatchTestArgs, reply *BatchTestReply) error {
+	reply.Number = 2 * args.Number
+	return nil
+}
+
+func (*BatchTestService) Halve(_ *http.Request, args *BatchTestArgs, reply *BatchTestReply) error {
+	if args.Number%2 != 0 {
+		return errOdd
+	}
+	reply.Number = args.Number / 2
+	return nil
+}
+
+func newBatchTestHandler(t *testing.T) http.Handler {
+	server := rpc.NewServer()
+	server.RegisterCodec(avajson.NewCodec(), "application/json")
+	server.RegisterCodec(avajson.NewCodec(), "application/json;charset=UTF-8")
+	require.NoError(t, server.RegisterService(&BatchTestService{}, "test"))
+	return newBatchHandler(server, 3)
+}
+
+func TestBatchHandler(t *testing.T) {
+	tests := []struct {
+		name             string
+		body             string
+		expectedResponse string
+	}{
+		{
+			name:             "single call",
+			body:             `{"jsonrpc": "2.0", "method": "test.double", "params": {"number": 2}, "id": 1}`,
+			expectedResponse: `{"jsonrpc": "2.0", "result": {"number": 4}, "id": 1}`,
+		},
+		{
+			name: "batch",
+			body: `[
+				{"jsonrpc": "2.0", "method": "test.double", "params": {"number": 2}, "id": 1},
+				{"jsonrpc": "2.0", "method": "test.halve", "params": {"number": 3}, "id": "two"},
+				{"jsonrpc": "2.0", "method": "test.halve", "params": {"number": 4}, "id": 3}
+			]`,
+			expectedResponse: `[
+				{"jsonrpc": "2.0", "result": {"number": 4}, "id": 1},
+				{"jsonrpc": "2.0", "error": {"code": -32000, "message": "odd number", "data": null}, "id": "two"},
+				{"jsonrpc": "2.0", "result": {"number": 2}, "id": 3}
+			]`,
+		},
+		{
+			name: "batch with notification",
+			body: `[
+				{"jsonrpc": "2.0", "method": "test.double", "params": {"number": 2}},
+				{"jsonrpc": "2.0", "method": "test.double", "params": {"number": 3}, "id": 2}
+			]`,
+			expectedResponse: `[
+				{"jsonrpc": "2.0", "result": {"number": 6}, "id": 2}
+			]`,
+		},
+		{
+			name:             "empty batch",
+			body:             `[]`,
+			expectedResponse: `{"jsonrpc": "2.0", "error": {"code": -32600, "message": "invalid JSON-RPC request: empty batch", "data": null}, "id": null}`,
+		},
+		{
+			name: "batch with invalid call",
+			body: `[
+				{"jsonrpc": "2.0", "method": "test.double", "params": {"number": 1}, "id": 1},
+				1
+			]`,
+			expectedResponse: `{"jsonrpc": "2.0", "error": {"code": -32600, "message": "call 1: invalid JSON-RPC request: json: cannot unmarshal number into Go value of type api.Call", "data": null}, "id": null}`,
+		},
+		{
+			name:             "invalid batch",
+			body:             `[{"jsonrpc": "2.0", "method": "test.double"`,
+			expectedResponse: `{"jsonrpc": "2.0", "error": {"code": -32700, "message": "invalid JSON-RPC request: unexpected EOF", "data": null}, "id": null}`,
+		},
+		{
+			name: "batch too large",
+			body: `[
+				{"jsonrpc": "2.0", "method": "test.double", "params": {"number": 1}, "id": 1},
+				{"jsonrpc": "2.0", "method": "test.double", "params": {"number": 2}, "id": 2},
+				{"jsonrpc": "2.0", "method": "test.double", "params": {"number": 3}, "id": 3},
+				{"jsonrpc": "2.0", "method": "test.double", "params": {"number": 4}, "id": 4}
+			]`,
+			expectedResponse: `{"jsonrpc": "2.0", "error": {"code": -32600, "message": "batch has 4 calls but the limit is 3", "data": null}, "id": null}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			handler := newBatchTestHandler(t)
+			r := httptest.NewRequest(http.MethodPost, "/ext/test", strings.NewReader(test.body))
+			r.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			require.Equal(http.StatusOK, w.Code)
+			require.JSONEq(test.expectedResponse, w.Body.String())
+		})
+	}
+}
+
+func TestBatchHandlerNonJSONResponse(t *testing.T) {
+	require := require.New(t)
+
+	handler := newBatchHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "chain is bootstrapping", http.StatusServiceUnavailable)
+		}),
+		10,
+	)
+	r := httptest.NewRequest(http.MethodPost, "/ext/test", strings.NewReader(`[{"jsonrpc": "2.0", "method": "test.double", "id": 1}]`))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	require.Equal(http.StatusOK, w.Code)
+	require.JSONEq(
+		`[{"jsonrpc": "2.0", "error": {"code": -32603, "message": "chain is bootstrapping", "data": null}, "id": 1}]`,
+		w.Body.String(),
+	)
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const grpcContentType = "application/grpc"
+
+var errDuplicateGRPCService = errors.New("duplicate gRPC service")
+
+// GRPCHandler is a handler that serves gRPC services, such as a *grpc.Server.
+//
+// gRPC calls are made to the paths of their services, so a GRPCHandler is
+// served at the paths of its services on the node's HTTP port, regardless of
+// the route it is added at.
+type GRPCHandler interface {
+	http.Handler
+	GetServiceInfo() map[string]grpc.ServiceInfo
+}
+
+// grpcRouter routes gRPC calls to the handlers of their services.
+type grpcRouter struct {
+	lock     sync.RWMutex
+	services map[string]http.Handler
+}
+
+func newGRPCRouter() *grpcRouter {
+	return &grpcRouter{
+		services: make(map[string]http.Handler),
+	}
+}
+
+func (r *grpcRouter) addServices(services []string, handler http.Handler) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, service := range services {
+		if _, ok := r.services[service]; ok {
+			return fmt.Errorf("%w: %s", errDuplicateGRPCService, service)
+		}
+	}
+	for _, service := range services {
+		r.services[service] = handler
+	}
+	return nil
+}
+
+func (r *grpcRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// gRPC calls are made to /<service>/<method>.
+	service, _, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/"), "/")
+
+	r.lock.RLock()
+	handler, ok := r.services[service]
+	r.lock.RUnlock()
+
+	if !ok {
+		w.Header().Set("Content-Type", grpcContentType)
+		w.Header().Set("Grpc-Status", strconv.Itoa(int(codes.Unimplemented)))
+		w.Header().Set("Grpc-Message", "unknown service "+service)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	handler.ServeHTTP(w, req)
+}
+
+// serveGRPC passes the gRPC calls to [grpcHandler] and every other request to
+// [handler].
+func serveGRPC(grpcHandler http.Handler, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isGRPCRequest(r) {
+			grpcHandler.ServeHTTP(w, r)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func isGRPCRequest(r *http.Request) bool {
+	return r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), grpcContentType)
+}
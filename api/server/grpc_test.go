@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	infopb "github.com/ava-labs/avalanchego/proto/pb/info"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func newHealthGRPCServer() *grpc.Server {
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	return server
+}
+
+func TestGRPC(t *testing.T) {
+	require := require.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+
+	s, err := New(
+		logging.NoLog{},
+		nil,
+		listener,
+		[]string{"*"},
+		time.Second,
+		ids.EmptyNodeID,
+		false,
+		trace.Noop,
+		prometheus.NewRegistry(),
+		HTTPConfig{
+			GRPCEnabled: true,
+		},
+		[]string{"*"},
+		nil,
+	)
+	require.NoError(err)
+
+	require.NoError(s.AddRoute(newHealthGRPCServer(), "health", "/grpc"))
+	require.NoError(s.AddRoute(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		}),
+		"health",
+		"",
+	))
+
+	// Services can only be served once.
+	err = s.AddRoute(newHealthGRPCServer(), "other", "/grpc")
+	require.ErrorIs(err, errDuplicateGRPCService)
+
+	go func() {
+		_ = s.Dispatch()
+	}()
+	defer func() {
+		require.NoError(s.Shutdown())
+	}()
+
+	conn, err := grpc.Dial(
+		listener.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(err)
+	defer conn.Close()
+
+	ctx := context.Background()
+	response, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(err)
+	require.Equal(healthpb.HealthCheckResponse_SERVING, response.Status)
+
+	_, err = infopb.NewInfoClient(conn).GetNodeID(ctx, nil)
+	require.Equal(codes.Unimplemented, status.Code(err))
+
+	// Other requests are still routed by their path.
+	httpResponse, err := http.Get("http://" + listener.Addr().String() + "/ext/health")
+	require.NoError(err)
+	defer httpResponse.Body.Close()
+	body, err := io.ReadAll(httpResponse.Body)
+	require.NoError(err)
+	require.Equal("ok", string(body))
+}
@@ -0,0 +1,453 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	defaultMaxClients = 10_000
+	defaultMethodCost = 1
+
+	// limitedErrorCode is the JSON-RPC error code of the calls that are
+	// rejected by the limits. It's the code that Ethereum clients use when a
+	// limit is exceeded.
+	limitedErrorCode = -32005
+
+	// otherMethod labels the metrics of the actions that aren't configured, so
+	// that callers can't create arbitrarily many metrics.
+	otherMethod = "other"
+
+	resultAccepted          = "accepted"
+	resultClientRateLimited = "client_rate_limited"
+	resultMethodRateLimited = "method_rate_limited"
+	resultTooManyInFlight   = "too_many_in_flight"
+	resultBodyTooLarge      = "body_too_large"
+	resultCostTooHigh       = "cost_too_high"
+	resultInvalidRequest    = "invalid_request"
+)
+
+var (
+	rejectionMessages = map[string]string{
+		resultClientRateLimited: "client rate limit exceeded",
+		resultMethodRateLimited: "method rate limit exceeded",
+		resultTooManyInFlight:   "too many requests in flight",
+		resultBodyTooLarge:      "request body too large",
+		resultCostTooHigh:       "request cost exceeds the rate limit burst",
+	}
+
+	errNegativeLimit = errors.New("limit can't be negative")
+	errZeroBurst     = errors.New("burst must be positive")
+	errCostTooHigh   = errors.New("cost exceeds the client burst")
+)
+
+// LimitsConfig limits the API calls that the server processes. The zero value
+// doesn't limit any calls.
+//
+// Calls are described by their actions, as returned by [api.Actions].
+type LimitsConfig struct {
+	// MaxRequestBodySize is the maximum size, in bytes, of a request body. If
+	// 0, the size isn't limited.
+	MaxRequestBodySize int64 `json:"maxRequestBodySize"`
+	// MaxInFlightRequests is the maximum number of requests that are processed
+	// at once. WebSocket upgrades aren't counted, as their handlers don't
+	// return until the connection is closed. If 0, the number isn't limited.
+	MaxInFlightRequests int `json:"maxInFlightRequests"`
+	// Client, if provided, limits the cost of the actions that each client IP
+	// performs.
+	Client *RateLimit `json:"client"`
+	// MaxClients is the number of client IPs whose rate limits are tracked.
+	// The least recently seen clients are forgotten first. If 0, 10,000
+	// clients are tracked.
+	MaxClients int `json:"maxClients"`
+	// Methods configures the actions that aren't performed with the default
+	// cost of 1, or that are rate limited across every client.
+	Methods map[string]MethodLimit `json:"methods"`
+}
+
+// RateLimit is a token bucket that is refilled with Rate tokens per second and
+// that holds at most Burst tokens.
+type RateLimit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+type MethodLimit struct {
+	// Cost is the number of tokens that an action takes from the bucket of
+	// its client. If nil, the cost is 1.
+	Cost *int `json:"cost"`
+	// RateLimit, if provided, limits the number of times that the action is
+	// performed across every client.
+	*RateLimit
+}
+
+// ParseLimitsConfig parses [b] as a LimitsConfig and verifies it.
+func ParseLimitsConfig(b []byte) (*LimitsConfig, error) {
+	config := &LimitsConfig{}
+	if err := json.Unmarshal(b, config); err != nil {
+		return nil, fmt.Errorf("couldn't parse limits config: %w", err)
+	}
+	return config, config.Verify()
+}
+
+func (c *LimitsConfig) Verify() error {
+	switch {
+	case c.MaxRequestBodySize < 0:
+		return fmt.Errorf("%w: maxRequestBodySize", errNegativeLimit)
+	case c.MaxInFlightRequests < 0:
+		return fmt.Errorf("%w: maxInFlightRequests", errNegativeLimit)
+	case c.MaxClients < 0:
+		return fmt.Errorf("%w: maxClients", errNegativeLimit)
+	}
+	if err := c.Client.verify(); err != nil {
+		return fmt.Errorf("invalid client limit: %w", err)
+	}
+
+	for method, limit := range c.Methods {
+		if err := limit.RateLimit.verify(); err != nil {
+			return fmt.Errorf("invalid limit for %q: %w", method, err)
+		}
+		cost := limit.cost()
+		switch {
+		case cost < 0:
+			return fmt.Errorf("%w: cost of %q", errNegativeLimit, method)
+		case c.Client != nil && cost > c.Client.Burst:
+			return fmt.Errorf("%w: cost of %q is %d but burst is %d", errCostTooHigh, method, cost, c.Client.Burst)
+		}
+	}
+	return nil
+}
+
+func (r *RateLimit) verify() error {
+	switch {
+	case r == nil:
+		return nil
+	case r.Rate < 0:
+		return fmt.Errorf("%w: rate", errNegativeLimit)
+	case r.Burst <= 0:
+		return errZeroBurst
+	default:
+		return nil
+	}
+}
+
+func (m MethodLimit) cost() int {
+	if m.Cost == nil {
+		return defaultMethodCost
+	}
+	return *m.Cost
+}
+
+// limiter rejects the API calls that exceed its limits before they are
+// passed to its handler.
+type limiter struct {
+	log     logging.Logger
+	config  *LimitsConfig
+	handler http.Handler
+	now     func() time.Time
+	calls   *prometheus.CounterVec
+
+	numInFlight atomic.Int64
+
+	// clientsLock is held while a client's limiter is created, so that only
+	// one limiter is created per client.
+	clientsLock sync.Mutex
+	clients     cache.LRU[string, *rate.Limiter]
+
+	methods map[string]*rate.Limiter
+}
+
+func newLimiter(
+	log logging.Logger,
+	config *LimitsConfig,
+	registerer prometheus.Registerer,
+	handler http.Handler,
+) (*limiter, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	maxClients := config.MaxClients
+	if maxClients == 0 {
+		maxClients = defaultMaxClients
+	}
+	l := &limiter{
+		log:     log,
+		config:  config,
+		handler: handler,
+		now:     time.Now,
+		calls: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "limited_calls",
+				Help: "The number of calls that were checked against the API limits",
+			},
+			[]string{"method", "result"},
+		),
+		clients: cache.LRU[string, *rate.Limiter]{Size: maxClients},
+		methods: make(map[string]*rate.Limiter),
+	}
+	for method, limit := range config.Methods {
+		if limit.RateLimit != nil {
+			l.methods[method] = newRateLimiter(limit.RateLimit)
+		}
+	}
+	return l, registerer.Register(l.calls)
+}
+
+func newRateLimiter(limit *RateLimit) *rate.Limiter {
+	return rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)
+}
+
+func (l *limiter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	maxBodySize := l.config.MaxRequestBodySize
+	if maxBodySize > 0 {
+		if r.ContentLength > maxBodySize {
+			l.reject(w, r, nil, false, []string{otherMethod}, resultBodyTooLarge, http.StatusRequestEntityTooLarge, 0)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	}
+
+	calls, batch, err := api.ReadCalls(r)
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		l.reject(w, r, nil, false, []string{otherMethod}, resultBodyTooLarge, http.StatusRequestEntityTooLarge, 0)
+		return
+	case errors.Is(err, api.ErrInvalidCalls):
+		// The cost of the request isn't known, so it can't be served.
+		l.calls.WithLabelValues(otherMethod, resultInvalidRequest).Inc()
+		api.WriteInvalidCallsError(w, err)
+		return
+	case err != nil:
+		http.Error(w, "couldn't read request", http.StatusBadRequest)
+		return
+	}
+	actions := api.Actions(r, calls)
+	methods := make([]string, len(actions))
+	for i, action := range actions {
+		methods[i] = l.methodLabel(action)
+	}
+
+	// Long-lived WebSocket connections would otherwise use up the in-flight
+	// requests.
+	maxInFlight := int64(l.config.MaxInFlightRequests)
+	if maxInFlight > 0 && !websocket.IsWebSocketUpgrade(r) {
+		if l.numInFlight.Add(1) > maxInFlight {
+			l.numInFlight.Add(-1)
+			l.reject(w, r, calls, batch, methods, resultTooManyInFlight, http.StatusServiceUnavailable, time.Second)
+			return
+		}
+		defer l.numInFlight.Add(-1)
+	}
+
+	if result, retryAfter, ok := l.reserve(r, actions); !ok {
+		statusCode := http.StatusTooManyRequests
+		if result == resultCostTooHigh {
+			// Retrying the request would never succeed.
+			statusCode = http.StatusBadRequest
+		}
+		l.reject(w, r, calls, batch, methods, result, statusCode, retryAfter)
+		return
+	}
+
+	for _, method := range methods {
+		l.calls.WithLabelValues(method, resultAccepted).Inc()
+	}
+	l.handler.ServeHTTP(w, r)
+}
+
+// reserve takes the tokens needed to perform [actions] from the client's and
+// the methods' buckets. If any bucket doesn't have enough tokens, no tokens
+// are taken, and the result and the time until the tokens are available are
+// returned. If any bucket could never hold enough tokens, e.g. because a batch
+// is too expensive, [resultCostTooHigh] is returned.
+func (l *limiter) reserve(r *http.Request, actions []string) (string, time.Duration, bool) {
+	var (
+		now        = l.now()
+		cost       int
+		numActions = make(map[string]int)
+	)
+	for _, action := range actions {
+		cost += l.config.Methods[action].cost()
+		if _, ok := l.methods[action]; ok {
+			numActions[action]++
+		}
+	}
+
+	if l.config.Client != nil && cost > l.config.Client.Burst {
+		return resultCostTooHigh, 0, false
+	}
+	for action, n := range numActions {
+		if n > l.methods[action].Burst() {
+			return resultCostTooHigh, 0, false
+		}
+	}
+
+	var reservations []*rate.Reservation
+	cancel := func() {
+		for _, reservation := range reservations {
+			reservation.CancelAt(now)
+		}
+	}
+
+	if l.config.Client != nil && cost > 0 {
+		reservation := l.clientLimiter(r).ReserveN(now, cost)
+		reservations = append(reservations, reservation)
+		if delay, ok := reservationDelay(reservation, now); !ok {
+			cancel()
+			return resultClientRateLimited, delay, false
+		}
+	}
+	for action, n := range numActions {
+		reservation := l.methods[action].ReserveN(now, n)
+		reservations = append(reservations, reservation)
+		if delay, ok := reservationDelay(reservation, now); !ok {
+			cancel()
+			return resultMethodRateLimited, delay, false
+		}
+	}
+	return resultAccepted, 0, true
+}
+
+// reservationDelay returns true if [reservation] can be acted on at [now].
+// Otherwise, it returns how long to wait until it could be, or 0 if it never
+// could be.
+func reservationDelay(reservation *rate.Reservation, now time.Time) (time.Duration, bool) {
+	if !reservation.OK() {
+		return 0, false
+	}
+	delay := reservation.DelayFrom(now)
+	return delay, delay == 0
+}
+
+func (l *limiter) clientLimiter(r *http.Request) *rate.Limiter {
+	client, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		client = r.RemoteAddr
+	}
+
+	l.clientsLock.Lock()
+	defer l.clientsLock.Unlock()
+
+	if clientLimiter, ok := l.clients.Get(client); ok {
+		return clientLimiter
+	}
+	clientLimiter := newRateLimiter(l.config.Client)
+	l.clients.Put(client, clientLimiter)
+	return clientLimiter
+}
+
+func (l *limiter) methodLabel(action string) string {
+	if _, ok := l.config.Methods[action]; ok {
+		return action
+	}
+	return otherMethod
+}
+
+// reject writes an error that explains why the request was rejected. If the
+// request is a JSON-RPC request, every call is answered with a JSON-RPC error.
+//
+// If [retryAfter] is positive, the client is told to retry after it.
+func (l *limiter) reject(
+	w http.ResponseWriter,
+	r *http.Request,
+	calls []api.Call,
+	batch bool,
+	methods []string,
+	result string,
+	statusCode int,
+	retryAfter time.Duration,
+) {
+	for _, method := range methods {
+		l.calls.WithLabelValues(method, result).Inc()
+	}
+	l.log.Debug("rejecting API call",
+		zap.String("reason", result),
+		zap.String("remoteAddr", r.RemoteAddr),
+		zap.Strings("methods", methods),
+		zap.Duration("retryAfter", retryAfter),
+	)
+
+	var retryAfterSeconds int
+	if retryAfter > 0 {
+		retryAfterSeconds = int(math.Ceil(retryAfter.Seconds()))
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+	}
+
+	message := rejectionMessages[result]
+	if len(calls) == 0 {
+		http.Error(w, message, statusCode)
+		return
+	}
+
+	// Every call is rejected with the same error.
+	responses := make([]limitedResponse, len(calls))
+	for i, call := range calls {
+		id := call.ID
+		if len(id) == 0 {
+			id = json.RawMessage("null")
+		}
+		responses[i] = limitedResponse{
+			JSONRPC: "2.0",
+			Error: limitedError{
+				Code:    limitedErrorCode,
+				Message: message,
+			},
+			ID: id,
+		}
+		if retryAfterSeconds > 0 {
+			responses[i].Error.Data = &limitedErrorData{
+				RetryAfterSeconds: retryAfterSeconds,
+			}
+		}
+	}
+	var response interface{} = responses
+	if !batch {
+		response = responses[0]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		l.log.Debug("failed to write rejection",
+			zap.Error(err),
+		)
+	}
+}
+
+type limitedResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Error   limitedError    `json:"error"`
+	ID      json.RawMessage `json:"id"`
+}
+
+type limitedError struct {
+	Code    int               `json:"code"`
+	Message string            `json:"message"`
+	Data    *limitedErrorData `json:"data,omitempty"`
+}
+
+type limitedErrorData struct {
+	RetryAfterSeconds int `json:"retryAfterSeconds"`
+}
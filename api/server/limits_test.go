@@ -0,0 +1,294 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestParseLimitsConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			config: `{
+				"maxRequestBodySize": 1024,
+				"maxInFlightRequests": 16,
+				"client": {"rate": 10, "burst": 20},
+				"methods": {
+					"eth_getLogs": {"cost": 10},
+					"platform.issueTx": {"rate": 1, "burst": 5}
+				}
+			}`,
+		},
+		{
+			name:        "negative body size",
+			config:      `{"maxRequestBodySize": -1}`,
+			expectedErr: errNegativeLimit,
+		},
+		{
+			name:        "zero client burst",
+			config:      `{"client": {"rate": 10}}`,
+			expectedErr: errZeroBurst,
+		},
+		{
+			name:        "negative method rate",
+			config:      `{"methods": {"info.peers": {"rate": -1, "burst": 1}}}`,
+			expectedErr: errNegativeLimit,
+		},
+		{
+			name:        "negative cost",
+			config:      `{"methods": {"info.peers": {"cost": -1}}}`,
+			expectedErr: errNegativeLimit,
+		},
+		{
+			name: "cost exceeds client burst",
+			config: `{
+				"client": {"rate": 1, "burst": 5},
+				"methods": {"eth_getLogs": {"cost": 10}}
+			}`,
+			expectedErr: errCostTooHigh,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseLimitsConfig([]byte(test.config))
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestLimiterRateLimits(t *testing.T) {
+	require := require.New(t)
+
+	cost := 3
+	config := &LimitsConfig{
+		Client: &RateLimit{
+			Rate:  1,
+			Burst: 5,
+		},
+		Methods: map[string]MethodLimit{
+			"eth_getLogs": {
+				Cost: &cost,
+			},
+			"platform.issueTx": {
+				RateLimit: &RateLimit{
+					Rate:  1,
+					Burst: 1,
+				},
+			},
+		},
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	l, err := newLimiter(logging.NoLog{}, config, prometheus.NewRegistry(), handler)
+	require.NoError(err)
+	now := time.Now()
+	l.now = func() time.Time {
+		return now
+	}
+
+	serve := func(remoteAddr string, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/ext/bc/C/rpc", strings.NewReader(body))
+		r.RemoteAddr = remoteAddr
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		l.ServeHTTP(w, r)
+		return w
+	}
+
+	// The first client can afford an expensive call and a cheap one.
+	require.Equal(http.StatusOK, serve("1.1.1.1:1", `{"method": "eth_getLogs", "id": 1}`).Code)
+	require.Equal(http.StatusOK, serve("1.1.1.1:2", `{"method": "eth_blockNumber", "id": 2}`).Code)
+
+	// But then it must wait for its bucket to refill.
+	w := serve("1.1.1.1:1", `{"method": "eth_getLogs", "id": 3}`)
+	require.Equal(http.StatusTooManyRequests, w.Code)
+	require.Equal("2", w.Header().Get("Retry-After"))
+
+	var response limitedResponse
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	require.Equal(limitedErrorCode, response.Error.Code)
+	require.Equal(rejectionMessages[resultClientRateLimited], response.Error.Message)
+	require.Equal(&limitedErrorData{RetryAfterSeconds: 2}, response.Error.Data)
+	require.JSONEq("3", string(response.ID))
+
+	// A rejected call doesn't take tokens, so a cheaper call is still served.
+	require.Equal(http.StatusOK, serve("1.1.1.1:1", `{"method": "eth_blockNumber", "id": 4}`).Code)
+
+	// Other clients have their own bucket.
+	require.Equal(http.StatusOK, serve("2.2.2.2:1", `{"method": "eth_getLogs", "id": 5}`).Code)
+
+	// Method rate limits are shared by every client.
+	require.Equal(http.StatusOK, serve("3.3.3.3:1", `{"method": "platform.issueTx", "id": 6}`).Code)
+	w = serve("4.4.4.4:1", `[{"method": "platform.issueTx", "id": 7}, {"method": "eth_blockNumber"}]`)
+	require.Equal(http.StatusTooManyRequests, w.Code)
+
+	// Every call of a batch is rejected.
+	var responses []limitedResponse
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &responses))
+	require.Len(responses, 2)
+	require.Equal(rejectionMessages[resultMethodRateLimited], responses[0].Error.Message)
+	require.JSONEq("7", string(responses[0].ID))
+	require.JSONEq("null", string(responses[1].ID))
+
+	// Once the buckets refill, calls are served again.
+	now = now.Add(3 * time.Second)
+	require.Equal(http.StatusOK, serve("1.1.1.1:1", `{"method": "eth_getLogs", "id": 8}`).Code)
+	require.Equal(http.StatusOK, serve("4.4.4.4:1", `{"method": "platform.issueTx", "id": 9}`).Code)
+
+	require.Equal(3.0, testutil.ToFloat64(l.calls.WithLabelValues("eth_getLogs", resultAccepted)))
+	require.Equal(1.0, testutil.ToFloat64(l.calls.WithLabelValues("eth_getLogs", resultClientRateLimited)))
+	require.Equal(2.0, testutil.ToFloat64(l.calls.WithLabelValues(otherMethod, resultAccepted)))
+	require.Equal(1.0, testutil.ToFloat64(l.calls.WithLabelValues(otherMethod, resultMethodRateLimited)))
+	require.Equal(1.0, testutil.ToFloat64(l.calls.WithLabelValues("platform.issueTx", resultMethodRateLimited)))
+
+	// A batch that costs more than the burst could never be served.
+	w = serve("5.5.5.5:1", `[{"method": "eth_getLogs", "id": 10}, {"method": "eth_getLogs", "id": 11}]`)
+	require.Equal(http.StatusBadRequest, w.Code)
+	require.Empty(w.Header().Get("Retry-After"))
+	var costlyResponses []limitedResponse
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &costlyResponses))
+	require.Len(costlyResponses, 2)
+	require.Equal(rejectionMessages[resultCostTooHigh], costlyResponses[0].Error.Message)
+	require.Nil(costlyResponses[0].Error.Data)
+	require.Equal(http.StatusOK, serve("5.5.5.5:1", `{"method": "eth_getLogs", "id": 12}`).Code)
+
+	// Calls whose methods aren't known can't be costed, so they are rejected.
+	w = serve("6.6.6.6:1", `[{"method": "eth_getLogs", "id": 13}, 1]`)
+	require.Equal(http.StatusBadRequest, w.Code)
+	require.Equal(1.0, testutil.ToFloat64(l.calls.WithLabelValues(otherMethod, resultInvalidRequest)))
+}
+
+func TestLimiterMaxRequestBodySize(t *testing.T) {
+	require := require.New(t)
+
+	config := &LimitsConfig{
+		MaxRequestBodySize: 64,
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	l, err := newLimiter(logging.NoLog{}, config, prometheus.NewRegistry(), handler)
+	require.NoError(err)
+
+	r := httptest.NewRequest(http.MethodPost, "/ext/info", strings.NewReader(`{"method": "info.getNodeID"}`))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	l.ServeHTTP(w, r)
+	require.Equal(http.StatusOK, w.Code)
+
+	body := `{"method": "info.getNodeID", "params": {"padding": "` + strings.Repeat("0", 64) + `"}}`
+
+	r = httptest.NewRequest(http.MethodPost, "/ext/info", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	l.ServeHTTP(w, r)
+	require.Equal(http.StatusRequestEntityTooLarge, w.Code)
+
+	// The body is limited even if its length isn't known in advance.
+	r = httptest.NewRequest(http.MethodPost, "/ext/info", strings.NewReader(body))
+	r.ContentLength = -1
+	r.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	l.ServeHTTP(w, r)
+	require.Equal(http.StatusRequestEntityTooLarge, w.Code)
+
+	require.Equal(2.0, testutil.ToFloat64(l.calls.WithLabelValues(otherMethod, resultBodyTooLarge)))
+}
+
+func TestLimiterMaxInFlightRequests(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		config = &LimitsConfig{
+			MaxInFlightRequests: 1,
+		}
+		started = make(chan struct{})
+		release = make(chan struct{})
+		handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			close(started)
+			<-release
+			w.WriteHeader(http.StatusOK)
+		})
+	)
+	l, err := newLimiter(logging.NoLog{}, config, prometheus.NewRegistry(), handler)
+	require.NoError(err)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		l.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ext/health", nil))
+	}()
+	<-started
+
+	w := httptest.NewRecorder()
+	l.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ext/health", nil))
+	require.Equal(http.StatusServiceUnavailable, w.Code)
+	require.Equal("1", w.Header().Get("Retry-After"))
+
+	close(release)
+	wg.Wait()
+	require.Zero(l.numInFlight.Load())
+}
+
+func TestLimiterMaxInFlightRequestsIgnoresWebSockets(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		config = &LimitsConfig{
+			MaxInFlightRequests: 1,
+		}
+		connected  = make(chan struct{})
+		disconnect = make(chan struct{})
+		handler    = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if websocket.IsWebSocketUpgrade(r) {
+				// The handler of a WebSocket returns once it's disconnected.
+				close(connected)
+				<-disconnect
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+	)
+	l, err := newLimiter(logging.NoLog{}, config, prometheus.NewRegistry(), handler)
+	require.NoError(err)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r := httptest.NewRequest(http.MethodGet, "/ext/events", nil)
+		r.Header.Set("Connection", "Upgrade")
+		r.Header.Set("Upgrade", "websocket")
+		l.ServeHTTP(httptest.NewRecorder(), r)
+	}()
+	<-connected
+
+	// Requests are still served while the WebSocket is connected.
+	w := httptest.NewRecorder()
+	l.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ext/health", nil))
+	require.Equal(http.StatusOK, w.Code)
+
+	close(disconnect)
+	wg.Wait()
+	require.Zero(l.numInFlight.Load())
+}
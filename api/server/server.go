@@ -16,9 +16,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/cors"
 	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
 	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/auth"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
@@ -74,6 +77,13 @@ type HTTPConfig struct {
 	ReadHeaderTimeout time.Duration `json:"readHeaderTimeout"`
 	WriteTimeout      time.Duration `json:"writeHeaderTimeout"`
 	IdleTimeout       time.Duration `json:"idleTimeout"`
+	// MaxBatchSize is the maximum number of calls in a JSON-RPC batch. If 0,
+	// batches are passed to the handlers unchanged.
+	MaxBatchSize int `json:"maxBatchSize"`
+	// GRPCEnabled serves the routes whose handler is a GRPCHandler.
+	GRPCEnabled bool `json:"grpcEnabled"`
+	// Limits, if provided, limits the API calls that are processed.
+	Limits *LimitsConfig `json:"limits,omitempty"`
 }
 
 type server struct {
@@ -89,8 +99,12 @@ type server struct {
 
 	metrics *metrics
 
+	maxBatchSize int
+
 	// Maps endpoints to handlers
 	router *router
+	// Maps gRPC services to handlers. Nil if gRPC is disabled.
+	grpcRouter *grpcRouter
 
 	srv *http.Server
 
@@ -111,6 +125,7 @@ func New(
 	registerer prometheus.Registerer,
 	httpConfig HTTPConfig,
 	allowedHosts []string,
+	authConfig *auth.Config,
 ) (Server, error) {
 	m, err := newMetrics(registerer)
 	if err != nil {
@@ -118,7 +133,28 @@ func New(
 	}
 
 	router := newRouter()
-	allowedHostsHandler := filterInvalidHosts(router, allowedHosts)
+	var (
+		routerHandler http.Handler = router
+		grpcRouter    *grpcRouter
+	)
+	if httpConfig.GRPCEnabled {
+		grpcRouter = newGRPCRouter()
+		routerHandler = serveGRPC(grpcRouter, routerHandler)
+	}
+	if authConfig != nil {
+		a, err := auth.New(log, authConfig)
+		if err != nil {
+			return nil, err
+		}
+		routerHandler = a.Wrap(routerHandler)
+	}
+	if httpConfig.Limits != nil {
+		routerHandler, err = newLimiter(log, httpConfig.Limits, registerer, routerHandler)
+		if err != nil {
+			return nil, err
+		}
+	}
+	allowedHostsHandler := filterInvalidHosts(routerHandler, allowedHosts)
 	corsHandler := cors.New(cors.Options{
 		AllowedOrigins:   allowedOrigins,
 		AllowCredentials: true,
@@ -131,6 +167,13 @@ func New(
 			gzipHandler.ServeHTTP(w, r)
 		},
 	)
+	if httpConfig.GRPCEnabled {
+		// gRPC requires HTTP/2, which is only negotiated over TLS, so HTTP/2
+		// is also accepted without TLS.
+		handler = h2c.NewHandler(handler, &http2.Server{
+			MaxConcurrentStreams: maxConcurrentStreams,
+		})
+	}
 
 	httpServer := &http.Server{
 		Handler:           handler,
@@ -148,6 +191,9 @@ func New(
 
 	log.Info("API created",
 		zap.Strings("allowedOrigins", allowedOrigins),
+		zap.Bool("authEnabled", authConfig != nil),
+		zap.Bool("limitsEnabled", httpConfig.Limits != nil),
+		zap.Bool("grpcEnabled", httpConfig.GRPCEnabled),
 	)
 
 	return &server{
@@ -157,7 +203,9 @@ func New(
 		tracingEnabled:  tracingEnabled,
 		tracer:          tracer,
 		metrics:         m,
+		maxBatchSize:    httpConfig.MaxBatchSize,
 		router:          router,
+		grpcRouter:      grpcRouter,
 		srv:             httpServer,
 		listener:        listener,
 	}, nil
@@ -206,17 +254,24 @@ func (s *server) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 }
 
 func (s *server) addChainRoute(chainName string, handler http.Handler, ctx *snow.ConsensusContext, base, endpoint string) error {
+	grpcServices, isGRPC := grpcServiceNames(handler)
 	url := fmt.Sprintf("%s/%s", baseURL, base)
-	s.log.Info("adding route",
-		zap.String("url", url),
-		zap.String("endpoint", endpoint),
-	)
+	if !isGRPC {
+		s.log.Info("adding route",
+			zap.String("url", url),
+			zap.String("endpoint", endpoint),
+		)
+	}
 	if s.tracingEnabled {
 		handler = api.TraceHandler(handler, chainName, s.tracer)
 	}
 	// Apply middleware to reject calls to the handler before the chain finishes bootstrapping
 	handler = rejectMiddleware(handler, ctx)
 	handler = s.metrics.wrapHandler(chainName, handler)
+	if isGRPC {
+		return s.addGRPCServices(grpcServices, handler)
+	}
+	handler = s.wrapBatches(handler)
 	return s.router.AddRouter(url, endpoint, handler)
 }
 
@@ -231,20 +286,61 @@ func (s *server) AddRouteWithReadLock(handler http.Handler, base, endpoint strin
 }
 
 func (s *server) addRoute(handler http.Handler, base, endpoint string) error {
+	grpcServices, isGRPC := grpcServiceNames(handler)
 	url := fmt.Sprintf("%s/%s", baseURL, base)
-	s.log.Info("adding route",
-		zap.String("url", url),
-		zap.String("endpoint", endpoint),
-	)
+	if !isGRPC {
+		s.log.Info("adding route",
+			zap.String("url", url),
+			zap.String("endpoint", endpoint),
+		)
+	}
 
 	if s.tracingEnabled {
 		handler = api.TraceHandler(handler, url, s.tracer)
 	}
 
 	handler = s.metrics.wrapHandler(base, handler)
+	if isGRPC {
+		return s.addGRPCServices(grpcServices, handler)
+	}
+	handler = s.wrapBatches(handler)
 	return s.router.AddRouter(url, endpoint, handler)
 }
 
+// grpcServiceNames returns the names of the services served by [handler], and
+// true if [handler] is a GRPCHandler.
+func grpcServiceNames(handler http.Handler) ([]string, bool) {
+	grpcHandler, ok := handler.(GRPCHandler)
+	if !ok {
+		return nil, false
+	}
+	return maps.Keys(grpcHandler.GetServiceInfo()), true
+}
+
+func (s *server) addGRPCServices(services []string, handler http.Handler) error {
+	if s.grpcRouter == nil {
+		s.log.Debug("skipping gRPC services because gRPC is disabled",
+			zap.Strings("services", services),
+		)
+		return nil
+	}
+
+	s.log.Info("adding gRPC services",
+		zap.Strings("services", services),
+	)
+	return s.grpcRouter.addServices(services, handler)
+}
+
+// wrapBatches splits the JSON-RPC batches sent to [handler] into single calls,
+// so that the batches are supported by every handler, and every call is
+// measured separately.
+func (s *server) wrapBatches(handler http.Handler) http.Handler {
+	if s.maxBatchSize <= 0 {
+		return handler
+	}
+	return newBatchHandler(handler, s.maxBatchSize)
+}
+
 // Reject middleware wraps a handler. If the chain that the context describes is
 // not done state-syncing/bootstrapping, writes back an error.
 func rejectMiddleware(handler http.Handler, ctx *snow.ConsensusContext) http.Handler {
@@ -105,6 +105,7 @@ var (
 	errCreatePlatformVM        = errors.New("attempted to create a chain running the PlatformVM")
 	errNotBootstrapped         = errors.New("subnets not bootstrapped")
 	errPartialSyncAsAValidator = errors.New("partial sync should not be configured for a validator")
+	errUnknownChain            = errors.New("unknown chain")
 
 	fxs = map[ids.ID]fx.Factory{
 		secp256k1fx.ID: &secp256k1fx.Factory{},
@@ -143,6 +144,11 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// Returns the state sync progress of the chain with the given ID. If the
+	// chain's VM doesn't report its progress,
+	// [block.ErrStateSyncProgressNotImplemented] is returned.
+	StateSyncProgress(context.Context, ids.ID) (block.StateSyncProgress, error)
+
 	// Starts the chain creator with the initial platform chain parameters, must
 	// be called once.
 	StartChainCreator(platformChain ChainParameters) error
@@ -193,6 +199,7 @@ type ManagerConfig struct {
 	LogFactory                logging.Factory
 	VMManager                 vms.Manager // Manage mappings from vm ID --> vm
 	BlockAcceptorGroup        snow.AcceptorGroup
+	BlockRejector             snow.Rejector // May be nil
 	TxAcceptorGroup           snow.AcceptorGroup
 	VertexAcceptorGroup       snow.AcceptorGroup
 	DB                        database.Database
@@ -223,6 +230,8 @@ type ManagerConfig struct {
 
 	FrontierPollFrequency   time.Duration
 	ConsensusAppConcurrency int
+	// MessagePriorities defines how the messages of each chain are scheduled
+	MessagePriorities handler.PriorityConfig
 
 	// Max Time to spend fetching a container and its
 	// ancestors when responding to a GetAncestors
@@ -530,6 +539,7 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 		PrimaryAlias:   primaryAlias,
 		Registerer:     snowmanMetrics,
 		BlockAcceptor:  m.BlockAcceptorGroup,
+		BlockRejector:  m.BlockRejector,
 		TxAcceptor:     m.TxAcceptorGroup,
 		VertexAcceptor: m.VertexAcceptorGroup,
 	}
@@ -758,12 +768,14 @@ func (m *manager) createAvalancheChain(
 
 	// Initialize the ProposerVM and the vm wrapped inside it
 	var (
-		minBlockDelay       = proposervm.DefaultMinBlockDelay
-		numHistoricalBlocks = proposervm.DefaultNumHistoricalBlocks
+		minBlockDelay        = proposervm.DefaultMinBlockDelay
+		numHistoricalBlocks  = proposervm.DefaultNumHistoricalBlocks
+		metricsMaxValidators int
 	)
 	if subnetCfg, ok := m.SubnetConfigs[ctx.SubnetID]; ok {
 		minBlockDelay = subnetCfg.ProposerMinBlockDelay
 		numHistoricalBlocks = subnetCfg.ProposerNumHistoricalBlocks
+		metricsMaxValidators = int(subnetCfg.ProposerMetricsMaxValidators)
 	}
 	m.Log.Info("creating proposervm wrapper",
 		zap.Time("activationTime", m.Upgrades.ApricotPhase4Time),
@@ -791,12 +803,13 @@ func (m *manager) createAvalancheChain(
 	proposerVM := proposervm.New(
 		vmWrappedInsideProposerVM,
 		proposervm.Config{
-			Upgrades:            m.Upgrades,
-			MinBlkDelay:         minBlockDelay,
-			NumHistoricalBlocks: numHistoricalBlocks,
-			StakingLeafSigner:   m.StakingTLSSigner,
-			StakingCertLeaf:     m.StakingTLSCert,
-			Registerer:          proposervmReg,
+			Upgrades:             m.Upgrades,
+			MinBlkDelay:          minBlockDelay,
+			NumHistoricalBlocks:  numHistoricalBlocks,
+			StakingLeafSigner:    m.StakingTLSSigner,
+			StakingCertLeaf:      m.StakingTLSCert,
+			MetricsMaxValidators: metricsMaxValidators,
+			Registerer:           proposervmReg,
 		},
 	)
 
@@ -897,6 +910,7 @@ func (m *manager) createAvalancheChain(
 		msgChan,
 		m.FrontierPollFrequency,
 		m.ConsensusAppConcurrency,
+		m.MessagePriorities,
 		m.ResourceTracker,
 		sb,
 		connectedValidators,
@@ -1157,12 +1171,14 @@ func (m *manager) createSnowmanChain(
 	}
 
 	var (
-		minBlockDelay       = proposervm.DefaultMinBlockDelay
-		numHistoricalBlocks = proposervm.DefaultNumHistoricalBlocks
+		minBlockDelay        = proposervm.DefaultMinBlockDelay
+		numHistoricalBlocks  = proposervm.DefaultNumHistoricalBlocks
+		metricsMaxValidators int
 	)
 	if subnetCfg, ok := m.SubnetConfigs[ctx.SubnetID]; ok {
 		minBlockDelay = subnetCfg.ProposerMinBlockDelay
 		numHistoricalBlocks = subnetCfg.ProposerNumHistoricalBlocks
+		metricsMaxValidators = int(subnetCfg.ProposerMetricsMaxValidators)
 	}
 	m.Log.Info("creating proposervm wrapper",
 		zap.Time("activationTime", m.Upgrades.ApricotPhase4Time),
@@ -1186,12 +1202,13 @@ func (m *manager) createSnowmanChain(
 	proposerVM := proposervm.New(
 		vm,
 		proposervm.Config{
-			Upgrades:            m.Upgrades,
-			MinBlkDelay:         minBlockDelay,
-			NumHistoricalBlocks: numHistoricalBlocks,
-			StakingLeafSigner:   m.StakingTLSSigner,
-			StakingCertLeaf:     m.StakingTLSCert,
-			Registerer:          proposervmReg,
+			Upgrades:             m.Upgrades,
+			MinBlkDelay:          minBlockDelay,
+			NumHistoricalBlocks:  numHistoricalBlocks,
+			StakingLeafSigner:    m.StakingTLSSigner,
+			StakingCertLeaf:      m.StakingTLSCert,
+			MetricsMaxValidators: metricsMaxValidators,
+			Registerer:           proposervmReg,
 		},
 	)
 
@@ -1291,6 +1308,7 @@ func (m *manager) createSnowmanChain(
 		msgChan,
 		m.FrontierPollFrequency,
 		m.ConsensusAppConcurrency,
+		m.MessagePriorities,
 		m.ResourceTracker,
 		sb,
 		connectedValidators,
@@ -1434,6 +1452,25 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 	return chain.Context().State.Get().State == snow.NormalOp
 }
 
+func (m *manager) StateSyncProgress(ctx context.Context, id ids.ID) (block.StateSyncProgress, error) {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[id]
+	m.chainsLock.Unlock()
+	if !exists {
+		return block.StateSyncProgress{}, fmt.Errorf("%w: %s", errUnknownChain, id)
+	}
+
+	engine := chain.GetEngineManager().Snowman
+	if engine == nil {
+		return block.StateSyncProgress{}, block.ErrStateSyncProgressNotImplemented
+	}
+	reporter, ok := engine.StateSyncer.(block.StateSyncProgressReporter)
+	if !ok {
+		return block.StateSyncProgress{}, block.ErrStateSyncProgressNotImplemented
+	}
+	return reporter.StateSyncProgress(ctx)
+}
+
 func (m *manager) registerBootstrappedHealthChecks() error {
 	bootstrappedCheck := health.CheckerFunc(func(context.Context) (interface{}, error) {
 		if subnetIDs := m.Subnets.Bootstrapping(); len(subnetIDs) != 0 {
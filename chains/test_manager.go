@@ -3,7 +3,12 @@
 
 package chains
 
-import "github.com/ava-labs/avalanchego/ids"
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
 
 // TestManager implements Manager but does nothing. Always returns nil error.
 // To be used only in tests
@@ -49,6 +54,10 @@ func (testManager) IsBootstrapped(ids.ID) bool {
 	return false
 }
 
+func (testManager) StateSyncProgress(context.Context, ids.ID) (block.StateSyncProgress, error) {
+	return block.StateSyncProgress{}, nil
+}
+
 func (testManager) Lookup(s string) (ids.ID, error) {
 	return ids.FromString(s)
 }
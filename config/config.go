@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"io/fs"
 	"math"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -18,6 +19,8 @@ import (
 
 	"github.com/spf13/viper"
 
+	"github.com/ava-labs/avalanchego/api/auth"
+	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/genesis"
@@ -28,6 +31,7 @@ import (
 	"github.com/ava-labs/avalanchego/node"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/staking"
@@ -171,12 +175,63 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 		}
 	}
 
+	var authBytes []byte
+	switch {
+	case v.IsSet(HTTPAuthContentKey):
+		rawContent := v.GetString(HTTPAuthContentKey)
+		authBytes, err = base64.StdEncoding.DecodeString(rawContent)
+		if err != nil {
+			return node.HTTPConfig{}, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.GetString(HTTPAuthFileKey) != "":
+		authFilepath := GetExpandedArg(v, HTTPAuthFileKey)
+		authBytes, err = os.ReadFile(filepath.Clean(authFilepath))
+		if err != nil {
+			return node.HTTPConfig{}, err
+		}
+	}
+
+	var authConfig *auth.Config
+	if authBytes != nil {
+		authConfig, err = auth.ParseConfig(authBytes)
+		if err != nil {
+			return node.HTTPConfig{}, err
+		}
+	}
+
+	var limitsBytes []byte
+	switch {
+	case v.IsSet(HTTPLimitsContentKey):
+		rawContent := v.GetString(HTTPLimitsContentKey)
+		limitsBytes, err = base64.StdEncoding.DecodeString(rawContent)
+		if err != nil {
+			return node.HTTPConfig{}, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.GetString(HTTPLimitsFileKey) != "":
+		limitsFilepath := GetExpandedArg(v, HTTPLimitsFileKey)
+		limitsBytes, err = os.ReadFile(filepath.Clean(limitsFilepath))
+		if err != nil {
+			return node.HTTPConfig{}, err
+		}
+	}
+
+	var limitsConfig *server.LimitsConfig
+	if limitsBytes != nil {
+		limitsConfig, err = server.ParseLimitsConfig(limitsBytes)
+		if err != nil {
+			return node.HTTPConfig{}, err
+		}
+	}
+
 	return node.HTTPConfig{
 		HTTPConfig: server.HTTPConfig{
 			ReadTimeout:       v.GetDuration(HTTPReadTimeoutKey),
 			ReadHeaderTimeout: v.GetDuration(HTTPReadHeaderTimeoutKey),
 			WriteTimeout:      v.GetDuration(HTTPWriteTimeoutKey),
 			IdleTimeout:       v.GetDuration(HTTPIdleTimeoutKey),
+			MaxBatchSize:      int(v.GetUint(HTTPMaxBatchSizeKey)),
+			GRPCEnabled:       v.GetBool(HTTPGRPCEnabledKey),
+			Limits:            limitsConfig,
 		},
 		APIConfig: node.APIConfig{
 			APIIndexerConfig: node.APIIndexerConfig{
@@ -184,6 +239,7 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 				IndexAllowIncomplete: v.GetBool(IndexAllowIncompleteKey),
 			},
 			AdminAPIEnabled:    v.GetBool(AdminAPIEnabledKey),
+			EventsAPIEnabled:   v.GetBool(EventsAPIEnabledKey),
 			InfoAPIEnabled:     v.GetBool(InfoAPIEnabledKey),
 			KeystoreAPIEnabled: v.GetBool(KeystoreAPIEnabledKey),
 			MetricsAPIEnabled:  v.GetBool(MetricsAPIEnabledKey),
@@ -196,6 +252,8 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 		HTTPSCert:          httpsCert,
 		HTTPAllowedOrigins: v.GetStringSlice(HTTPAllowedOrigins),
 		HTTPAllowedHosts:   v.GetStringSlice(HTTPAllowedHostsKey),
+		HTTPAuthEnabled:    authConfig != nil,
+		HTTPAuthConfig:     authConfig,
 		ShutdownTimeout:    v.GetDuration(HTTPShutdownTimeoutKey),
 		ShutdownWait:       v.GetDuration(HTTPShutdownWaitKey),
 	}, nil
@@ -220,6 +278,37 @@ func getRouterHealthConfig(v *viper.Viper, halflife time.Duration) (router.Healt
 	return config, nil
 }
 
+func getHealthConfig(v *viper.Viper) (health.Config, error) {
+	config := health.Config{
+		FailureThreshold:  int(v.GetUint(HealthCheckFailureThresholdKey)),
+		SuccessThreshold:  int(v.GetUint(HealthCheckSuccessThresholdKey)),
+		HistorySize:       int(v.GetUint(HealthCheckHistorySizeKey)),
+		FlappingThreshold: int(v.GetUint(HealthCheckFlappingThresholdKey)),
+		FlappingWindow:    v.GetDuration(HealthCheckFlappingWindowKey),
+	}
+	return config, config.Verify()
+}
+
+func getHealthWebhookConfig(v *viper.Viper) (health.WebhookConfig, error) {
+	config := health.WebhookConfig{
+		URLs:    v.GetStringSlice(HealthWebhookURLsKey),
+		Timeout: v.GetDuration(HealthWebhookTimeoutKey),
+	}
+	if config.Timeout <= 0 {
+		return health.WebhookConfig{}, fmt.Errorf("%q must be positive", HealthWebhookTimeoutKey)
+	}
+	for _, rawURL := range config.URLs {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return health.WebhookConfig{}, fmt.Errorf("invalid %q: %w", HealthWebhookURLsKey, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return health.WebhookConfig{}, fmt.Errorf("%q must be http or https URLs but got %q", HealthWebhookURLsKey, rawURL)
+		}
+	}
+	return config, nil
+}
+
 func getAdaptiveTimeoutConfig(v *viper.Viper) (timer.AdaptiveTimeoutConfig, error) {
 	config := timer.AdaptiveTimeoutConfig{
 		InitialTimeout:     v.GetDuration(NetworkInitialTimeoutKey),
@@ -381,6 +470,7 @@ func getNetworkConfig(
 		RequireValidatorToConnect: v.GetBool(NetworkRequireValidatorToConnectKey),
 		PeerReadBufferSize:        int(v.GetUint(NetworkPeerReadBufferSizeKey)),
 		PeerWriteBufferSize:       int(v.GetUint(NetworkPeerWriteBufferSizeKey)),
+		PeerMetricsMaxPeers:       int(v.GetUint(NetworkPeerMetricsMaxPeersKey)),
 	}
 
 	switch {
@@ -443,6 +533,23 @@ func getBenchlistConfig(v *viper.Viper, consensusParameters snowball.Parameters)
 	return config, nil
 }
 
+func getConsensusPriorityConfig(v *viper.Viper) (handler.PriorityConfig, error) {
+	opPriorities, err := handler.ParseOpPriorities(v.GetStringMapString(ConsensusPriorityOpsKey))
+	if err != nil {
+		return handler.PriorityConfig{}, fmt.Errorf("couldn't parse %q: %w", ConsensusPriorityOpsKey, err)
+	}
+	config := handler.PriorityConfig{
+		ConsensusWeight: v.GetUint64(ConsensusPriorityConsensusWeightKey),
+		BootstrapWeight: v.GetUint64(ConsensusPriorityBootstrapWeightKey),
+		AppWeight:       v.GetUint64(ConsensusPriorityAppWeightKey),
+		OpPriorities:    opPriorities,
+	}
+	if err := config.Verify(); err != nil {
+		return handler.PriorityConfig{}, fmt.Errorf("invalid consensus priority config: %w", err)
+	}
+	return config, nil
+}
+
 func getStateSyncConfig(v *viper.Viper) (node.StateSyncConfig, error) {
 	var (
 		config       = node.StateSyncConfig{}
@@ -1287,6 +1394,12 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, fmt.Errorf("%s must be > 0", ConsensusAppConcurrencyKey)
 	}
 
+	// Message priorities
+	nodeConfig.ConsensusPriorityConfig, err = getConsensusPriorityConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	nodeConfig.UseCurrentHeight = v.GetBool(ProposerVMUseCurrentHeightKey)
 
 	// Logging
@@ -1341,6 +1454,14 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 	if healthCheckAveragerHalflife <= 0 {
 		return node.Config{}, fmt.Errorf("%s must be positive", HealthCheckAveragerHalflifeKey)
 	}
+	nodeConfig.HealthConfig, err = getHealthConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+	nodeConfig.HealthWebhookConfig, err = getHealthWebhookConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
 
 	// Router
 	nodeConfig.RouterHealthConfig, err = getRouterHealthConfig(v, healthCheckAveragerHalflife)
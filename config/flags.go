@@ -13,11 +13,13 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/database/leveldb"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/pebbledb"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -186,6 +188,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Bool(NetworkRequireValidatorToConnectKey, constants.DefaultNetworkRequireValidatorToConnect, "If true, this node will only maintain a connection with another node if this node is a validator, the other node is a validator, or the other node is a beacon")
 	fs.Uint(NetworkPeerReadBufferSizeKey, constants.DefaultNetworkPeerReadBufferSize, "Size, in bytes, of the buffer that we read peer messages into (there is one buffer per peer)")
 	fs.Uint(NetworkPeerWriteBufferSizeKey, constants.DefaultNetworkPeerWriteBufferSize, "Size, in bytes, of the buffer that we write peer messages into (there is one buffer per peer)")
+	fs.Uint(NetworkPeerMetricsMaxPeersKey, 0, "Maximum number of peers whose connection stats are reported as metrics labelled by their node IDs. If 0, per-peer metrics are disabled")
 
 	fs.Bool(NetworkTCPProxyEnabledKey, constants.DefaultNetworkTCPProxyEnabled, "Require all P2P connections to be initiated with a TCP proxy header")
 	// The PROXY protocol specification recommends setting this value to be at
@@ -206,6 +209,10 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Uint(ConsensusAppConcurrencyKey, constants.DefaultConsensusAppConcurrency, "Maximum number of goroutines to use when handling App messages on a chain")
 	fs.Duration(ConsensusShutdownTimeoutKey, constants.DefaultConsensusShutdownTimeout, "Timeout before killing an unresponsive chain")
 	fs.Duration(ConsensusFrontierPollFrequencyKey, constants.DefaultFrontierPollFrequency, "Frequency of polling for new consensus frontiers")
+	fs.Uint64(ConsensusPriorityConsensusWeightKey, handler.DefaultPriorityConfig.ConsensusWeight, "Relative share of handled messages given to consensus messages when a chain has messages of multiple priorities pending")
+	fs.Uint64(ConsensusPriorityBootstrapWeightKey, handler.DefaultPriorityConfig.BootstrapWeight, "Relative share of handled messages given to bootstrapping messages when a chain has messages of multiple priorities pending")
+	fs.Uint64(ConsensusPriorityAppWeightKey, handler.DefaultPriorityConfig.AppWeight, "Relative share of handled messages given to app messages when a chain has messages of multiple priorities pending")
+	fs.StringToString(ConsensusPriorityOpsKey, map[string]string{}, "Overrides the priority of the provided ops. Priorities are one of consensus, bootstrap or app")
 
 	// Inbound Throttling
 	fs.Uint64(InboundThrottlerAtLargeAllocSizeKey, constants.DefaultInboundThrottlerAtLargeAllocSize, "Size, in bytes, of at-large byte allocation in inbound message throttler")
@@ -230,6 +237,10 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.String(HTTPSKeyContentKey, "", "Specifies base64 encoded TLS private key for the HTTPs server")
 	fs.String(HTTPSCertFileKey, "", fmt.Sprintf("TLS certificate file for the HTTPs server. Ignored if %s is specified", HTTPSCertContentKey))
 	fs.String(HTTPSCertContentKey, "", "Specifies base64 encoded TLS certificate for the HTTPs server")
+	fs.String(HTTPAuthFileKey, "", fmt.Sprintf("Specifies a JSON file that configures the authentication of API calls. If empty, API calls aren't authenticated. Ignored if %s is specified", HTTPAuthContentKey))
+	fs.String(HTTPAuthContentKey, "", "Specifies base64 encoded JSON content that configures the authentication of API calls")
+	fs.String(HTTPLimitsFileKey, "", fmt.Sprintf("Specifies a JSON file that configures the rate limits of API calls. If empty, API calls aren't limited. Ignored if %s is specified", HTTPLimitsContentKey))
+	fs.String(HTTPLimitsContentKey, "", "Specifies base64 encoded JSON content that configures the rate limits of API calls")
 	fs.String(HTTPAllowedOrigins, "*", "Origins to allow on the HTTP port. Defaults to * which allows all origins. Example: https://*.avax.network https://*.avax-test.network")
 	fs.StringSlice(HTTPAllowedHostsKey, []string{"localhost"}, "List of acceptable host names in API requests. Provide the wildcard ('*') to accept requests from all hosts. API requests where the Host field is empty or an IP address will always be accepted. An API call whose HTTP Host field isn't acceptable will receive a 403 error code")
 	fs.Duration(HTTPShutdownWaitKey, 0, "Duration to wait after receiving SIGTERM or SIGINT before initiating shutdown. The /health endpoint will return unhealthy during this duration")
@@ -238,9 +249,12 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(HTTPReadHeaderTimeoutKey, 30*time.Second, fmt.Sprintf("Maximum duration to read request headers. The connection's read deadline is reset after reading the headers. If %s is zero, the value of %s is used. If both are zero, there is no timeout.", HTTPReadHeaderTimeoutKey, HTTPReadTimeoutKey))
 	fs.Duration(HTTPWriteTimeoutKey, 30*time.Second, "Maximum duration before timing out writes of the response. It is reset whenever a new request's header is read. A zero or negative value means there will be no timeout.")
 	fs.Duration(HTTPIdleTimeoutKey, 120*time.Second, fmt.Sprintf("Maximum duration to wait for the next request when keep-alives are enabled. If %s is zero, the value of %s is used. If both are zero, there is no timeout.", HTTPIdleTimeoutKey, HTTPReadTimeoutKey))
+	fs.Uint(HTTPMaxBatchSizeKey, 100, "Maximum number of calls in a JSON-RPC batch. If 0, JSON-RPC batches are passed to the APIs unchanged")
+	fs.Bool(HTTPGRPCEnabledKey, false, "If true, the info, health and platform APIs are also served over gRPC on the HTTP port")
 
 	// Enable/Disable APIs
 	fs.Bool(AdminAPIEnabledKey, false, "If true, this node exposes the Admin API")
+	fs.Bool(EventsAPIEnabledKey, false, "If true, this node exposes the Events API")
 	fs.Bool(InfoAPIEnabledKey, true, "If true, this node exposes the Info API")
 	fs.Bool(KeystoreAPIEnabledKey, false, "If true, this node exposes the Keystore API")
 	fs.Bool(MetricsAPIEnabledKey, true, "If true, this node exposes the Metrics API")
@@ -249,6 +263,13 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// Health Checks
 	fs.Duration(HealthCheckFreqKey, 30*time.Second, "Time between health checks")
 	fs.Duration(HealthCheckAveragerHalflifeKey, constants.DefaultHealthCheckAveragerHalflife, "Halflife of averager when calculating a running average in a health check")
+	fs.Uint(HealthCheckFailureThresholdKey, uint(health.DefaultConfig.FailureThreshold), "Number of consecutive failures after which a passing health check is reported as failing")
+	fs.Uint(HealthCheckSuccessThresholdKey, uint(health.DefaultConfig.SuccessThreshold), "Number of consecutive passes after which a failing health check is reported as passing")
+	fs.Uint(HealthCheckHistorySizeKey, uint(health.DefaultConfig.HistorySize), "Number of transitions between passing and failing that are remembered for each health check")
+	fs.Uint(HealthCheckFlappingThresholdKey, uint(health.DefaultConfig.FlappingThreshold), fmt.Sprintf("Number of transitions within %s after which a health check is reported as flapping. If 0, health checks are never reported as flapping", HealthCheckFlappingWindowKey))
+	fs.Duration(HealthCheckFlappingWindowKey, health.DefaultConfig.FlappingWindow, "Window of time over which the transitions of a health check are counted to detect flapping")
+	fs.StringSlice(HealthWebhookURLsKey, nil, "List of URLs that are sent a POST request whenever the node starts or stops being ready, healthy, or alive")
+	fs.Duration(HealthWebhookTimeoutKey, 10*time.Second, "Timeout of the requests sent to the health webhooks")
 	// Network Layer Health
 	fs.Duration(NetworkHealthMaxTimeSinceMsgSentKey, constants.DefaultNetworkHealthMaxTimeSinceMsgSent, "Network layer returns unhealthy if haven't sent a message for at least this much time")
 	fs.Duration(NetworkHealthMaxTimeSinceMsgReceivedKey, constants.DefaultNetworkHealthMaxTimeSinceMsgReceived, "Network layer returns unhealthy if haven't received a message for at least this much time")
@@ -69,6 +69,10 @@ const (
 	HTTPSKeyContentKey                       = "http-tls-key-file-content"
 	HTTPSCertFileKey                         = "http-tls-cert-file"
 	HTTPSCertContentKey                      = "http-tls-cert-file-content"
+	HTTPAuthFileKey                          = "http-auth-file"
+	HTTPAuthContentKey                       = "http-auth-file-content"
+	HTTPLimitsFileKey                        = "http-limits-file"
+	HTTPLimitsContentKey                     = "http-limits-file-content"
 
 	HTTPAllowedOrigins       = "http-allowed-origins"
 	HTTPAllowedHostsKey      = "http-allowed-hosts"
@@ -78,6 +82,8 @@ const (
 	HTTPReadHeaderTimeoutKey = "http-read-header-timeout"
 
 	HTTPIdleTimeoutKey                                 = "http-idle-timeout"
+	HTTPMaxBatchSizeKey                                = "http-max-batch-size"
+	HTTPGRPCEnabledKey                                 = "http-grpc-enabled"
 	StateSyncIPsKey                                    = "state-sync-ips"
 	StateSyncIDsKey                                    = "state-sync-ids"
 	BootstrapIPsKey                                    = "bootstrap-ips"
@@ -120,6 +126,7 @@ const (
 	NetworkRequireValidatorToConnectKey                = "network-require-validator-to-connect"
 	NetworkPeerReadBufferSizeKey                       = "network-peer-read-buffer-size"
 	NetworkPeerWriteBufferSizeKey                      = "network-peer-write-buffer-size"
+	NetworkPeerMetricsMaxPeersKey                      = "network-peer-metrics-max-peers"
 	NetworkTCPProxyEnabledKey                          = "network-tcp-proxy-enabled"
 	NetworkTCPProxyReadTimeoutKey                      = "network-tcp-proxy-read-timeout"
 	NetworkTLSKeyLogFileKey                            = "network-tls-key-log-file-unsafe"
@@ -151,6 +158,7 @@ const (
 	PartialSyncPrimaryNetworkKey                       = "partial-sync-primary-network"
 	TrackSubnetsKey                                    = "track-subnets"
 	AdminAPIEnabledKey                                 = "api-admin-enabled"
+	EventsAPIEnabledKey                                = "api-events-enabled"
 	InfoAPIEnabledKey                                  = "api-info-enabled"
 	KeystoreAPIEnabledKey                              = "api-keystore-enabled"
 	MetricsAPIEnabledKey                               = "api-metrics-enabled"
@@ -159,6 +167,10 @@ const (
 	ConsensusAppConcurrencyKey                         = "consensus-app-concurrency"
 	ConsensusShutdownTimeoutKey                        = "consensus-shutdown-timeout"
 	ConsensusFrontierPollFrequencyKey                  = "consensus-frontier-poll-frequency"
+	ConsensusPriorityConsensusWeightKey                = "consensus-priority-consensus-weight"
+	ConsensusPriorityBootstrapWeightKey                = "consensus-priority-bootstrap-weight"
+	ConsensusPriorityAppWeightKey                      = "consensus-priority-app-weight"
+	ConsensusPriorityOpsKey                            = "consensus-priority-ops"
 	ProposerVMUseCurrentHeightKey                      = "proposervm-use-current-height"
 	FdLimitKey                                         = "fd-limit"
 	IndexEnabledKey                                    = "index-enabled"
@@ -167,6 +179,13 @@ const (
 	RouterHealthMaxOutstandingRequestsKey              = "router-health-max-outstanding-requests"
 	HealthCheckFreqKey                                 = "health-check-frequency"
 	HealthCheckAveragerHalflifeKey                     = "health-check-averager-halflife"
+	HealthCheckFailureThresholdKey                     = "health-check-failure-threshold"
+	HealthCheckSuccessThresholdKey                     = "health-check-success-threshold"
+	HealthCheckHistorySizeKey                          = "health-check-history-size"
+	HealthCheckFlappingThresholdKey                    = "health-check-flapping-threshold"
+	HealthCheckFlappingWindowKey                       = "health-check-flapping-window"
+	HealthWebhookURLsKey                               = "health-webhook-urls"
+	HealthWebhookTimeoutKey                            = "health-webhook-timeout"
 	PluginDirKey                                       = "plugin-dir"
 	BootstrapBeaconConnectionTimeoutKey                = "bootstrap-beacon-connection-timeout"
 	BootstrapMaxTimeGetAncestorsKey                    = "bootstrap-max-time-get-ancestors"
@@ -0,0 +1,317 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+const (
+	// The genesis block is never accepted by consensus, so the first block in
+	// a complete block index is at this height.
+	firstIndexedHeight = 1
+
+	// Maximum number of blocks backfilled each time the chain's lock is
+	// grabbed.
+	backfillBatchSize = 1024
+
+	// Minimum amount of time between backfill progress logs.
+	backfillLogPeriod = 5 * time.Second
+)
+
+var errCantBackfill = errors.New("can't backfill index")
+
+// backfillableVM returns [vm] as a block.ChainVM if the block index of its
+// chain can be backfilled.
+//
+// DAG chains can't be backfilled because their vertex and tx indices can't be
+// rebuilt from the VM.
+func backfillableVM(vm interface{}) (block.ChainVM, bool) {
+	if _, ok := vm.(vertex.DAGVM); ok {
+		return nil, false
+	}
+	chainVM, ok := vm.(block.ChainVM)
+	return chainVM, ok
+}
+
+// backfillBlocks indexes the blocks that [vm] accepted while its chain wasn't
+// being indexed, and then marks the chain's index as complete.
+//
+// [index] must be backfilling, so that it ignores the blocks accepted by
+// consensus until this catches up to them.
+//
+// Assumes [ctx.Lock] is not held.
+func (i *indexer) backfillBlocks(chainName string, ctx *snow.ConsensusContext, vm block.ChainVM, index *index) {
+	err := i.backfill(chainName, ctx, vm, index)
+	if err == nil {
+		return
+	}
+
+	select {
+	case <-index.closing:
+		i.log.Debug("stopped backfilling index",
+			zap.String("reason", "index closed"),
+			zap.String("chainName", chainName),
+			zap.Error(err),
+		)
+		return
+	default:
+	}
+
+	if errors.Is(err, errCantBackfill) && i.allowIncompleteIndex {
+		i.log.Warn("couldn't backfill index. Index will be incomplete",
+			zap.String("chainName", chainName),
+			zap.Error(err),
+		)
+		index.setBackfilling(false)
+		return
+	}
+
+	i.log.Fatal("couldn't backfill index",
+		zap.String("chainName", chainName),
+		zap.Error(err),
+	)
+	if err := i.Close(); err != nil {
+		i.log.Error("failed to close indexer",
+			zap.Error(err),
+		)
+	}
+}
+
+func (i *indexer) backfill(chainName string, ctx *snow.ConsensusContext, vm block.ChainVM, index *index) error {
+	ctx.Lock.Lock()
+	height, lastAcceptedHeight, err := i.prepareBackfill(chainName, ctx, vm, index)
+	ctx.Lock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	i.log.Info("backfilling index",
+		zap.String("chainName", chainName),
+		zap.Uint64("startHeight", height),
+		zap.Uint64("lastAcceptedHeight", lastAcceptedHeight),
+	)
+
+	var (
+		startHeight   = height
+		startTime     = time.Now()
+		timeOfNextLog = startTime.Add(backfillLogPeriod)
+		done          bool
+	)
+	for !done {
+		select {
+		case <-index.closing:
+			return errIndexClosed
+		default:
+		}
+
+		ctx.Lock.Lock()
+		height, lastAcceptedHeight, done, err = backfillBatch(vm, index, height)
+		if done {
+			// Because [ctx.Lock] is held, every block that consensus has
+			// accepted has been committed by the VM, and has therefore been
+			// backfilled. So, consensus can take over indexing.
+			index.setBackfilling(false)
+		}
+		ctx.Lock.Unlock()
+		if err != nil {
+			return err
+		}
+
+		if now := time.Now(); now.After(timeOfNextLog) {
+			var (
+				numBackfilled = height - startHeight
+				numToBackfill = lastAcceptedHeight + 1 - startHeight
+				eta           = timer.EstimateETA(startTime, numBackfilled, numToBackfill)
+			)
+			i.log.Info("backfilling index",
+				zap.String("chainName", chainName),
+				zap.Uint64("numBackfilled", numBackfilled),
+				zap.Uint64("numToBackfill", numToBackfill),
+				zap.Duration("eta", eta),
+			)
+			timeOfNextLog = now.Add(backfillLogPeriod)
+		}
+	}
+
+	if err := i.markComplete(ctx.ChainID); err != nil {
+		return fmt.Errorf("couldn't mark index as complete: %w", err)
+	}
+	i.log.Info("finished backfilling index",
+		zap.String("chainName", chainName),
+		zap.Uint64("numBackfilled", height-startHeight),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+	return nil
+}
+
+// prepareBackfill returns the height of the first block to backfill and the
+// height of the last accepted block.
+//
+// If [index] isn't a prefix of the chain, it's cleared so it can be rebuilt
+// from the first indexed height.
+//
+// Assumes [ctx.Lock] is held.
+func (i *indexer) prepareBackfill(chainName string, ctx *snow.ConsensusContext, vm block.ChainVM, index *index) (uint64, uint64, error) {
+	lastAcceptedHeight, err := getLastAcceptedHeight(vm)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	rebuilding, err := i.isRebuilding(ctx.ChainID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	height := uint64(firstIndexedHeight)
+	if !rebuilding {
+		nextHeight, isPrefix, err := nextIndexedHeight(vm, index)
+		if err != nil {
+			return 0, 0, err
+		}
+		height = nextHeight
+		rebuilding = !isPrefix
+	}
+	if rebuilding {
+		height = firstIndexedHeight
+	}
+
+	// Make sure the index can be backfilled before modifying it.
+	if height <= lastAcceptedHeight {
+		if _, err := vm.GetBlockIDAtHeight(context.TODO(), height); err == database.ErrNotFound {
+			return 0, 0, fmt.Errorf("%w: VM doesn't have the block at height %d", errCantBackfill, height)
+		} else if err != nil {
+			return 0, 0, fmt.Errorf("couldn't get block ID at height %d: %w", height, err)
+		}
+	}
+	if !rebuilding {
+		return height, lastAcceptedHeight, nil
+	}
+
+	i.log.Warn("rebuilding index",
+		zap.String("reason", "index is missing blocks that it can't be backfilled with"),
+		zap.String("chainName", chainName),
+	)
+	// If the node shuts down while the index is being cleared, the index
+	// will be cleared again on the next run.
+	if err := i.markRebuilding(ctx.ChainID); err != nil {
+		return 0, 0, err
+	}
+	if err := index.clear(); err != nil {
+		return 0, 0, fmt.Errorf("couldn't clear index: %w", err)
+	}
+	if err := i.unmarkRebuilding(ctx.ChainID); err != nil {
+		return 0, 0, err
+	}
+	return height, lastAcceptedHeight, nil
+}
+
+// backfillBatch backfills up to [backfillBatchSize] blocks into [index],
+// starting from [height]. Returns the height of the next block to backfill, the
+// height of the last accepted block, and true if every accepted block has been
+// backfilled.
+//
+// Assumes [ctx.Lock] is held.
+func backfillBatch(vm block.ChainVM, index *index, height uint64) (uint64, uint64, bool, error) {
+	lastAcceptedHeight, err := getLastAcceptedHeight(vm)
+	if err != nil {
+		return height, 0, false, err
+	}
+
+	for end := min(lastAcceptedHeight, height+backfillBatchSize-1); height <= end; height++ {
+		blkID, err := vm.GetBlockIDAtHeight(context.TODO(), height)
+		if err == database.ErrNotFound {
+			return height, lastAcceptedHeight, false, fmt.Errorf("%w: VM doesn't have the block at height %d", errCantBackfill, height)
+		}
+		if err != nil {
+			return height, lastAcceptedHeight, false, fmt.Errorf("couldn't get block ID at height %d: %w", height, err)
+		}
+		blk, err := vm.GetBlock(context.TODO(), blkID)
+		if err != nil {
+			return height, lastAcceptedHeight, false, fmt.Errorf("couldn't get block %s at height %d: %w", blkID, height, err)
+		}
+		if err := index.backfill(blkID, blk.Bytes(), blk.Timestamp()); err != nil {
+			return height, lastAcceptedHeight, false, fmt.Errorf("couldn't index block %s at height %d: %w", blkID, height, err)
+		}
+	}
+	return height, lastAcceptedHeight, height > lastAcceptedHeight, nil
+}
+
+// nextIndexedHeight returns the height of the block that should be indexed
+// after the blocks in [index], and true if [index] contains every block from
+// the first indexed height up to that height.
+//
+// Assumes the chain's lock is held.
+func nextIndexedHeight(vm block.ChainVM, index *index) (uint64, bool, error) {
+	numIndexed := index.getNextAcceptedIndex()
+	if numIndexed == 0 {
+		return firstIndexedHeight, true, nil
+	}
+
+	// Accepted blocks are indexed in order of height, so [index] is a prefix
+	// of the chain iff its first and last blocks are at the expected heights.
+	first, err := index.GetContainerByIndex(0)
+	if err != nil {
+		return 0, false, err
+	}
+	firstHeight, err := getHeight(vm, first)
+	if err != nil {
+		return 0, false, err
+	}
+	last, err := index.GetContainerByIndex(numIndexed - 1)
+	if err != nil {
+		return 0, false, err
+	}
+	lastHeight, err := getHeight(vm, last)
+	if err != nil {
+		return 0, false, err
+	}
+	isPrefix := firstHeight == firstIndexedHeight && lastHeight == firstIndexedHeight+numIndexed-1
+	return lastHeight + 1, isPrefix, nil
+}
+
+func getHeight(vm block.ChainVM, container Container) (uint64, error) {
+	blk, err := vm.ParseBlock(context.TODO(), container.Bytes)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't parse indexed block %s: %w", container.ID, err)
+	}
+	return blk.Height(), nil
+}
+
+func getLastAcceptedHeight(vm block.ChainVM) (uint64, error) {
+	lastAcceptedID, err := vm.LastAccepted(context.TODO())
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get last accepted block ID: %w", err)
+	}
+	lastAccepted, err := vm.GetBlock(context.TODO(), lastAcceptedID)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get last accepted block %s: %w", lastAcceptedID, err)
+	}
+	return lastAccepted.Height(), nil
+}
+
+func (i *indexer) markRebuilding(chainID ids.ID) error {
+	return i.db.Put(chainKey(chainID, isRebuildingPrefix), nil)
+}
+
+func (i *indexer) unmarkRebuilding(chainID ids.ID) error {
+	return i.db.Delete(chainKey(chainID, isRebuildingPrefix))
+}
+
+// Returns true if the chain's index was being cleared to be rebuilt
+func (i *indexer) isRebuilding(chainID ids.ID) (bool, error) {
+	return i.db.Has(chainKey(chainID, isRebuildingPrefix))
+}
@@ -0,0 +1,279 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman/snowmantest"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block/blocktest"
+	"github.com/ava-labs/avalanchego/snow/snowtest"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+type backfillTest struct {
+	require *require.Assertions
+	baseDB  database.Database
+	ctx     *snow.ConsensusContext
+	blks    []*snowmantest.Block
+	vm      *blocktest.VM
+}
+
+// newBackfillTest returns a chain with [numBlks] blocks after the genesis,
+// none of which have been accepted.
+func newBackfillTest(t *testing.T, numBlks int) *backfillTest {
+	blks := snowmantest.BuildChain(numBlks + 1)
+	vm := &blocktest.VM{
+		LastAcceptedF:       snowmantest.MakeLastAcceptedBlockF(blks),
+		GetBlockIDAtHeightF: snowmantest.MakeGetBlockIDAtHeightF(blks),
+		GetBlockF: func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+			for _, blk := range blks {
+				if blk.ID() == blkID {
+					return blk, nil
+				}
+			}
+			return nil, database.ErrNotFound
+		},
+		ParseBlockF: func(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+			for _, blk := range blks {
+				if bytes.Equal(blk.Bytes(), blkBytes) {
+					return blk, nil
+				}
+			}
+			return nil, errUnimplemented
+		},
+	}
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	return &backfillTest{
+		require: require.New(t),
+		baseDB:  memdb.New(),
+		ctx:     snowtest.ConsensusContext(snowCtx),
+		blks:    blks,
+		vm:      vm,
+	}
+}
+
+// open creates an indexer on the test's database and registers the chain
+// with it.
+func (b *backfillTest) open(indexingEnabled, allowIncompleteIndex bool) *indexer {
+	idxrIntf, err := NewIndexer(Config{
+		IndexingEnabled:      indexingEnabled,
+		AllowIncompleteIndex: allowIncompleteIndex,
+		Log:                  logging.NoLog{},
+		// The indexer closes its database, so it's wrapped to be able to
+		// re-open the indexer.
+		DB:                  prefixdb.New(nil, b.baseDB),
+		BlockAcceptorGroup:  snow.NewAcceptorGroup(logging.NoLog{}),
+		TxAcceptorGroup:     snow.NewAcceptorGroup(logging.NoLog{}),
+		VertexAcceptorGroup: snow.NewAcceptorGroup(logging.NoLog{}),
+		APIServer:           &apiServerMock{},
+		ShutdownF:           func() {},
+	})
+	b.require.NoError(err)
+	idxr := idxrIntf.(*indexer)
+	idxr.RegisterChain("chain", b.ctx, b.vm)
+	return idxr
+}
+
+// accept accepts the blocks at [heights], notifying [idxr] if it isn't nil.
+func (b *backfillTest) accept(idxr *indexer, heights ...int) {
+	b.ctx.Lock.Lock()
+	defer b.ctx.Lock.Unlock()
+
+	b.acceptLocked(idxr, heights...)
+}
+
+// Assumes [b.ctx.Lock] is held
+func (b *backfillTest) acceptLocked(idxr *indexer, heights ...int) {
+	for _, height := range heights {
+		blk := b.blks[height]
+		if idxr != nil {
+			b.require.NoError(idxr.blockAcceptorGroup.Accept(b.ctx, blk.ID(), blk.Bytes()))
+		}
+		b.require.NoError(blk.Accept(context.Background()))
+	}
+}
+
+// requireComplete waits for [idxr] to mark the chain as complete.
+func (b *backfillTest) requireComplete(idxr *indexer) {
+	b.require.Eventually(func() bool {
+		isIncomplete, err := idxr.isIncomplete(b.ctx.ChainID)
+		b.require.NoError(err)
+		return !isIncomplete
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// requireIndexed checks that the index of the chain contains exactly the
+// blocks at [heights], in order.
+func (b *backfillTest) requireIndexed(idxr *indexer, heights ...int) {
+	idxr.lock.RLock()
+	index := idxr.blockIndices[b.ctx.ChainID]
+	idxr.lock.RUnlock()
+	b.require.NotNil(index)
+	b.require.Equal(uint64(len(heights)), index.getNextAcceptedIndex())
+
+	for i, height := range heights {
+		container, err := index.GetContainerByIndex(uint64(i))
+		b.require.NoError(err)
+		b.require.Equal(b.blks[height].ID(), container.ID)
+	}
+}
+
+func (b *backfillTest) isClosed(idxr *indexer) bool {
+	idxr.lock.RLock()
+	defer idxr.lock.RUnlock()
+
+	return idxr.closed
+}
+
+func TestBackfill(t *testing.T) {
+	b := newBackfillTest(t, 7)
+	b.accept(nil, 1, 2, 3, 4, 5)
+
+	// Running with indexing disabled marks the index as incomplete.
+	idxr := b.open(false, false)
+	isIncomplete, err := idxr.isIncomplete(b.ctx.ChainID)
+	b.require.NoError(err)
+	b.require.True(isIncomplete)
+	b.require.NoError(idxr.Close())
+
+	// Enabling indexing backfills the index, even though incomplete indices
+	// aren't allowed. A block accepted while the index is being backfilled is
+	// indexed by the backfill rather than by consensus.
+	b.ctx.Lock.Lock()
+	idxr = b.open(true, false)
+	b.acceptLocked(idxr, 6)
+	b.ctx.Lock.Unlock()
+	b.requireComplete(idxr)
+	b.require.False(b.isClosed(idxr))
+	b.requireIndexed(idxr, 1, 2, 3, 4, 5, 6)
+
+	// Backfilled blocks are timestamped with the time they were produced.
+	container, err := idxr.blockIndices[b.ctx.ChainID].GetContainerByIndex(0)
+	b.require.NoError(err)
+	b.require.Equal(b.blks[1].Timestamp().UnixNano(), container.Timestamp)
+
+	// Once the backfill is done, consensus indexes accepted blocks.
+	b.accept(idxr, 7)
+	b.requireIndexed(idxr, 1, 2, 3, 4, 5, 6, 7)
+	b.require.NoError(idxr.Close())
+}
+
+func TestBackfillResumesFromIndex(t *testing.T) {
+	b := newBackfillTest(t, 6)
+
+	idxr := b.open(true, false)
+	b.accept(idxr, 1, 2, 3)
+	b.requireIndexed(idxr, 1, 2, 3)
+	b.require.NoError(idxr.Close())
+
+	idxr = b.open(false, true)
+	b.accept(idxr, 4, 5)
+	b.require.NoError(idxr.Close())
+
+	// Only the blocks accepted while indexing was disabled are backfilled.
+	idxr = b.open(true, false)
+	b.requireComplete(idxr)
+	b.requireIndexed(idxr, 1, 2, 3, 4, 5)
+
+	b.accept(idxr, 6)
+	b.requireIndexed(idxr, 1, 2, 3, 4, 5, 6)
+	b.require.NoError(idxr.Close())
+}
+
+func TestBackfillRebuildsIndex(t *testing.T) {
+	b := newBackfillTest(t, 5)
+	b.accept(nil, 1, 2)
+
+	// Create an index that is missing the first blocks of the chain.
+	idxr := b.open(true, false)
+	b.accept(idxr, 3, 4)
+	b.requireIndexed(idxr, 3, 4)
+	b.require.NoError(idxr.markIncomplete(b.ctx.ChainID))
+	b.require.NoError(idxr.Close())
+
+	// The index can't be backfilled in place, so it's rebuilt.
+	idxr = b.open(true, false)
+	b.requireComplete(idxr)
+	b.requireIndexed(idxr, 1, 2, 3, 4)
+	isRebuilding, err := idxr.isRebuilding(b.ctx.ChainID)
+	b.require.NoError(err)
+	b.require.False(isRebuilding)
+
+	b.accept(idxr, 5)
+	b.requireIndexed(idxr, 1, 2, 3, 4, 5)
+	b.require.NoError(idxr.Close())
+}
+
+func TestBackfillMissingBlocks(t *testing.T) {
+	tests := []struct {
+		name                 string
+		allowIncompleteIndex bool
+	}{
+		{
+			name:                 "incomplete index not allowed",
+			allowIncompleteIndex: false,
+		},
+		{
+			name:                 "incomplete index allowed",
+			allowIncompleteIndex: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := newBackfillTest(t, 3)
+			b.accept(nil, 1, 2)
+
+			idxr := b.open(false, false)
+			b.require.NoError(idxr.Close())
+
+			// The VM doesn't have the blocks before its last accepted block,
+			// as if it was state synced.
+			b.vm.GetBlockIDAtHeightF = func(_ context.Context, height uint64) (ids.ID, error) {
+				if height < 2 {
+					return ids.Empty, database.ErrNotFound
+				}
+				return b.blks[height].ID(), nil
+			}
+
+			idxr = b.open(true, test.allowIncompleteIndex)
+			if !test.allowIncompleteIndex {
+				b.require.Eventually(func() bool {
+					return b.isClosed(idxr)
+				}, 5*time.Second, 10*time.Millisecond)
+				return
+			}
+
+			// The index is left incomplete, and consensus indexes accepted
+			// blocks.
+			index := idxr.blockIndices[b.ctx.ChainID]
+			b.require.Eventually(func() bool {
+				index.lock.RLock()
+				defer index.lock.RUnlock()
+
+				return !index.backfilling
+			}, 5*time.Second, 10*time.Millisecond)
+			b.require.False(b.isClosed(idxr))
+			isIncomplete, err := idxr.isIncomplete(b.ctx.ChainID)
+			b.require.NoError(err)
+			b.require.True(isIncomplete)
+
+			b.accept(idxr, 3)
+			b.requireIndexed(idxr, 3)
+			b.require.NoError(idxr.Close())
+		})
+	}
+}
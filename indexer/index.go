@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -16,13 +17,18 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/units"
 )
 
 // Maximum number of containers IDs that can be fetched at a time in a call to
 // GetContainerRange
 const MaxFetchedByRange = 1024
 
+// Size of the batches written when clearing an index
+const clearWriteSize = units.MiB
+
 var (
 	// Maps to the byte representation of the next accepted index
 	nextAcceptedIndexKey   = []byte{0x00}
@@ -31,6 +37,7 @@ var (
 	errNoneAccepted        = errors.New("no containers have been accepted")
 	errNumToFetchInvalid   = fmt.Errorf("numToFetch must be in [1,%d]", MaxFetchedByRange)
 	errNoContainerAtIndex  = errors.New("no container at index")
+	errIndexClosed         = errors.New("index closed")
 
 	_ snow.Acceptor = (*index)(nil)
 )
@@ -54,6 +61,15 @@ type index struct {
 	// Container ID --> Index
 	containerToIndex database.Database
 	log              logging.Logger
+
+	// If true, containers accepted by consensus aren't indexed because the
+	// index is being backfilled from the VM.
+	backfilling bool
+
+	// Notified when a container is accepted
+	subscriptions set.Set[*subscription]
+	// Closed when the index is closed
+	closing chan struct{}
 }
 
 // Create a new thread-safe index.
@@ -75,6 +91,7 @@ func newIndex(
 		indexToContainer: indexToContainer,
 		containerToIndex: containerToIndex,
 		log:              log,
+		closing:          make(chan struct{}),
 	}
 
 	// Get next accepted index from db
@@ -98,6 +115,14 @@ func newIndex(
 
 // Close this index
 func (i *index) Close() error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	select {
+	case <-i.closing:
+	default:
+		close(i.closing)
+	}
 	return errors.Join(
 		i.indexToContainer.Close(),
 		i.containerToIndex.Close(),
@@ -113,13 +138,37 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 	i.lock.Lock()
 	defer i.lock.Unlock()
 
+	if i.backfilling {
+		// The backfill will index this container once the VM has committed
+		// it.
+		ctx.Log.Debug("not indexing container",
+			zap.String("reason", "index is being backfilled"),
+			zap.Stringer("containerID", containerID),
+		)
+		return nil
+	}
+	return i.accept(ctx.Log, containerID, containerBytes, i.clock.Time())
+}
+
+// backfill indexes a container that was accepted before the containers that
+// are being accepted by consensus. [timestamp] is recorded as the time the
+// container was accepted.
+func (i *index) backfill(containerID ids.ID, containerBytes []byte, timestamp time.Time) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	return i.accept(i.log, containerID, containerBytes, timestamp)
+}
+
+// Assumes [i.lock] is held
+func (i *index) accept(log logging.Logger, containerID ids.ID, containerBytes []byte, timestamp time.Time) error {
 	// It may be the case that in a previous run of this node, this index committed [containerID]
 	// as accepted and then the node shut down before the VM committed [containerID] as accepted.
 	// In that case, when the node restarts Accept will be called with the same container.
 	// Make sure we don't index the same container twice in that event.
 	_, err := i.containerToIndex.Get(containerID[:])
 	if err == nil {
-		ctx.Log.Debug("not indexing already accepted container",
+		log.Debug("not indexing already accepted container",
 			zap.Stringer("containerID", containerID),
 		)
 		return nil
@@ -128,7 +177,7 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 		return fmt.Errorf("couldn't get whether %s is accepted: %w", containerID, err)
 	}
 
-	ctx.Log.Debug("indexing container",
+	log.Debug("indexing container",
 		zap.Uint64("nextAcceptedIndex", i.nextAcceptedIndex),
 		zap.Stringer("containerID", containerID),
 	)
@@ -137,7 +186,7 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 	bytes, err := Codec.Marshal(CodecVersion, Container{
 		ID:        containerID,
 		Bytes:     containerBytes,
-		Timestamp: i.clock.Time().UnixNano(),
+		Timestamp: timestamp.UnixNano(),
 	})
 	if err != nil {
 		return fmt.Errorf("couldn't serialize container %s: %w", containerID, err)
@@ -158,7 +207,14 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 	}
 
 	// Atomically commit [i.vDB], [i.indexToContainer], [i.containerToIndex] to [i.baseDB]
-	return i.vDB.Commit()
+	if err := i.vDB.Commit(); err != nil {
+		return err
+	}
+
+	for s := range i.subscriptions {
+		s.notifyAccepted()
+	}
+	return nil
 }
 
 // Returns the ID of the [index]th accepted container and the container itself.
@@ -270,6 +326,54 @@ func (i *index) GetLastAccepted() (Container, error) {
 	return i.getContainerByIndex(lastAcceptedIndex)
 }
 
+// subscribe registers [s] to be notified when a container is accepted.
+// Returns the index of the next container to be accepted.
+func (i *index) subscribe(s *subscription) uint64 {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.subscriptions.Add(s)
+	return i.nextAcceptedIndex
+}
+
+func (i *index) unsubscribe(s *subscription) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.subscriptions.Remove(s)
+}
+
+// setBackfilling sets whether containers accepted by consensus should be
+// ignored because the index is being backfilled.
+func (i *index) setBackfilling(backfilling bool) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.backfilling = backfilling
+}
+
+// clear removes every container from the index.
+func (i *index) clear() error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	// Every write to [i.vDB] has been committed, so [i.baseDB] contains the
+	// entire index.
+	if err := database.Clear(i.baseDB, clearWriteSize); err != nil {
+		return err
+	}
+	i.nextAcceptedIndex = 0
+	return nil
+}
+
+// Returns the index of the next container to be accepted.
+func (i *index) getNextAcceptedIndex() uint64 {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.nextAcceptedIndex
+}
+
 // Assumes i.lock is held
 // Returns:
 //
@@ -34,6 +34,7 @@ const (
 	blockPrefix             = 0x03
 	isIncompletePrefix      = 0x04
 	previouslyIndexedPrefix = 0x05
+	isRebuildingPrefix      = 0x06
 )
 
 var (
@@ -217,7 +218,11 @@ func (i *indexer) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 		return
 	}
 
-	if !i.allowIncompleteIndex && isIncomplete && (previouslyIndexed || i.hasRunBefore) {
+	// If the index is incomplete, a linear chain's index is backfilled from
+	// its VM. The index of a DAG chain can't be backfilled.
+	chainVM, canBackfill := backfillableVM(vm)
+	backfill := isIncomplete && canBackfill
+	if !i.allowIncompleteIndex && isIncomplete && !canBackfill && (previouslyIndexed || i.hasRunBefore) {
 		i.log.Fatal("index is incomplete but incomplete indices are disabled. Shutting down",
 			zap.String("chainName", chainName),
 		)
@@ -243,7 +248,7 @@ func (i *indexer) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 		return
 	}
 
-	index, err := i.registerChainHelper(chainID, blockPrefix, chainName, "block", i.blockAcceptorGroup)
+	index, err := i.registerChainHelper(chainID, blockPrefix, chainName, "block", i.blockAcceptorGroup, backfill)
 	if err != nil {
 		i.log.Fatal("failed to create index",
 			zap.String("chainName", chainName),
@@ -261,7 +266,7 @@ func (i *indexer) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 
 	switch vm.(type) {
 	case vertex.DAGVM:
-		vtxIndex, err := i.registerChainHelper(chainID, vtxPrefix, chainName, "vtx", i.vertexAcceptorGroup)
+		vtxIndex, err := i.registerChainHelper(chainID, vtxPrefix, chainName, "vtx", i.vertexAcceptorGroup, false)
 		if err != nil {
 			i.log.Fatal("couldn't create index",
 				zap.String("chainName", chainName),
@@ -277,7 +282,7 @@ func (i *indexer) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 		}
 		i.vtxIndices[chainID] = vtxIndex
 
-		txIndex, err := i.registerChainHelper(chainID, txPrefix, chainName, "tx", i.txAcceptorGroup)
+		txIndex, err := i.registerChainHelper(chainID, txPrefix, chainName, "tx", i.txAcceptorGroup, false)
 		if err != nil {
 			i.log.Fatal("couldn't create index",
 				zap.String("chainName", chainName),
@@ -293,6 +298,9 @@ func (i *indexer) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 		}
 		i.txIndices[chainID] = txIndex
 	case block.ChainVM:
+		if backfill {
+			go i.backfillBlocks(chainName, ctx, chainVM, index)
+		}
 	default:
 		vmType := fmt.Sprintf("%T", vm)
 		i.log.Error("got unexpected vm type",
@@ -311,17 +319,19 @@ func (i *indexer) registerChainHelper(
 	prefixEnd byte,
 	name, endpoint string,
 	acceptorGroup snow.AcceptorGroup,
+	backfilling bool,
 ) (*index, error) {
-	prefix := make([]byte, ids.IDLen+wrappers.ByteLen)
-	copy(prefix, chainID[:])
-	prefix[ids.IDLen] = prefixEnd
-	indexDB := prefixdb.New(prefix, i.db)
+	indexDB := prefixdb.New(chainKey(chainID, prefixEnd), i.db)
 	index, err := newIndex(indexDB, i.log, i.clock)
 	if err != nil {
 		_ = indexDB.Close()
 		return nil, err
 	}
 
+	// Set before the index is registered so that none of the containers
+	// accepted by consensus are indexed out of order.
+	index.backfilling = backfilling
+
 	// Register index to learn about new accepted vertices
 	if err := acceptorGroup.RegisterAcceptor(chainID, fmt.Sprintf("%s%s", indexNamePrefix, chainID), index, true); err != nil {
 		_ = index.Close()
@@ -337,7 +347,12 @@ func (i *indexer) registerChainHelper(
 		_ = index.Close()
 		return nil, err
 	}
-	if err := i.pathAdder.AddRoute(apiServer, "index/"+name, "/"+endpoint); err != nil {
+	handler := &indexHandler{
+		index: index,
+		rpc:   apiServer,
+		log:   i.log,
+	}
+	if err := i.pathAdder.AddRoute(handler, "index/"+name, "/"+endpoint); err != nil {
 		_ = index.Close()
 		return nil, err
 	}
@@ -387,33 +402,25 @@ func (i *indexer) close() error {
 }
 
 func (i *indexer) markIncomplete(chainID ids.ID) error {
-	key := make([]byte, ids.IDLen+wrappers.ByteLen)
-	copy(key, chainID[:])
-	key[ids.IDLen] = isIncompletePrefix
-	return i.db.Put(key, nil)
+	return i.db.Put(chainKey(chainID, isIncompletePrefix), nil)
+}
+
+func (i *indexer) markComplete(chainID ids.ID) error {
+	return i.db.Delete(chainKey(chainID, isIncompletePrefix))
 }
 
 // Returns true if this chain is incomplete
 func (i *indexer) isIncomplete(chainID ids.ID) (bool, error) {
-	key := make([]byte, ids.IDLen+wrappers.ByteLen)
-	copy(key, chainID[:])
-	key[ids.IDLen] = isIncompletePrefix
-	return i.db.Has(key)
+	return i.db.Has(chainKey(chainID, isIncompletePrefix))
 }
 
 func (i *indexer) markPreviouslyIndexed(chainID ids.ID) error {
-	key := make([]byte, ids.IDLen+wrappers.ByteLen)
-	copy(key, chainID[:])
-	key[ids.IDLen] = previouslyIndexedPrefix
-	return i.db.Put(key, nil)
+	return i.db.Put(chainKey(chainID, previouslyIndexedPrefix), nil)
 }
 
 // Returns true if this chain is incomplete
 func (i *indexer) previouslyIndexed(chainID ids.ID) (bool, error) {
-	key := make([]byte, ids.IDLen+wrappers.ByteLen)
-	copy(key, chainID[:])
-	key[ids.IDLen] = previouslyIndexedPrefix
-	return i.db.Has(key)
+	return i.db.Has(chainKey(chainID, previouslyIndexedPrefix))
 }
 
 // Mark that the node has run at least once
@@ -425,3 +432,11 @@ func (i *indexer) markHasRun() error {
 func (i *indexer) hasRun() (bool, error) {
 	return i.db.Has(hasRunKey)
 }
+
+// Returns the key of the chain's entry with [prefixEnd]
+func chainKey(chainID ids.ID, prefixEnd byte) []byte {
+	key := make([]byte, ids.IDLen+wrappers.ByteLen)
+	copy(key, chainID[:])
+	key[ids.IDLen] = prefixEnd
+	return key
+}
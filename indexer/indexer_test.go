@@ -443,9 +443,12 @@ func TestIncompleteIndex(t *testing.T) {
 	idxr = idxrIntf.(*indexer)
 	require.True(idxr.indexingEnabled)
 
-	// Register the chain again. Should die due to incomplete index.
+	// Register the chain again, as a DAG chain, whose index can't be
+	// backfilled. Should die due to incomplete index. Linear chains are tested
+	// in backfill_test.go.
 	require.NoError(config.DB.(*versiondb.Database).Commit())
-	idxr.RegisterChain("chain1", chain1Ctx, chainVM)
+	dagVM := vertexmock.NewLinearizableVM(ctrl)
+	idxr.RegisterChain("chain1", chain1Ctx, dagVM)
 	require.True(idxr.closed)
 
 	// Close and re-open the indexer, this time with indexing enabled
@@ -460,7 +463,7 @@ func TestIncompleteIndex(t *testing.T) {
 	require.True(idxr.allowIncompleteIndex)
 
 	// Register the chain again. Should be OK
-	idxr.RegisterChain("chain1", chain1Ctx, chainVM)
+	idxr.RegisterChain("chain1", chain1Ctx, dagVM)
 	require.False(idxr.closed)
 
 	// Close the indexer and re-open with indexing disabled and
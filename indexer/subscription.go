@@ -0,0 +1,337 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// DefaultSubscriptionWindow is the number of containers that may be sent
+	// to a subscriber before it acknowledges them, if the subscriber doesn't
+	// specify a window.
+	DefaultSubscriptionWindow = 256
+
+	// Size of the ws read buffer
+	subscriptionReadBufferSize = units.KiB
+
+	// Size of the ws write buffer
+	subscriptionWriteBufferSize = 64 * units.KiB
+
+	// Maximum message size allowed from a subscriber.
+	maxSubscriptionMessageSize = units.KiB
+
+	// Time allowed to write a message to a subscriber.
+	subscriptionWriteWait = 10 * time.Second
+
+	// Time allowed to read the next message or pong from a subscriber.
+	subscriptionPongWait = 60 * time.Second
+
+	// Send pings to subscribers with this period. Must be less than
+	// subscriptionPongWait.
+	subscriptionPingPeriod = (subscriptionPongWait * 9) / 10
+)
+
+var (
+	errInvalidWindow        = fmt.Errorf("window must be in [0,%d]", MaxFetchedByRange)
+	errStartIndexTooLarge   = errors.New("start index is greater than the next accepted index")
+	errUnexpectedAck        = errors.New("acknowledged a container that wasn't sent")
+	errSubscriptionTimedOut = errors.New("subscription timed out")
+	errFailedToWrite        = errors.New("failed to write to subscriber")
+
+	subscriptionUpgrader = websocket.Upgrader{
+		ReadBufferSize:  subscriptionReadBufferSize,
+		WriteBufferSize: subscriptionWriteBufferSize,
+		CheckOrigin: func(*http.Request) bool {
+			return true
+		},
+	}
+)
+
+// SubscribeArgs is the first message a subscriber sends.
+type SubscribeArgs struct {
+	// The index of the first container to send. If nil, the first container
+	// sent is the next one accepted.
+	StartIndex *json.Uint64        `json:"startIndex"`
+	Encoding   formatting.Encoding `json:"encoding"`
+	// The maximum number of containers that may be sent to the subscriber
+	// before it acknowledges them. If 0, [DefaultSubscriptionWindow] is used.
+	Window json.Uint64 `json:"window"`
+}
+
+// SubscriptionAck is sent by a subscriber to acknowledge every container up to
+// and including the one at [Index].
+type SubscriptionAck struct {
+	Index json.Uint64 `json:"index"`
+}
+
+// indexHandler serves subscriptions to [index] over websockets and every other
+// request with [rpc].
+type indexHandler struct {
+	index *index
+	rpc   http.Handler
+	log   logging.Logger
+}
+
+func (h *indexHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !websocket.IsWebSocketUpgrade(r) {
+		h.rpc.ServeHTTP(w, r)
+		return
+	}
+
+	conn, err := subscriptionUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.log.Debug("failed to upgrade",
+			zap.Error(err),
+		)
+		return
+	}
+	s := &subscription{
+		index:    h.index,
+		conn:     conn,
+		log:      h.log,
+		accepted: make(chan struct{}, 1),
+		acked:    make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	s.serve()
+}
+
+// subscription streams the containers of [index] to a subscriber, starting
+// from the index it requests. The containers are read from [index] as they're
+// sent, so a subscriber that falls behind doesn't cause containers to be
+// buffered in memory.
+type subscription struct {
+	index *index
+	conn  *websocket.Conn
+	log   logging.Logger
+
+	encoding formatting.Encoding
+	window   uint64
+
+	// Notified when a container is accepted.
+	accepted chan struct{}
+	// Notified when the subscriber acknowledges containers.
+	acked chan struct{}
+	// Closed when the subscriber stops reading.
+	done chan struct{}
+
+	lock sync.Mutex
+	// The index of the next container to send.
+	nextIndex uint64
+	// The index of the first container the subscriber hasn't acknowledged.
+	nextUnacked uint64
+	// The reason the subscriber stopped reading, if it violated the protocol.
+	readErr error
+}
+
+// serve streams containers to the subscriber until the connection is closed.
+func (s *subscription) serve() {
+	defer s.conn.Close()
+
+	s.conn.SetReadLimit(maxSubscriptionMessageSize)
+	if err := s.conn.SetReadDeadline(time.Now().Add(subscriptionPongWait)); err != nil {
+		return
+	}
+	var args SubscribeArgs
+	if err := s.conn.ReadJSON(&args); err != nil {
+		s.close(websocket.CloseUnsupportedData, err)
+		return
+	}
+	if err := s.init(&args); err != nil {
+		s.close(websocket.ClosePolicyViolation, err)
+		return
+	}
+	defer s.index.unsubscribe(s)
+
+	go s.readAcks()
+
+	ticker := time.NewTicker(subscriptionPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		sent, err := s.sendContainers()
+		if err != nil {
+			s.log.Debug("closing subscription",
+				zap.String("reason", "failed to send containers"),
+				zap.Error(err),
+			)
+			select {
+			case <-s.index.closing:
+				s.close(websocket.CloseGoingAway, errIndexClosed)
+			default:
+				if !errors.Is(err, errFailedToWrite) {
+					s.close(websocket.CloseInternalServerErr, err)
+				}
+			}
+			return
+		}
+		if sent {
+			continue
+		}
+
+		select {
+		case <-s.accepted:
+		case <-s.acked:
+		case <-ticker.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(subscriptionWriteWait)); err != nil {
+				return
+			}
+		case <-s.done:
+			s.lock.Lock()
+			err := s.readErr
+			s.lock.Unlock()
+			if err != nil {
+				s.close(websocket.ClosePolicyViolation, err)
+			}
+			return
+		case <-s.index.closing:
+			s.close(websocket.CloseGoingAway, errIndexClosed)
+			return
+		}
+	}
+}
+
+// init validates [args] and subscribes to [s.index].
+func (s *subscription) init(args *SubscribeArgs) error {
+	if args.Window > MaxFetchedByRange {
+		return fmt.Errorf("%w but is %d", errInvalidWindow, args.Window)
+	}
+	s.encoding = args.Encoding
+	s.window = uint64(args.Window)
+	if s.window == 0 {
+		s.window = DefaultSubscriptionWindow
+	}
+
+	// Subscribing before the start index is checked guarantees that the
+	// subscription is notified of every container accepted after it.
+	nextAcceptedIndex := s.index.subscribe(s)
+	startIndex := nextAcceptedIndex
+	if args.StartIndex != nil {
+		startIndex = uint64(*args.StartIndex)
+	}
+	if startIndex > nextAcceptedIndex {
+		s.index.unsubscribe(s)
+		return fmt.Errorf("%w: %d > %d", errStartIndexTooLarge, startIndex, nextAcceptedIndex)
+	}
+	s.nextIndex = startIndex
+	s.nextUnacked = startIndex
+	return nil
+}
+
+// sendContainers sends the accepted containers that fit in the subscriber's
+// window, up to [MaxFetchedByRange] of them. Returns true if any containers
+// were sent.
+func (s *subscription) sendContainers() (bool, error) {
+	s.lock.Lock()
+	var (
+		startIndex = s.nextIndex
+		endIndex   = min(s.nextUnacked+s.window, startIndex+MaxFetchedByRange)
+	)
+	s.lock.Unlock()
+
+	endIndex = min(endIndex, s.index.getNextAcceptedIndex())
+	if endIndex <= startIndex {
+		return false, nil
+	}
+
+	containers, err := s.index.GetContainerRange(startIndex, endIndex-startIndex)
+	if err != nil {
+		return false, err
+	}
+	for i, container := range containers {
+		index := startIndex + uint64(i)
+		formatted, err := newFormattedContainer(container, index, s.encoding)
+		if err != nil {
+			return false, err
+		}
+		if err := s.conn.SetWriteDeadline(time.Now().Add(subscriptionWriteWait)); err != nil {
+			return false, fmt.Errorf("%w: %w", errFailedToWrite, err)
+		}
+		if err := s.conn.WriteJSON(formatted); err != nil {
+			return false, fmt.Errorf("%w: %w", errFailedToWrite, err)
+		}
+
+		s.lock.Lock()
+		s.nextIndex = index + 1
+		s.lock.Unlock()
+	}
+	return true, nil
+}
+
+// readAcks reads acknowledgements from the subscriber until the connection is
+// closed or the subscriber violates the protocol.
+func (s *subscription) readAcks() {
+	defer close(s.done)
+
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(subscriptionPongWait))
+	})
+	for {
+		var ack SubscriptionAck
+		if err := s.conn.ReadJSON(&ack); err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				s.setReadErr(errSubscriptionTimedOut)
+			} else if _, ok := err.(*websocket.CloseError); !ok {
+				s.setReadErr(err)
+			}
+			return
+		}
+		if err := s.conn.SetReadDeadline(time.Now().Add(subscriptionPongWait)); err != nil {
+			return
+		}
+
+		s.lock.Lock()
+		index := uint64(ack.Index)
+		if index >= s.nextIndex {
+			s.readErr = fmt.Errorf("%w: %d", errUnexpectedAck, index)
+			s.lock.Unlock()
+			return
+		}
+		s.nextUnacked = max(s.nextUnacked, index+1)
+		s.lock.Unlock()
+
+		select {
+		case s.acked <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (s *subscription) setReadErr(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.readErr = err
+}
+
+// notifyAccepted notifies the subscription that a container was accepted. It
+// doesn't block.
+func (s *subscription) notifyAccepted() {
+	select {
+	case s.accepted <- struct{}{}:
+	default:
+	}
+}
+
+// close attempts to close the connection gracefully, with [code] and the
+// reason [err].
+func (s *subscription) close(code int, err error) {
+	msg := websocket.FormatCloseMessage(code, err.Error())
+	_ = s.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(subscriptionWriteWait))
+}
@@ -0,0 +1,241 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/snowtest"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+type subscriptionTest struct {
+	require    *require.Assertions
+	ctx        *snow.ConsensusContext
+	index      *index
+	url        string
+	containers [][]byte
+}
+
+func newSubscriptionTest(t *testing.T) *subscriptionTest {
+	require := require.New(t)
+
+	idx, err := newIndex(memdb.New(), logging.NoLog{}, mockable.Clock{})
+	require.NoError(err)
+
+	server := httptest.NewServer(&indexHandler{
+		index: idx,
+		rpc:   http.NotFoundHandler(),
+		log:   logging.NoLog{},
+	})
+	t.Cleanup(server.Close)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	return &subscriptionTest{
+		require: require,
+		ctx:     snowtest.ConsensusContext(snowCtx),
+		index:   idx,
+		url:     "ws" + strings.TrimPrefix(server.URL, "http"),
+	}
+}
+
+func (s *subscriptionTest) accept(numContainers int) {
+	for i := 0; i < numContainers; i++ {
+		containerBytes := utils.RandomBytes(32)
+		s.require.NoError(s.index.Accept(s.ctx, ids.GenerateTestID(), containerBytes))
+		s.containers = append(s.containers, containerBytes)
+	}
+}
+
+func (s *subscriptionTest) subscribe(args *SubscribeArgs) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial(s.url, nil)
+	s.require.NoError(err)
+	s.require.NoError(conn.WriteJSON(args))
+	return conn
+}
+
+// Reads the next container from [conn] and checks that it's the container at
+// [index].
+func (s *subscriptionTest) requireNext(conn *websocket.Conn, index uint64) {
+	s.require.NoError(conn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+	var container FormattedContainer
+	s.require.NoError(conn.ReadJSON(&container))
+	s.require.Equal(json.Uint64(index), container.Index)
+
+	containerBytes, err := formatting.Decode(container.Encoding, container.Bytes)
+	s.require.NoError(err)
+	s.require.True(bytes.Equal(s.containers[index], containerBytes))
+}
+
+// Reads from [conn] until it's closed and checks that it was closed with
+// [code].
+func (s *subscriptionTest) requireClosed(conn *websocket.Conn, code int) {
+	s.require.NoError(conn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+	for {
+		_, _, err := conn.ReadMessage()
+		if err == nil {
+			continue
+		}
+		s.require.True(websocket.IsCloseError(err, code), "unexpected error: %s", err)
+		return
+	}
+}
+
+func (s *subscriptionTest) subscription() *subscription {
+	s.index.lock.RLock()
+	defer s.index.lock.RUnlock()
+
+	for sub := range s.index.subscriptions {
+		return sub
+	}
+	return nil
+}
+
+func TestSubscriptionBackfillAndTail(t *testing.T) {
+	s := newSubscriptionTest(t)
+	s.accept(5)
+
+	startIndex := json.Uint64(2)
+	conn := s.subscribe(&SubscribeArgs{
+		StartIndex: &startIndex,
+		Encoding:   formatting.HexNC,
+	})
+	defer conn.Close()
+
+	// Containers accepted before the subscription are read from disk.
+	for i := uint64(2); i < 5; i++ {
+		s.requireNext(conn, i)
+	}
+
+	// Containers accepted after the subscription are streamed as they're
+	// accepted.
+	s.accept(3)
+	for i := uint64(5); i < 8; i++ {
+		s.requireNext(conn, i)
+	}
+}
+
+func TestSubscriptionLiveOnly(t *testing.T) {
+	s := newSubscriptionTest(t)
+	s.accept(3)
+
+	conn := s.subscribe(&SubscribeArgs{})
+	defer conn.Close()
+
+	s.require.Eventually(func() bool {
+		return s.subscription() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	s.accept(1)
+	s.requireNext(conn, 3)
+}
+
+func TestSubscriptionWindow(t *testing.T) {
+	s := newSubscriptionTest(t)
+	s.accept(5)
+
+	startIndex := json.Uint64(0)
+	conn := s.subscribe(&SubscribeArgs{
+		StartIndex: &startIndex,
+		Window:     2,
+	})
+	defer conn.Close()
+
+	s.requireNext(conn, 0)
+	s.requireNext(conn, 1)
+
+	// No more containers are sent until the sent containers are acknowledged.
+	sub := s.subscription()
+	s.require.NotNil(sub)
+	s.require.Never(func() bool {
+		sub.lock.Lock()
+		defer sub.lock.Unlock()
+
+		return sub.nextIndex != 2
+	}, 100*time.Millisecond, 10*time.Millisecond)
+
+	s.require.NoError(conn.WriteJSON(&SubscriptionAck{Index: 0}))
+	s.requireNext(conn, 2)
+
+	s.require.NoError(conn.WriteJSON(&SubscriptionAck{Index: 2}))
+	s.requireNext(conn, 3)
+	s.requireNext(conn, 4)
+}
+
+func TestSubscriptionInvalid(t *testing.T) {
+	startIndex := json.Uint64(6)
+	tests := []struct {
+		name string
+		args *SubscribeArgs
+	}{
+		{
+			name: "window too large",
+			args: &SubscribeArgs{
+				Window: MaxFetchedByRange + 1,
+			},
+		},
+		{
+			name: "start index too large",
+			args: &SubscribeArgs{
+				StartIndex: &startIndex,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSubscriptionTest(t)
+			s.accept(5)
+
+			conn := s.subscribe(tt.args)
+			defer conn.Close()
+
+			s.requireClosed(conn, websocket.ClosePolicyViolation)
+			s.require.Nil(s.subscription())
+		})
+	}
+}
+
+func TestSubscriptionUnexpectedAck(t *testing.T) {
+	s := newSubscriptionTest(t)
+	s.accept(1)
+
+	startIndex := json.Uint64(0)
+	conn := s.subscribe(&SubscribeArgs{
+		StartIndex: &startIndex,
+	})
+	defer conn.Close()
+
+	s.requireNext(conn, 0)
+	s.require.NoError(conn.WriteJSON(&SubscriptionAck{Index: 1}))
+	s.requireClosed(conn, websocket.ClosePolicyViolation)
+}
+
+func TestSubscriptionIndexClosed(t *testing.T) {
+	s := newSubscriptionTest(t)
+
+	conn := s.subscribe(&SubscribeArgs{})
+	defer conn.Close()
+
+	s.require.Eventually(func() bool {
+		return s.subscription() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	s.require.NoError(s.index.Close())
+	s.requireClosed(conn, websocket.CloseGoingAway)
+}
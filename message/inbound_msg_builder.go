@@ -314,6 +314,22 @@ func InboundAppResponse(
 	}
 }
 
+func InboundAppGossip(
+	chainID ids.ID,
+	msg []byte,
+	nodeID ids.NodeID,
+) InboundMessage {
+	return &inboundMessage{
+		nodeID: nodeID,
+		op:     AppGossipOp,
+		message: &p2p.AppGossip{
+			ChainId:  chainID[:],
+			AppBytes: msg,
+		},
+		expiration: mockable.MaxTime,
+	}
+}
+
 func encodeIDs(ids []ids.ID, result [][]byte) {
 	for i, id := range ids {
 		id := id
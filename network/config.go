@@ -167,6 +167,11 @@ type Config struct {
 	// (there is one buffer per peer)
 	PeerWriteBufferSize int `json:"peerWriteBufferSize"`
 
+	// PeerMetricsMaxPeers is the maximum number of peers whose connection
+	// stats are reported as metrics labelled by their node IDs. If 0, the
+	// per-peer metrics are disabled.
+	PeerMetricsMaxPeers int `json:"peerMetricsMaxPeers"`
+
 	// Tracks the CPU/disk usage caused by processing messages of each peer.
 	ResourceTracker tracker.ResourceTracker `json:"-"`
 
@@ -311,6 +311,18 @@ func NewNetwork(
 		router:          router,
 	}
 	n.peerConfig.Network = n
+
+	if config.PeerMetricsMaxPeers > 0 {
+		err := metricsRegisterer.Register(newPeerMetrics(
+			config.PeerMetricsMaxPeers,
+			func() []peer.Info {
+				return n.PeerInfo(nil)
+			},
+		))
+		if err != nil {
+			return nil, fmt.Errorf("initializing per-peer metrics failed with: %w", err)
+		}
+	}
 	return n, nil
 }
 
@@ -633,7 +645,7 @@ func (n *network) Dispatch() error {
 				zap.Stringer("peerIP", ip),
 			)
 
-			if err := n.upgrade(conn, n.serverUpgrader); err != nil {
+			if err := n.upgrade(conn, n.serverUpgrader, true); err != nil {
 				n.peerConfig.Log.Verbo("failed to upgrade connection",
 					zap.String("direction", "inbound"),
 					zap.Error(err),
@@ -980,7 +992,7 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 				zap.Stringer("peerIP", ip.ip),
 			)
 
-			err = n.upgrade(conn, n.clientUpgrader)
+			err = n.upgrade(conn, n.clientUpgrader, false)
 			if err != nil {
 				n.peerConfig.Log.Verbo(
 					"failed to upgrade, attempting again",
@@ -996,14 +1008,14 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 }
 
 // upgrade the provided connection, which may be an inbound connection or an
-// outbound connection, with the provided [upgrader].
+// outbound connection, as specified by [inbound], with the provided [upgrader].
 //
 // If the connection is successfully upgraded, [nil] will be returned.
 //
 // If the connection is desired by the node, then the resulting upgraded
 // connection will be used to create a new peer. Otherwise the connection will
 // be immediately closed.
-func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
+func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader, inbound bool) error {
 	upgradeTimeout := n.peerConfig.Clock.Time().Add(n.config.ReadHandshakeTimeout)
 	if err := conn.SetReadDeadline(upgradeTimeout); err != nil {
 		_ = conn.Close()
@@ -1103,6 +1115,7 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 			n.peerConfig.Log,
 			n.outboundMsgThrottler,
 		),
+		inbound,
 	)
 	n.connectingPeers.Add(peer)
 	n.peersLock.Unlock()
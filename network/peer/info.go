@@ -23,4 +23,6 @@ type Info struct {
 	TrackedSubnets set.Set[ids.ID] `json:"trackedSubnets"`
 	SupportedACPs  set.Set[uint32] `json:"supportedACPs"`
 	ObjectedACPs   set.Set[uint32] `json:"objectedACPs"`
+	// Stats of the connection to the peer.
+	Stats *Stats `json:"stats,omitempty"`
 }
@@ -47,6 +47,9 @@ type MessageQueue interface {
 	// available or the queue is closed, then `false` is returned.
 	PopNow() (message.OutboundMessage, bool)
 
+	// Len returns the number of messages that are waiting to be sent.
+	Len() int
+
 	// Close empties the queue and prevents further messages from being pushed
 	// onto it. After calling close once, future calls to close will do nothing.
 	Close()
@@ -174,6 +177,16 @@ func (q *throttledMessageQueue) pop() message.OutboundMessage {
 	return msg
 }
 
+func (q *throttledMessageQueue) Len() int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.closed {
+		return 0
+	}
+	return q.queue.Len()
+}
+
 func (q *throttledMessageQueue) Close() {
 	q.cond.L.Lock()
 	defer q.cond.L.Unlock()
@@ -285,6 +298,10 @@ func (q *blockingMessageQueue) PopNow() (message.OutboundMessage, bool) {
 	}
 }
 
+func (q *blockingMessageQueue) Len() int {
+	return len(q.queue)
+}
+
 func (q *blockingMessageQueue) Close() {
 	q.closeOnce.Do(func() {
 		close(q.closing)
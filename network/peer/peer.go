@@ -138,6 +138,9 @@ type peer struct {
 	// queue of messages to send to this peer.
 	messageQueue MessageQueue
 
+	// stats of the connection to this peer.
+	stats *statsTracker
+
 	// ip is the claimed IP the peer gave us in the Handshake message.
 	ip *SignedIP
 	// version is the claimed version the peer is running that we received in
@@ -198,6 +201,8 @@ type peer struct {
 
 // Start a new peer instance.
 //
+// [inbound] is true if the peer initiated the connection.
+//
 // Invariant: There must only be one peer running at a time with a reference to
 // the same [config.InboundMsgThrottler].
 func Start(
@@ -206,6 +211,7 @@ func Start(
 	cert *staking.Certificate,
 	id ids.NodeID,
 	messageQueue MessageQueue,
+	inbound bool,
 ) Peer {
 	onClosingCtx, onClosingCtxCancel := context.WithCancel(context.Background())
 	p := &peer{
@@ -214,6 +220,7 @@ func Start(
 		cert:               cert,
 		id:                 id,
 		messageQueue:       messageQueue,
+		stats:              newStatsTracker(inbound, config.Clock.Time()),
 		onFinishHandshake:  make(chan struct{}),
 		numExecuting:       3,
 		onClosingCtx:       onClosingCtx,
@@ -281,6 +288,7 @@ func (p *peer) Info() Info {
 		TrackedSubnets: p.trackedSubnets,
 		SupportedACPs:  p.supportedACPs,
 		ObjectedACPs:   p.objectedACPs,
+		Stats:          p.stats.stats(p.messageQueue.Len(), p.Clock.Time()),
 	}
 }
 
@@ -411,11 +419,13 @@ func (p *peer) readMessages() {
 		// exited before calling [Network.Disconnected] to guarantee that there
 		// can't be multiple instances of this goroutine running over different
 		// peer instances.
+		startedWaiting := p.Clock.Time()
 		onFinishedHandling := p.InboundMsgThrottler.Acquire(
 			p.onClosingCtx,
 			uint64(msgLen),
 			p.id,
 		)
+		p.stats.throttled(p.Clock.Time().Sub(startedWaiting))
 
 		// If the peer is shutting down, there's no need to read the message.
 		if err := p.onClosingCtx.Err(); err != nil {
@@ -478,6 +488,7 @@ func (p *peer) readMessages() {
 		now := p.Clock.Time()
 		p.storeLastReceived(now)
 		p.Metrics.Received(msg, msgLen)
+		p.stats.received(msg.Op(), msgLen, now)
 
 		// Handle the message. Note that when we are done handling this message,
 		// we must call [msg.OnFinishedHandling()].
@@ -611,6 +622,7 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 	now := p.Clock.Time()
 	p.storeLastSent(now)
 	p.Metrics.Sent(msg)
+	p.stats.sent(msg.Op(), len(msgBytes), now)
 }
 
 func (p *peer) sendNetworkMessages() {
@@ -26,6 +26,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/math/meter"
 	"github.com/ava-labs/avalanchego/utils/resource"
@@ -141,6 +142,7 @@ func startTestPeer(self *rawTestPeer, peer *rawTestPeer, conn net.Conn) *testPee
 				logging.NoLog{},
 				throttling.NewNoOutboundThrottler(),
 			),
+			false,
 		),
 		inboundMsgChan: self.inboundMsgChan,
 	}
@@ -240,6 +242,45 @@ func TestPingUptimes(t *testing.T) {
 	require.Equal(uint32(1), uptime)
 }
 
+func TestInfoStats(t *testing.T) {
+	sharedConfig := newConfig(t)
+
+	rawPeer0 := newRawTestPeer(t, sharedConfig)
+	rawPeer1 := newRawTestPeer(t, sharedConfig)
+
+	require := require.New(t)
+
+	peer0, peer1 := startTestPeers(rawPeer0, rawPeer1)
+	awaitReady(t, peer0, peer1)
+	defer func() {
+		peer1.StartClose()
+		peer0.StartClose()
+		require.NoError(peer0.AwaitClosed(context.Background()))
+		require.NoError(peer1.AwaitClosed(context.Background()))
+	}()
+	pingMsg, err := sharedConfig.MessageCreator.Ping(1)
+	require.NoError(err)
+	require.True(peer0.Send(context.Background(), pingMsg))
+	sendAndFlush(t, peer0, peer1)
+
+	stats := peer1.Info().Stats
+	require.NotNil(stats)
+	require.False(stats.Inbound)
+	require.Equal(json.Uint64(1), stats.Ops[message.PingOp.String()].MessagesReceived)
+	require.Equal(json.Uint64(1), stats.Ops[message.GetOp.String()].MessagesReceived)
+	require.Positive(stats.BytesReceived)
+
+	// peer1 answers the ping with a pong, which measures the round trip time.
+	require.Eventually(func() bool {
+		return len(peer0.Info().Stats.PingRTTs) == 1
+	}, time.Minute, time.Millisecond)
+
+	stats = peer0.Info().Stats
+	require.Equal(json.Uint64(1), stats.Ops[message.PingOp.String()].MessagesSent)
+	require.Equal(json.Uint64(1), stats.Ops[message.PongOp.String()].MessagesReceived)
+	require.Zero(stats.SendQueueLength)
+}
+
 func TestTrackedSubnets(t *testing.T) {
 	sharedConfig := newConfig(t)
 	rawPeer0 := newRawTestPeer(t, sharedConfig)
@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/buffer"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+// maxPingRTTs is the number of ping round trip times that are remembered for
+// each peer.
+const maxPingRTTs = 16
+
+// Stats describes the connection to a peer.
+type Stats struct {
+	// Inbound is true if the peer connected to this node, and false if this
+	// node connected to the peer.
+	Inbound bool `json:"inbound"`
+	// ConnectedAt is the time the connection was established.
+	ConnectedAt time.Time `json:"connectedAt"`
+	// ConnectionAge is the amount of time the connection has been open.
+	ConnectionAge time.Duration `json:"connectionAge"`
+
+	MessagesSent     json.Uint64 `json:"messagesSent"`
+	BytesSent        json.Uint64 `json:"bytesSent"`
+	MessagesReceived json.Uint64 `json:"messagesReceived"`
+	BytesReceived    json.Uint64 `json:"bytesReceived"`
+	// Ops maps message ops to the messages of that op that were sent and
+	// received.
+	Ops map[string]OpStats `json:"ops"`
+
+	// PingRTTs are the round trip times of the most recent pings, from the
+	// oldest to the newest.
+	PingRTTs []time.Duration `json:"pingRTTs"`
+	// SendQueueLength is the number of messages waiting to be sent.
+	SendQueueLength json.Uint64 `json:"sendQueueLength"`
+	// InboundThrottlerWaitTime is the total amount of time spent waiting for
+	// the inbound message throttler before reading messages from the peer.
+	InboundThrottlerWaitTime time.Duration `json:"inboundThrottlerWaitTime"`
+}
+
+// OpStats counts the messages of an op that were sent and received.
+type OpStats struct {
+	MessagesSent     json.Uint64 `json:"messagesSent"`
+	BytesSent        json.Uint64 `json:"bytesSent"`
+	MessagesReceived json.Uint64 `json:"messagesReceived"`
+	BytesReceived    json.Uint64 `json:"bytesReceived"`
+}
+
+// statsTracker collects the statistics of the connection to a peer.
+type statsTracker struct {
+	inbound     bool
+	connectedAt time.Time
+
+	lock sync.Mutex
+	ops  map[message.Op]*OpStats
+	// pingSentAt is the time the latest ping was sent, or the zero time if a
+	// pong was received since.
+	pingSentAt        time.Time
+	pingRTTs          buffer.Deque[time.Duration]
+	throttlerWaitTime time.Duration
+}
+
+func newStatsTracker(inbound bool, connectedAt time.Time) *statsTracker {
+	return &statsTracker{
+		inbound:     inbound,
+		connectedAt: connectedAt,
+		ops:         make(map[message.Op]*OpStats),
+		pingRTTs:    buffer.NewUnboundedDeque[time.Duration](maxPingRTTs),
+	}
+}
+
+func (s *statsTracker) sent(op message.Op, numBytes int, now time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stats := s.opStats(op)
+	stats.MessagesSent++
+	stats.BytesSent += json.Uint64(numBytes)
+
+	if op == message.PingOp {
+		s.pingSentAt = now
+	}
+}
+
+func (s *statsTracker) received(op message.Op, numBytes uint32, now time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stats := s.opStats(op)
+	stats.MessagesReceived++
+	stats.BytesReceived += json.Uint64(numBytes)
+
+	// Pongs don't identify the ping they answer, so a pong is assumed to
+	// answer the latest ping.
+	if op == message.PongOp && !s.pingSentAt.IsZero() {
+		if s.pingRTTs.Len() == maxPingRTTs {
+			_, _ = s.pingRTTs.PopLeft()
+		}
+		s.pingRTTs.PushRight(now.Sub(s.pingSentAt))
+		s.pingSentAt = time.Time{}
+	}
+}
+
+func (s *statsTracker) throttled(waitTime time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.throttlerWaitTime += waitTime
+}
+
+// opStats returns the stats of [op].
+//
+// Assumes [s.lock] is held.
+func (s *statsTracker) opStats(op message.Op) *OpStats {
+	stats, ok := s.ops[op]
+	if !ok {
+		stats = &OpStats{}
+		s.ops[op] = stats
+	}
+	return stats
+}
+
+func (s *statsTracker) stats(sendQueueLength int, now time.Time) *Stats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stats := &Stats{
+		Inbound:                  s.inbound,
+		ConnectedAt:              s.connectedAt,
+		ConnectionAge:            now.Sub(s.connectedAt),
+		Ops:                      make(map[string]OpStats, len(s.ops)),
+		PingRTTs:                 s.pingRTTs.List(),
+		SendQueueLength:          json.Uint64(sendQueueLength),
+		InboundThrottlerWaitTime: s.throttlerWaitTime,
+	}
+	for op, opStats := range s.ops {
+		stats.MessagesSent += opStats.MessagesSent
+		stats.BytesSent += opStats.BytesSent
+		stats.MessagesReceived += opStats.MessagesReceived
+		stats.BytesReceived += opStats.BytesReceived
+		stats.Ops[op.String()] = *opStats
+	}
+	return stats
+}
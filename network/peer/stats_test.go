@@ -0,0 +1,59 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+func TestStatsTracker(t *testing.T) {
+	require := require.New(t)
+
+	start := time.Unix(1_000_000, 0)
+	s := newStatsTracker(true, start)
+
+	now := start
+	s.sent(message.GetOp, 10, now)
+	s.received(message.PutOp, 100, now)
+	s.received(message.PutOp, 50, now)
+	s.throttled(time.Second)
+
+	// A pong without a ping doesn't have a round trip time.
+	s.received(message.PongOp, 1, now)
+
+	for i := 1; i <= maxPingRTTs+1; i++ {
+		s.sent(message.PingOp, 2, now)
+		now = now.Add(time.Duration(i) * time.Millisecond)
+		s.received(message.PongOp, 1, now)
+	}
+
+	stats := s.stats(3, now)
+	require.True(stats.Inbound)
+	require.Equal(start, stats.ConnectedAt)
+	require.Equal(now.Sub(start), stats.ConnectionAge)
+	require.Equal(json.Uint64(1+maxPingRTTs+1), stats.MessagesSent)
+	require.Equal(json.Uint64(10+2*(maxPingRTTs+1)), stats.BytesSent)
+	require.Equal(json.Uint64(2+1+maxPingRTTs+1), stats.MessagesReceived)
+	require.Equal(json.Uint64(150+1+maxPingRTTs+1), stats.BytesReceived)
+	require.Equal(
+		OpStats{
+			MessagesReceived: 2,
+			BytesReceived:    150,
+		},
+		stats.Ops[message.PutOp.String()],
+	)
+	require.Equal(json.Uint64(3), stats.SendQueueLength)
+	require.Equal(time.Second, stats.InboundThrottlerWaitTime)
+
+	// Only the latest round trip times are remembered.
+	require.Len(stats.PingRTTs, maxPingRTTs)
+	require.Equal(2*time.Millisecond, stats.PingRTTs[0])
+	require.Equal(time.Duration(maxPingRTTs+1)*time.Millisecond, stats.PingRTTs[maxPingRTTs-1])
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/network/peer"
+)
+
+const (
+	nodeIDLabel = "nodeID"
+	ioLabel     = "io"
+
+	sentLabel     = "sent"
+	receivedLabel = "received"
+)
+
+var (
+	_ prometheus.Collector = (*peerMetrics)(nil)
+
+	nodeIDLabels   = []string{nodeIDLabel}
+	nodeIDIOLabels = []string{nodeIDLabel, ioLabel}
+)
+
+// peerMetrics reports the stats of the connections to peers, labelled by the
+// node IDs of the peers.
+//
+// To limit the cardinality of the metrics, at most [maxPeers] peers are
+// reported. If more peers are connected, the peers with the oldest connections
+// are reported, so that the reported peers rarely change.
+type peerMetrics struct {
+	maxPeers int
+	peerInfo func() []peer.Info
+
+	msgs                 *prometheus.Desc
+	bytes                *prometheus.Desc
+	sendQueueLength      *prometheus.Desc
+	pingRTT              *prometheus.Desc
+	inboundThrottlerWait *prometheus.Desc
+}
+
+func newPeerMetrics(maxPeers int, peerInfo func() []peer.Info) *peerMetrics {
+	return &peerMetrics{
+		maxPeers: maxPeers,
+		peerInfo: peerInfo,
+		msgs: prometheus.NewDesc(
+			"peer_msgs",
+			"number of messages exchanged with the peer",
+			nodeIDIOLabels,
+			nil,
+		),
+		bytes: prometheus.NewDesc(
+			"peer_msgs_bytes",
+			"number of message bytes exchanged with the peer",
+			nodeIDIOLabels,
+			nil,
+		),
+		sendQueueLength: prometheus.NewDesc(
+			"peer_send_queue_length",
+			"number of messages waiting to be sent to the peer",
+			nodeIDLabels,
+			nil,
+		),
+		pingRTT: prometheus.NewDesc(
+			"peer_ping_rtt",
+			"round trip time (in ns) of the latest ping sent to the peer",
+			nodeIDLabels,
+			nil,
+		),
+		inboundThrottlerWait: prometheus.NewDesc(
+			"peer_inbound_throttler_wait",
+			"time (in ns) spent waiting for the inbound message throttler before reading messages from the peer",
+			nodeIDLabels,
+			nil,
+		),
+	}
+}
+
+func (m *peerMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.msgs
+	ch <- m.bytes
+	ch <- m.sendQueueLength
+	ch <- m.pingRTT
+	ch <- m.inboundThrottlerWait
+}
+
+func (m *peerMetrics) Collect(ch chan<- prometheus.Metric) {
+	peers := m.peerInfo()
+	slices.SortFunc(peers, func(a, b peer.Info) int {
+		if c := a.Stats.ConnectedAt.Compare(b.Stats.ConnectedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+	if len(peers) > m.maxPeers {
+		peers = peers[:m.maxPeers]
+	}
+
+	for _, p := range peers {
+		nodeID := p.ID.String()
+		stats := p.Stats
+		ch <- prometheus.MustNewConstMetric(m.msgs, prometheus.CounterValue, float64(stats.MessagesSent), nodeID, sentLabel)
+		ch <- prometheus.MustNewConstMetric(m.msgs, prometheus.CounterValue, float64(stats.MessagesReceived), nodeID, receivedLabel)
+		ch <- prometheus.MustNewConstMetric(m.bytes, prometheus.CounterValue, float64(stats.BytesSent), nodeID, sentLabel)
+		ch <- prometheus.MustNewConstMetric(m.bytes, prometheus.CounterValue, float64(stats.BytesReceived), nodeID, receivedLabel)
+		ch <- prometheus.MustNewConstMetric(m.sendQueueLength, prometheus.GaugeValue, float64(stats.SendQueueLength), nodeID)
+		if numRTTs := len(stats.PingRTTs); numRTTs > 0 {
+			ch <- prometheus.MustNewConstMetric(m.pingRTT, prometheus.GaugeValue, float64(stats.PingRTTs[numRTTs-1]), nodeID)
+		}
+		ch <- prometheus.MustNewConstMetric(m.inboundThrottlerWait, prometheus.CounterValue, float64(stats.InboundThrottlerWaitTime), nodeID)
+	}
+}
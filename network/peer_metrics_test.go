@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/peer"
+)
+
+func TestPeerMetrics(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		start  = time.Unix(1_000_000, 0)
+		nodeID = ids.BuildTestNodeID([]byte{1})
+		peers  = []peer.Info{
+			{
+				ID: ids.BuildTestNodeID([]byte{2}),
+				Stats: &peer.Stats{
+					ConnectedAt: start.Add(time.Second),
+				},
+			},
+			{
+				ID: nodeID,
+				Stats: &peer.Stats{
+					ConnectedAt:              start,
+					MessagesSent:             1,
+					BytesSent:                2,
+					MessagesReceived:         3,
+					BytesReceived:            4,
+					PingRTTs:                 []time.Duration{5, 6},
+					SendQueueLength:          7,
+					InboundThrottlerWaitTime: 8,
+				},
+			},
+		}
+	)
+	m := newPeerMetrics(1, func() []peer.Info {
+		return peers
+	})
+
+	// Only the peer with the oldest connection is reported.
+	expected := strings.ReplaceAll(`
+# HELP peer_inbound_throttler_wait time (in ns) spent waiting for the inbound message throttler before reading messages from the peer
+# TYPE peer_inbound_throttler_wait counter
+peer_inbound_throttler_wait{nodeID="NODE_ID"} 8
+# HELP peer_msgs number of messages exchanged with the peer
+# TYPE peer_msgs counter
+peer_msgs{io="received",nodeID="NODE_ID"} 3
+peer_msgs{io="sent",nodeID="NODE_ID"} 1
+# HELP peer_msgs_bytes number of message bytes exchanged with the peer
+# TYPE peer_msgs_bytes counter
+peer_msgs_bytes{io="received",nodeID="NODE_ID"} 4
+peer_msgs_bytes{io="sent",nodeID="NODE_ID"} 2
+# HELP peer_ping_rtt round trip time (in ns) of the latest ping sent to the peer
+# TYPE peer_ping_rtt gauge
+peer_ping_rtt{nodeID="NODE_ID"} 6
+# HELP peer_send_queue_length number of messages waiting to be sent to the peer
+# TYPE peer_send_queue_length gauge
+peer_send_queue_length{nodeID="NODE_ID"} 7
+`, "NODE_ID", nodeID.String())
+	require.NoError(testutil.CollectAndCompare(m, strings.NewReader(expected)))
+}
@@ -8,12 +8,15 @@ import (
 	"net/netip"
 	"time"
 
+	"github.com/ava-labs/avalanchego/api/auth"
+	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/subnets"
@@ -44,6 +47,10 @@ type HTTPConfig struct {
 	HTTPAllowedOrigins []string `json:"httpAllowedOrigins"`
 	HTTPAllowedHosts   []string `json:"httpAllowedHosts"`
 
+	// HTTPAuthConfig contains secrets, so only whether it's set is logged.
+	HTTPAuthEnabled bool         `json:"httpAuthEnabled"`
+	HTTPAuthConfig  *auth.Config `json:"-"`
+
 	ShutdownTimeout time.Duration `json:"shutdownTimeout"`
 	ShutdownWait    time.Duration `json:"shutdownWait"`
 }
@@ -53,6 +60,7 @@ type APIConfig struct {
 
 	// Enable/Disable APIs
 	AdminAPIEnabled    bool `json:"adminAPIEnabled"`
+	EventsAPIEnabled   bool `json:"eventsAPIEnabled"`
 	InfoAPIEnabled     bool `json:"infoAPIEnabled"`
 	KeystoreAPIEnabled bool `json:"keystoreAPIEnabled"`
 	MetricsAPIEnabled  bool `json:"metricsAPIEnabled"`
@@ -141,7 +149,9 @@ type Config struct {
 	NetworkID uint32 `json:"networkID"`
 
 	// Health
-	HealthCheckFreq time.Duration `json:"healthCheckFreq"`
+	HealthCheckFreq     time.Duration        `json:"healthCheckFreq"`
+	HealthConfig        health.Config        `json:"healthConfig"`
+	HealthWebhookConfig health.WebhookConfig `json:"healthWebhookConfig"`
 
 	// Network configuration
 	NetworkConfig network.Config `json:"networkConfig"`
@@ -169,6 +179,9 @@ type Config struct {
 	// ConsensusAppConcurrency defines the maximum number of goroutines to
 	// handle App messages per chain.
 	ConsensusAppConcurrency int `json:"consensusAppConcurrency"`
+	// ConsensusPriorityConfig defines how messages of different priorities are
+	// scheduled per chain.
+	ConsensusPriorityConfig handler.PriorityConfig `json:"consensusPriorityConfig"`
 
 	TrackedSubnets set.Set[ids.ID] `json:"trackedSubnets"`
 
@@ -0,0 +1,138 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/pubsub"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+const eventsAcceptorName = "events"
+
+var (
+	_ chains.Registrant                  = (*eventPublisher)(nil)
+	_ snow.Acceptor                      = (*eventPublisher)(nil)
+	_ snow.Rejector                      = (*eventPublisher)(nil)
+	_ validators.ManagerCallbackListener = (*eventPublisher)(nil)
+	_ health.Listener                    = (*eventPublisher)(nil)
+	_ router.Router                      = (*peerEventRouter)(nil)
+)
+
+// eventPublisher publishes the events of the node to the subscribers of the
+// Events API.
+type eventPublisher struct {
+	log                logging.Logger
+	server             *pubsub.Server
+	blockAcceptorGroup snow.AcceptorGroup
+}
+
+func newEventPublisher(log logging.Logger, blockAcceptorGroup snow.AcceptorGroup) *eventPublisher {
+	return &eventPublisher{
+		log:                log,
+		server:             pubsub.New(log),
+		blockAcceptorGroup: blockAcceptorGroup,
+	}
+}
+
+func (e *eventPublisher) RegisterChain(chainName string, ctx *snow.ConsensusContext, _ common.VM) {
+	err := e.blockAcceptorGroup.RegisterAcceptor(ctx.ChainID, eventsAcceptorName, e, false /*=dieOnError*/)
+	if err != nil {
+		e.log.Error("failed to publish the blocks of chain",
+			zap.String("chainName", chainName),
+			zap.Error(err),
+		)
+	}
+}
+
+func (e *eventPublisher) Accept(ctx *snow.ConsensusContext, blkID ids.ID, _ []byte) error {
+	e.server.PublishEvent(pubsub.NewEvent(pubsub.BlockAcceptedTopic, &pubsub.BlockEvent{
+		ChainID: ctx.ChainID,
+		BlockID: blkID,
+	}))
+	return nil
+}
+
+func (e *eventPublisher) Reject(ctx *snow.ConsensusContext, blkID ids.ID, _ []byte) {
+	e.server.PublishEvent(pubsub.NewEvent(pubsub.BlockRejectedTopic, &pubsub.BlockEvent{
+		ChainID: ctx.ChainID,
+		BlockID: blkID,
+	}))
+}
+
+func (e *eventPublisher) OnValidatorAdded(subnetID ids.ID, nodeID ids.NodeID, _ *bls.PublicKey, txID ids.ID, weight uint64) {
+	e.server.PublishEvent(pubsub.NewEvent(pubsub.ValidatorAddedTopic, &pubsub.ValidatorAddedEvent{
+		SubnetID: subnetID,
+		NodeID:   nodeID,
+		TxID:     txID,
+		Weight:   json.Uint64(weight),
+	}))
+}
+
+func (e *eventPublisher) OnValidatorRemoved(subnetID ids.ID, nodeID ids.NodeID, weight uint64) {
+	e.server.PublishEvent(pubsub.NewEvent(pubsub.ValidatorRemovedTopic, &pubsub.ValidatorRemovedEvent{
+		SubnetID: subnetID,
+		NodeID:   nodeID,
+		Weight:   json.Uint64(weight),
+	}))
+}
+
+func (e *eventPublisher) OnValidatorWeightChanged(subnetID ids.ID, nodeID ids.NodeID, oldWeight, newWeight uint64) {
+	e.server.PublishEvent(pubsub.NewEvent(pubsub.ValidatorWeightChangedTopic, &pubsub.ValidatorWeightChangedEvent{
+		SubnetID:       subnetID,
+		NodeID:         nodeID,
+		PreviousWeight: json.Uint64(oldWeight),
+		Weight:         json.Uint64(newWeight),
+	}))
+}
+
+func (e *eventPublisher) OnCheckChanged(namespace string, name string, result health.Result) {
+	event := &pubsub.HealthChangedEvent{
+		Namespace: namespace,
+		Check:     name,
+		Healthy:   result.Error == nil,
+	}
+	if result.Error != nil {
+		event.Error = *result.Error
+	}
+	e.server.PublishEvent(pubsub.NewEvent(pubsub.HealthChangedTopic, event))
+}
+
+// peerEventRouter publishes the connections and disconnections of peers before
+// routing them.
+type peerEventRouter struct {
+	router.Router
+	events *eventPublisher
+}
+
+func (p *peerEventRouter) Connected(nodeID ids.NodeID, nodeVersion *version.Application, subnetID ids.ID) {
+	// Peers are connected to the primary network before any other subnet, so
+	// that connection is the one that is published.
+	if subnetID == constants.PrimaryNetworkID {
+		p.events.server.PublishEvent(pubsub.NewEvent(pubsub.PeerConnectedTopic, &pubsub.PeerConnectedEvent{
+			NodeID:  nodeID,
+			Version: nodeVersion.String(),
+		}))
+	}
+	p.Router.Connected(nodeID, nodeVersion, subnetID)
+}
+
+func (p *peerEventRouter) Disconnected(nodeID ids.NodeID) {
+	p.events.server.PublishEvent(pubsub.NewEvent(pubsub.PeerDisconnectedTopic, &pubsub.PeerDisconnectedEvent{
+		NodeID: nodeID,
+	}))
+	p.Router.Disconnected(nodeID)
+}
@@ -49,6 +49,7 @@ import (
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/pubsub"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
@@ -230,12 +231,15 @@ func New(
 	}
 	n.initCPUTargeter(&config.CPUTargeterConfig)
 	n.initDiskTargeter(&config.DiskTargeterConfig)
+
+	// Has to be initialized before networking so that peer events can be
+	// published
+	n.initEventDispatchers()
+
 	if err := n.initNetworking(networkRegisterer); err != nil { // Set up networking layer.
 		return nil, fmt.Errorf("problem initializing networking: %w", err)
 	}
 
-	n.initEventDispatchers()
-
 	// Start the Health API
 	// Has to be initialized before chain manager
 	// [n.Net] must already be set
@@ -254,6 +258,9 @@ func New(
 	if err := n.initAdminAPI(); err != nil { // Start the Admin API
 		return nil, fmt.Errorf("couldn't initialize admin API: %w", err)
 	}
+	if err := n.initEventsAPI(); err != nil { // Start the Events API
+		return nil, fmt.Errorf("couldn't initialize events API: %w", err)
+	}
 	if err := n.initInfoAPI(); err != nil { // Start the Info API
 		return nil, fmt.Errorf("couldn't initialize info API: %w", err)
 	}
@@ -313,6 +320,9 @@ type Node struct {
 
 	// Monitors node health and runs health checks
 	health health.Health
+	// Notifies webhooks when the node starts or stops being ready, healthy,
+	// or alive. Nil if no webhooks are configured.
+	healthWebhooks *health.WebhookNotifier
 
 	// Build and parse messages, for both network layer and chain manager
 	msgCreator message.Creator
@@ -333,6 +343,10 @@ type Node struct {
 	TxAcceptorGroup     snow.AcceptorGroup
 	VertexAcceptorGroup snow.AcceptorGroup
 
+	// Publishes node events to the Events API. Nil if the Events API is
+	// disabled.
+	events *eventPublisher
+
 	// Net runs the networking stack
 	Net network.Network
 
@@ -614,6 +628,13 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 		close(n.onSufficientlyConnected)
 	}
 
+	if n.events != nil {
+		consensusRouter = &peerEventRouter{
+			Router: consensusRouter,
+			events: n.events,
+		}
+	}
+
 	// add node configs to network config
 	n.Config.NetworkConfig.MyNodeID = n.ID
 	n.Config.NetworkConfig.MyIPPort = atomicIP
@@ -879,6 +900,9 @@ func (n *Node) initEventDispatchers() {
 	n.BlockAcceptorGroup = snow.NewAcceptorGroup(n.Log)
 	n.TxAcceptorGroup = snow.NewAcceptorGroup(n.Log)
 	n.VertexAcceptorGroup = snow.NewAcceptorGroup(n.Log)
+	if n.Config.EventsAPIEnabled {
+		n.events = newEventPublisher(n.Log, n.BlockAcceptorGroup)
+	}
 }
 
 // Initialize [n.indexer].
@@ -1043,6 +1067,7 @@ func (n *Node) initAPIServer() error {
 		apiRegisterer,
 		n.Config.HTTPConfig.HTTPConfig,
 		n.Config.HTTPAllowedHosts,
+		n.Config.HTTPAuthConfig,
 	)
 	return err
 }
@@ -1133,6 +1158,12 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		return fmt.Errorf("failed to initialize subnets: %w", err)
 	}
 
+	// Block rejections are only listened to by the Events API
+	var blockRejector snow.Rejector
+	if n.events != nil {
+		blockRejector = n.events
+	}
+
 	n.chainManager, err = chains.New(
 		&chains.ManagerConfig{
 			SybilProtectionEnabled:                  n.Config.SybilProtectionEnabled,
@@ -1143,6 +1174,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 			LogFactory:                              n.LogFactory,
 			VMManager:                               n.VMManager,
 			BlockAcceptorGroup:                      n.BlockAcceptorGroup,
+			BlockRejector:                           blockRejector,
 			TxAcceptorGroup:                         n.TxAcceptorGroup,
 			VertexAcceptorGroup:                     n.VertexAcceptorGroup,
 			DB:                                      n.DB,
@@ -1170,6 +1202,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 			ChainConfigs:                            n.Config.ChainConfigs,
 			FrontierPollFrequency:                   n.Config.FrontierPollFrequency,
 			ConsensusAppConcurrency:                 n.Config.ConsensusAppConcurrency,
+			MessagePriorities:                       n.Config.ConsensusPriorityConfig,
 			BootstrapMaxTimeGetAncestors:            n.Config.BootstrapMaxTimeGetAncestors,
 			BootstrapAncestorsMaxContainersSent:     n.Config.BootstrapAncestorsMaxContainersSent,
 			BootstrapAncestorsMaxContainersReceived: n.Config.BootstrapAncestorsMaxContainersReceived,
@@ -1359,6 +1392,7 @@ func (n *Node) initAdminAPI() error {
 			NodeConfig:   n.Config,
 			VMManager:    n.VMManager,
 			VMRegistry:   n.VMRegistry,
+			Benchlist:    n.benchlistManager,
 		},
 	)
 	if err != nil {
@@ -1371,6 +1405,34 @@ func (n *Node) initAdminAPI() error {
 	)
 }
 
+// initEventsAPI initializes the Events API
+// Assumes n.events, n.vdrs, n.health, and n.chainManager already initialized
+func (n *Node) initEventsAPI() error {
+	if !n.Config.EventsAPIEnabled {
+		n.Log.Info("skipping events API initialization because it has been disabled")
+		return nil
+	}
+	n.Log.Info("initializing events API")
+	n.vdrs.RegisterCallbackListener(n.events)
+	n.health.RegisterListener(n.events)
+	// Chain manager will notify the publisher when a chain is created
+	n.chainManager.AddRegistrant(n.events)
+
+	err := n.APIServer.AddRoute(
+		n.events.server,
+		"events",
+		"",
+	)
+	if err != nil {
+		return err
+	}
+	return n.APIServer.AddRoute(
+		pubsub.NewSchemaHandler(),
+		"events",
+		"/schema",
+	)
+}
+
 // initProfiler initializes the continuous profiling
 func (n *Node) initProfiler() {
 	if !n.Config.ProfilerConfig.Enabled {
@@ -1403,16 +1465,17 @@ func (n *Node) initInfoAPI() error {
 
 	n.Log.Info("initializing info API")
 
+	parameters := info.Parameters{
+		Version:     version.CurrentApp,
+		NodeID:      n.ID,
+		NodePOP:     signer.NewProofOfPossession(n.Config.StakingSigningKey),
+		NetworkID:   n.Config.NetworkID,
+		TxFeeConfig: n.Config.TxFeeConfig,
+		VMManager:   n.VMManager,
+		Upgrades:    n.Config.UpgradeConfig,
+	}
 	service, err := info.NewService(
-		info.Parameters{
-			Version:     version.CurrentApp,
-			NodeID:      n.ID,
-			NodePOP:     signer.NewProofOfPossession(n.Config.StakingSigningKey),
-			NetworkID:   n.Config.NetworkID,
-			TxFeeConfig: n.Config.TxFeeConfig,
-			VMManager:   n.VMManager,
-			Upgrades:    n.Config.UpgradeConfig,
-		},
+		parameters,
 		n.Log,
 		n.vdrs,
 		n.chainManager,
@@ -1424,11 +1487,32 @@ func (n *Node) initInfoAPI() error {
 	if err != nil {
 		return err
 	}
-	return n.APIServer.AddRoute(
+	err = n.APIServer.AddRoute(
 		service,
 		"info",
 		"",
 	)
+	if err != nil {
+		return err
+	}
+
+	// The gRPC service is served at the paths of its services rather than at
+	// this route.
+	grpcService := info.NewGRPCService(
+		parameters,
+		n.Log,
+		n.vdrs,
+		n.chainManager,
+		n.VMManager,
+		n.Config.NetworkConfig.MyIPPort,
+		n.Net,
+		n.benchlistManager,
+	)
+	return n.APIServer.AddRoute(
+		grpcService,
+		"info",
+		"/grpc",
+	)
 }
 
 // initHealthAPI initializes the Health API service
@@ -1442,11 +1526,22 @@ func (n *Node) initHealthAPI() error {
 		return err
 	}
 
-	n.health, err = health.New(n.Log, healthReg)
+	n.health, err = health.New(n.Log, n.Config.HealthConfig, healthReg)
 	if err != nil {
 		return err
 	}
 
+	if len(n.Config.HealthWebhookConfig.URLs) > 0 {
+		n.healthWebhooks = health.NewWebhookNotifier(
+			n.Log,
+			n.ID,
+			n.Config.HealthWebhookConfig,
+			n.health,
+		)
+		n.health.RegisterListener(n.healthWebhooks)
+		go n.Log.RecoverAndPanic(n.healthWebhooks.Dispatch)
+	}
+
 	if !n.Config.HealthAPIEnabled {
 		n.Log.Info("skipping health API initialization because it has been disabled")
 		return nil
@@ -1528,11 +1623,20 @@ func (n *Node) initHealthAPI() error {
 		return err
 	}
 
-	return n.APIServer.AddRoute(
+	err = n.APIServer.AddRoute(
 		health.NewGetHandler(n.health.Liveness),
 		"health",
 		"/liveness",
 	)
+	if err != nil {
+		return err
+	}
+
+	return n.APIServer.AddRoute(
+		health.NewGRPCService(n.Log, n.health),
+		"health",
+		"/grpc",
+	)
 }
 
 // Give chains aliases as specified by the genesis information
@@ -1675,6 +1779,9 @@ func (n *Node) shutdown() {
 
 		time.Sleep(n.Config.ShutdownWait)
 	}
+	if n.healthWebhooks != nil {
+		n.healthWebhooks.Stop()
+	}
 
 	if n.resourceManager != nil {
 		n.resourceManager.Shutdown()
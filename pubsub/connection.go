@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -40,6 +41,10 @@ type connection struct {
 
 	fp *FilterParam
 
+	subscriptionsLock sync.RWMutex
+	// topic -> filter of the events to send
+	subscriptions map[Topic]*eventFilter
+
 	active uint32
 }
 
@@ -47,6 +52,15 @@ func (c *connection) Check(addr []byte) bool {
 	return c.fp.Check(addr)
 }
 
+// isSubscribed returns true if [event] should be sent to this connection.
+func (c *connection) isSubscribed(event *Event) bool {
+	c.subscriptionsLock.RLock()
+	defer c.subscriptionsLock.RUnlock()
+
+	filter, ok := c.subscriptions[event.Topic]
+	return ok && event.Data.matches(filter)
+}
+
 func (c *connection) isActive() bool {
 	active := atomic.LoadUint32(&c.active)
 	return active != 0
@@ -174,6 +188,16 @@ func (c *connection) readMessage() error {
 		c.handleNewSet(cmd.NewSet)
 	case cmd.AddAddresses != nil:
 		err = c.handleAddAddresses(cmd.AddAddresses)
+	case cmd.Subscribe != nil:
+		// Invalid subscriptions are reported without closing the connection,
+		// so that the error can be read by the client.
+		if err := c.handleSubscribe(cmd.Subscribe); err != nil {
+			c.Send(&errorMsg{
+				Error: err.Error(),
+			})
+		}
+	case cmd.Unsubscribe != nil:
+		c.handleUnsubscribe(cmd.Unsubscribe)
 	default:
 		err = ErrInvalidCommand
 	}
@@ -212,3 +236,27 @@ func (c *connection) handleAddAddresses(cmd *AddAddresses) error {
 	c.s.subscribedConnections.Add(c)
 	return nil
 }
+
+func (c *connection) handleSubscribe(cmd *Subscribe) error {
+	filter, err := newEventFilter(cmd.Topic, cmd.Filter)
+	if err != nil {
+		return err
+	}
+
+	c.subscriptionsLock.Lock()
+	defer c.subscriptionsLock.Unlock()
+
+	c.subscriptions[cmd.Topic] = filter
+	c.s.eventConnections.Add(c)
+	return nil
+}
+
+func (c *connection) handleUnsubscribe(cmd *Unsubscribe) {
+	c.subscriptionsLock.Lock()
+	defer c.subscriptionsLock.Unlock()
+
+	delete(c.subscriptions, cmd.Topic)
+	if len(c.subscriptions) == 0 {
+		c.s.eventConnections.Remove(c)
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+const (
+	BlockAcceptedTopic          Topic = "blockAccepted"
+	BlockRejectedTopic          Topic = "blockRejected"
+	ValidatorAddedTopic         Topic = "validatorAdded"
+	ValidatorRemovedTopic       Topic = "validatorRemoved"
+	ValidatorWeightChangedTopic Topic = "validatorWeightChanged"
+	PeerConnectedTopic          Topic = "peerConnected"
+	PeerDisconnectedTopic       Topic = "peerDisconnected"
+	HealthChangedTopic          Topic = "healthChanged"
+)
+
+var (
+	ErrUnknownTopic      = errors.New("unknown topic")
+	ErrUnsupportedFilter = errors.New("unsupported filter")
+
+	// EventsSchema is the JSON schema of the commands that can be sent to
+	// subscribe to events and of the messages that are sent for them.
+	//
+	//go:embed events.schema.json
+	EventsSchema []byte
+
+	// topicFilters maps each topic to the filters that can be applied to its
+	// events.
+	topicFilters = map[Topic]supportedFilters{
+		BlockAcceptedTopic:          {chainIDs: true},
+		BlockRejectedTopic:          {chainIDs: true},
+		ValidatorAddedTopic:         {subnetIDs: true, nodeIDs: true},
+		ValidatorRemovedTopic:       {subnetIDs: true, nodeIDs: true},
+		ValidatorWeightChangedTopic: {subnetIDs: true, nodeIDs: true},
+		PeerConnectedTopic:          {nodeIDs: true},
+		PeerDisconnectedTopic:       {nodeIDs: true},
+		HealthChangedTopic:          {checks: true},
+	}
+
+	_ EventData = (*BlockEvent)(nil)
+	_ EventData = (*ValidatorAddedEvent)(nil)
+	_ EventData = (*ValidatorRemovedEvent)(nil)
+	_ EventData = (*ValidatorWeightChangedEvent)(nil)
+	_ EventData = (*PeerConnectedEvent)(nil)
+	_ EventData = (*PeerDisconnectedEvent)(nil)
+	_ EventData = (*HealthChangedEvent)(nil)
+)
+
+// Topic is a kind of event that connections can subscribe to.
+type Topic string
+
+// Event is sent to every connection that is subscribed to its topic and whose
+// filter matches its data.
+type Event struct {
+	Topic     Topic     `json:"topic"`
+	Timestamp time.Time `json:"timestamp"`
+	Data      EventData `json:"data"`
+}
+
+// NewEvent returns an event of [topic] that happened now.
+func NewEvent(topic Topic, data EventData) *Event {
+	return &Event{
+		Topic:     topic,
+		Timestamp: time.Now(),
+		Data:      data,
+	}
+}
+
+// EventData is the topic specific content of an event.
+type EventData interface {
+	matches(f *eventFilter) bool
+}
+
+// BlockEvent is the data of the [BlockAcceptedTopic] and [BlockRejectedTopic]
+// events.
+type BlockEvent struct {
+	ChainID ids.ID `json:"chainID"`
+	BlockID ids.ID `json:"blockID"`
+}
+
+func (e *BlockEvent) matches(f *eventFilter) bool {
+	return matches(f.chainIDs, e.ChainID)
+}
+
+// ValidatorAddedEvent is the data of the [ValidatorAddedTopic] events.
+type ValidatorAddedEvent struct {
+	SubnetID ids.ID      `json:"subnetID"`
+	NodeID   ids.NodeID  `json:"nodeID"`
+	TxID     ids.ID      `json:"txID"`
+	Weight   json.Uint64 `json:"weight"`
+}
+
+func (e *ValidatorAddedEvent) matches(f *eventFilter) bool {
+	return matches(f.subnetIDs, e.SubnetID) && matches(f.nodeIDs, e.NodeID)
+}
+
+// ValidatorRemovedEvent is the data of the [ValidatorRemovedTopic] events.
+type ValidatorRemovedEvent struct {
+	SubnetID ids.ID      `json:"subnetID"`
+	NodeID   ids.NodeID  `json:"nodeID"`
+	Weight   json.Uint64 `json:"weight"`
+}
+
+func (e *ValidatorRemovedEvent) matches(f *eventFilter) bool {
+	return matches(f.subnetIDs, e.SubnetID) && matches(f.nodeIDs, e.NodeID)
+}
+
+// ValidatorWeightChangedEvent is the data of the [ValidatorWeightChangedTopic]
+// events.
+type ValidatorWeightChangedEvent struct {
+	SubnetID       ids.ID      `json:"subnetID"`
+	NodeID         ids.NodeID  `json:"nodeID"`
+	PreviousWeight json.Uint64 `json:"previousWeight"`
+	Weight         json.Uint64 `json:"weight"`
+}
+
+func (e *ValidatorWeightChangedEvent) matches(f *eventFilter) bool {
+	return matches(f.subnetIDs, e.SubnetID) && matches(f.nodeIDs, e.NodeID)
+}
+
+// PeerConnectedEvent is the data of the [PeerConnectedTopic] events.
+type PeerConnectedEvent struct {
+	NodeID  ids.NodeID `json:"nodeID"`
+	Version string     `json:"version"`
+}
+
+func (e *PeerConnectedEvent) matches(f *eventFilter) bool {
+	return matches(f.nodeIDs, e.NodeID)
+}
+
+// PeerDisconnectedEvent is the data of the [PeerDisconnectedTopic] events.
+type PeerDisconnectedEvent struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+func (e *PeerDisconnectedEvent) matches(f *eventFilter) bool {
+	return matches(f.nodeIDs, e.NodeID)
+}
+
+// HealthChangedEvent is the data of the [HealthChangedTopic] events.
+type HealthChangedEvent struct {
+	// Namespace is one of "readiness", "health", or "liveness".
+	Namespace string `json:"namespace"`
+	Check     string `json:"check"`
+	Healthy   bool   `json:"healthy"`
+	Error     string `json:"error,omitempty"`
+}
+
+func (e *HealthChangedEvent) matches(f *eventFilter) bool {
+	return matches(f.checks, e.Check)
+}
+
+// EventFilter restricts the events of a topic that are sent to a connection.
+// An event must match every non-empty field of the filter. Each topic only
+// supports the fields that are relevant to its events.
+type EventFilter struct {
+	// Supported by the block topics.
+	ChainIDs []ids.ID `json:"chainIDs,omitempty"`
+	// Supported by the validator topics.
+	SubnetIDs []ids.ID `json:"subnetIDs,omitempty"`
+	// Supported by the validator and peer topics.
+	NodeIDs []ids.NodeID `json:"nodeIDs,omitempty"`
+	// Supported by the health topic.
+	Checks []string `json:"checks,omitempty"`
+}
+
+type supportedFilters struct {
+	chainIDs  bool
+	subnetIDs bool
+	nodeIDs   bool
+	checks    bool
+}
+
+type eventFilter struct {
+	chainIDs  set.Set[ids.ID]
+	subnetIDs set.Set[ids.ID]
+	nodeIDs   set.Set[ids.NodeID]
+	checks    set.Set[string]
+}
+
+// newEventFilter verifies that [f] is supported by [topic] and returns the
+// filter to apply to its events.
+func newEventFilter(topic Topic, f *EventFilter) (*eventFilter, error) {
+	supported, ok := topicFilters[topic]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTopic, topic)
+	}
+	if f == nil {
+		return &eventFilter{}, nil
+	}
+
+	switch {
+	case len(f.ChainIDs) > 0 && !supported.chainIDs:
+		return nil, fmt.Errorf("%w: %q doesn't support filtering by chainIDs", ErrUnsupportedFilter, topic)
+	case len(f.SubnetIDs) > 0 && !supported.subnetIDs:
+		return nil, fmt.Errorf("%w: %q doesn't support filtering by subnetIDs", ErrUnsupportedFilter, topic)
+	case len(f.NodeIDs) > 0 && !supported.nodeIDs:
+		return nil, fmt.Errorf("%w: %q doesn't support filtering by nodeIDs", ErrUnsupportedFilter, topic)
+	case len(f.Checks) > 0 && !supported.checks:
+		return nil, fmt.Errorf("%w: %q doesn't support filtering by checks", ErrUnsupportedFilter, topic)
+	}
+	return &eventFilter{
+		chainIDs:  set.Of(f.ChainIDs...),
+		subnetIDs: set.Of(f.SubnetIDs...),
+		nodeIDs:   set.Of(f.NodeIDs...),
+		checks:    set.Of(f.Checks...),
+	}, nil
+}
+
+// matches returns true if [elt] is in [s], or if [s] is empty.
+func matches[T comparable](s set.Set[T], elt T) bool {
+	return s.Len() == 0 || s.Contains(elt)
+}
+
+// NewSchemaHandler returns a handler that serves [EventsSchema].
+func NewSchemaHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/schema+json")
+		_, _ = w.Write(EventsSchema)
+	})
+}
@@ -0,0 +1,218 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestNewEventFilter(t *testing.T) {
+	tests := []struct {
+		name        string
+		topic       Topic
+		filter      *EventFilter
+		expectedErr error
+	}{
+		{
+			name:  "no filter",
+			topic: BlockAcceptedTopic,
+		},
+		{
+			name:  "supported filter",
+			topic: ValidatorAddedTopic,
+			filter: &EventFilter{
+				SubnetIDs: []ids.ID{ids.GenerateTestID()},
+				NodeIDs:   []ids.NodeID{ids.GenerateTestNodeID()},
+			},
+		},
+		{
+			name:        "unknown topic",
+			topic:       "unknown",
+			expectedErr: ErrUnknownTopic,
+		},
+		{
+			name:  "unsupported filter",
+			topic: BlockRejectedTopic,
+			filter: &EventFilter{
+				NodeIDs: []ids.NodeID{ids.GenerateTestNodeID()},
+			},
+			expectedErr: ErrUnsupportedFilter,
+		},
+		{
+			name:  "unsupported checks filter",
+			topic: PeerConnectedTopic,
+			filter: &EventFilter{
+				Checks: []string{"network"},
+			},
+			expectedErr: ErrUnsupportedFilter,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newEventFilter(test.topic, test.filter)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestEventDataMatches(t *testing.T) {
+	var (
+		chainID  = ids.GenerateTestID()
+		subnetID = ids.GenerateTestID()
+		nodeID   = ids.GenerateTestNodeID()
+	)
+	tests := []struct {
+		name     string
+		topic    Topic
+		filter   *EventFilter
+		data     EventData
+		expected bool
+	}{
+		{
+			name:     "empty filter",
+			topic:    BlockAcceptedTopic,
+			data:     &BlockEvent{ChainID: chainID},
+			expected: true,
+		},
+		{
+			name:     "matching chain",
+			topic:    BlockAcceptedTopic,
+			filter:   &EventFilter{ChainIDs: []ids.ID{chainID}},
+			data:     &BlockEvent{ChainID: chainID},
+			expected: true,
+		},
+		{
+			name:     "other chain",
+			topic:    BlockAcceptedTopic,
+			filter:   &EventFilter{ChainIDs: []ids.ID{chainID}},
+			data:     &BlockEvent{ChainID: ids.GenerateTestID()},
+			expected: false,
+		},
+		{
+			name:  "matching subnet and node",
+			topic: ValidatorWeightChangedTopic,
+			filter: &EventFilter{
+				SubnetIDs: []ids.ID{subnetID},
+				NodeIDs:   []ids.NodeID{nodeID},
+			},
+			data: &ValidatorWeightChangedEvent{
+				SubnetID: subnetID,
+				NodeID:   nodeID,
+			},
+			expected: true,
+		},
+		{
+			name:  "matching subnet and other node",
+			topic: ValidatorRemovedTopic,
+			filter: &EventFilter{
+				SubnetIDs: []ids.ID{subnetID},
+				NodeIDs:   []ids.NodeID{nodeID},
+			},
+			data: &ValidatorRemovedEvent{
+				SubnetID: subnetID,
+				NodeID:   ids.GenerateTestNodeID(),
+			},
+			expected: false,
+		},
+		{
+			name:     "matching peer",
+			topic:    PeerDisconnectedTopic,
+			filter:   &EventFilter{NodeIDs: []ids.NodeID{nodeID}},
+			data:     &PeerDisconnectedEvent{NodeID: nodeID},
+			expected: true,
+		},
+		{
+			name:     "other check",
+			topic:    HealthChangedTopic,
+			filter:   &EventFilter{Checks: []string{"network"}},
+			data:     &HealthChangedEvent{Check: "bootstrapped"},
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			filter, err := newEventFilter(test.topic, test.filter)
+			require.NoError(err)
+			require.Equal(test.expected, test.data.matches(filter))
+		})
+	}
+}
+
+func TestEventsSchema(t *testing.T) {
+	require.True(t, json.Valid(EventsSchema))
+}
+
+func TestPublishEvent(t *testing.T) {
+	require := require.New(t)
+
+	s := New(logging.NoLog{})
+	httpServer := httptest.NewServer(s)
+	defer httpServer.Close()
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(err)
+	defer conn.Close()
+
+	chainID := ids.GenerateTestID()
+	require.NoError(conn.WriteJSON(&Command{
+		Subscribe: &Subscribe{
+			Topic: BlockAcceptedTopic,
+			Filter: &EventFilter{
+				ChainIDs: []ids.ID{chainID},
+			},
+		},
+	}))
+	require.Eventually(func() bool {
+		return len(s.eventConnections.Conns()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Only the events that match the subscriptions are sent.
+	s.PublishEvent(NewEvent(BlockRejectedTopic, &BlockEvent{ChainID: chainID}))
+	s.PublishEvent(NewEvent(BlockAcceptedTopic, &BlockEvent{ChainID: ids.GenerateTestID()}))
+	expected := &BlockEvent{
+		ChainID: chainID,
+		BlockID: ids.GenerateTestID(),
+	}
+	s.PublishEvent(NewEvent(BlockAcceptedTopic, expected))
+
+	var event struct {
+		Topic Topic       `json:"topic"`
+		Data  *BlockEvent `json:"data"`
+	}
+	require.NoError(conn.ReadJSON(&event))
+	require.Equal(BlockAcceptedTopic, event.Topic)
+	require.Equal(expected, event.Data)
+
+	require.NoError(conn.WriteJSON(&Command{
+		Unsubscribe: &Unsubscribe{
+			Topic: BlockAcceptedTopic,
+		},
+	}))
+	require.Eventually(func() bool {
+		return len(s.eventConnections.Conns()) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Invalid subscriptions are reported.
+	require.NoError(conn.WriteJSON(&Command{
+		Subscribe: &Subscribe{
+			Topic: "unknown",
+		},
+	}))
+	var msg errorMsg
+	require.NoError(conn.ReadJSON(&msg))
+	require.Contains(msg.Error, ErrUnknownTopic.Error())
+}
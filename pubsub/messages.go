@@ -34,6 +34,20 @@ type AddAddresses struct {
 	addressIds [][]byte
 }
 
+// Subscribe command to receive the events of a topic. Subscribing to a topic
+// that is already subscribed to replaces its filter.
+type Subscribe struct {
+	Topic Topic `json:"topic"`
+	// Filter is optional. If it isn't provided, every event of the topic is
+	// sent.
+	Filter *EventFilter `json:"filter,omitempty"`
+}
+
+// Unsubscribe command to stop receiving the events of a topic
+type Unsubscribe struct {
+	Topic Topic `json:"topic"`
+}
+
 // Command execution command
 //
 // Deprecated: The pubsub server is deprecated.
@@ -41,6 +55,8 @@ type Command struct {
 	NewBloom     *NewBloom     `json:"newBloom,omitempty"`
 	NewSet       *NewSet       `json:"newSet,omitempty"`
 	AddAddresses *AddAddresses `json:"addAddresses,omitempty"`
+	Subscribe    *Subscribe    `json:"subscribe,omitempty"`
+	Unsubscribe  *Unsubscribe  `json:"unsubscribe,omitempty"`
 }
 
 func (c *Command) String() string {
@@ -51,6 +67,10 @@ func (c *Command) String() string {
 		return "newSet"
 	case c.AddAddresses != nil:
 		return "addAddresses"
+	case c.Subscribe != nil:
+		return "subscribe"
+	case c.Unsubscribe != nil:
+		return "unsubscribe"
 	default:
 		return "unknown"
 	}
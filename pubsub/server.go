@@ -65,6 +65,8 @@ type Server struct {
 	conns set.Set[*connection]
 	// subscribedConnections the connections that have activated subscriptions
 	subscribedConnections *connections
+	// eventConnections the connections that are subscribed to event topics
+	eventConnections *connections
 }
 
 // Deprecated: The pubsub server is deprecated.
@@ -72,6 +74,7 @@ func New(log logging.Logger) *Server {
 	return &Server{
 		log:                   log,
 		subscribedConnections: newConnections(),
+		eventConnections:      newConnections(),
 	}
 }
 
@@ -84,11 +87,12 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	conn := &connection{
-		s:      s,
-		conn:   wsConn,
-		send:   make(chan interface{}, maxPendingMessages),
-		fp:     NewFilterParam(),
-		active: 1,
+		s:             s,
+		conn:          wsConn,
+		send:          make(chan interface{}, maxPendingMessages),
+		fp:            NewFilterParam(),
+		subscriptions: make(map[Topic]*eventFilter),
+		active:        1,
 	}
 	s.addConnection(conn)
 }
@@ -107,6 +111,24 @@ func (s *Server) Publish(parser Filterer) {
 	}
 }
 
+// PublishEvent sends [event] to the connections that are subscribed to it.
+//
+// PublishEvent doesn't block. If a connection has too many pending messages,
+// [event] is dropped for it.
+func (s *Server) PublishEvent(event *Event) {
+	for _, conn := range s.eventConnections.Conns() {
+		conn := conn.(*connection)
+		if !conn.isSubscribed(event) {
+			continue
+		}
+		if !conn.Send(event) {
+			s.log.Verbo("dropping event to subscribed connection due to too many pending messages",
+				zap.String("topic", string(event.Topic)),
+			)
+		}
+	}
+}
+
 func (s *Server) addConnection(conn *connection) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -119,6 +141,7 @@ func (s *Server) addConnection(conn *connection) {
 
 func (s *Server) removeConnection(conn *connection) {
 	s.subscribedConnections.Remove(conn)
+	s.eventConnections.Remove(conn)
 
 	s.lock.Lock()
 	defer s.lock.Unlock()
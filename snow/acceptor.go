@@ -30,6 +30,15 @@ type Acceptor interface {
 	Accept(ctx *ConsensusContext, containerID ids.ID, container []byte) error
 }
 
+// Rejector is implemented when a struct is monitoring if a message is rejected
+type Rejector interface {
+	// Reject is called after [containerID] is rejected by consensus.
+	//
+	// Reject is called while the lock of the chain associated with [ctx] is
+	// held, so it must not block.
+	Reject(ctx *ConsensusContext, containerID ids.ID, container []byte)
+}
+
 type acceptorWrapper struct {
 	Acceptor
 
@@ -17,14 +17,22 @@ import (
 	"gonum.org/v1/gonum/mathext/prng"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman/snowmantest"
 	"github.com/ava-labs/avalanchego/snow/snowtest"
 	"github.com/ava-labs/avalanchego/utils/bag"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 type testFunc func(*testing.T, Factory)
 
+type rejectorFunc func(ids.ID)
+
+func (f rejectorFunc) Reject(_ *snow.ConsensusContext, containerID ids.ID, _ []byte) {
+	f(containerID)
+}
+
 var (
 	testFuncs = []testFunc{
 		InitializeTest,
@@ -584,6 +592,10 @@ func RecordPollRejectTransitivelyTest(t *testing.T, factory Factory) {
 
 	snowCtx := snowtest.Context(t, snowtest.CChainID)
 	ctx := snowtest.ConsensusContext(snowCtx)
+	var rejected set.Set[ids.ID]
+	ctx.BlockRejector = rejectorFunc(func(blkID ids.ID) {
+		rejected.Add(blkID)
+	})
 	params := snowball.Parameters{
 		K:                     1,
 		AlphaPreference:       1,
@@ -630,6 +642,7 @@ func RecordPollRejectTransitivelyTest(t *testing.T, factory Factory) {
 	require.Equal(snowtest.Accepted, block0.Status)
 	require.Equal(snowtest.Rejected, block1.Status)
 	require.Equal(snowtest.Rejected, block2.Status)
+	require.Equal(set.Of(block1.ID(), block2.ID()), rejected)
 }
 
 func RecordPollTransitivelyResetConfidenceTest(t *testing.T, factory Factory) {
@@ -635,7 +635,7 @@ func (ts *Topological) acceptPreferredChild(ctx context.Context, n *snowmanBlock
 		if err := child.Reject(ctx); err != nil {
 			return err
 		}
-		ts.metrics.Rejected(childID, ts.pollNumber, len(child.Bytes()))
+		ts.rejected(childID, child)
 
 		// Track which blocks have been directly rejected
 		rejects = append(rejects, childID)
@@ -669,7 +669,7 @@ func (ts *Topological) rejectTransitively(ctx context.Context, rejected []ids.ID
 			if err := child.Reject(ctx); err != nil {
 				return err
 			}
-			ts.metrics.Rejected(childID, ts.pollNumber, len(child.Bytes()))
+			ts.rejected(childID, child)
 
 			// add the newly rejected block to the end of the stack
 			rejected = append(rejected, childID)
@@ -677,3 +677,12 @@ func (ts *Topological) rejectTransitively(ctx context.Context, rejected []ids.ID
 	}
 	return nil
 }
+
+// rejected records that [child] was rejected and notifies anyone listening.
+func (ts *Topological) rejected(childID ids.ID, child Block) {
+	bytes := child.Bytes()
+	ts.metrics.Rejected(childID, ts.pollNumber, len(bytes))
+	if ts.ctx.BlockRejector != nil {
+		ts.ctx.BlockRejector.Reject(ts.ctx, childID, bytes)
+	}
+}
@@ -78,6 +78,10 @@ type ConsensusContext struct {
 	// notified that their block was accepted.
 	BlockAcceptor Acceptor
 
+	// BlockRejector, if non-nil, is the callback that will be fired whenever
+	// consensus rejects a block.
+	BlockRejector Rejector
+
 	// TxAcceptor is the callback that will be fired whenever a VM is notified
 	// that their transaction was accepted.
 	TxAcceptor Acceptor
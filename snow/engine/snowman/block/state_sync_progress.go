@@ -0,0 +1,35 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrStateSyncProgressNotImplemented = errors.New("vm does not implement StateSyncProgressReporter interface")
+
+// StateSyncProgress describes how far along an ongoing state sync is.
+type StateSyncProgress struct {
+	// Syncing is true iff the VM is currently syncing to a state summary.
+	Syncing bool `json:"syncing"`
+	// SummaryHeight is the height of the state summary being synced to.
+	SummaryHeight uint64 `json:"summaryHeight"`
+	// BytesSynced is the number of bytes of state that have been fetched.
+	BytesSynced uint64 `json:"bytesSynced"`
+	// LeavesSynced is the number of state leaves that have been fetched.
+	LeavesSynced uint64 `json:"leavesSynced"`
+	// ETA is the estimated amount of time until the sync completes. If the
+	// remaining time can't be estimated, ETA is 0.
+	ETA time.Duration `json:"eta"`
+}
+
+// StateSyncProgressReporter can optionally be implemented by a
+// StateSyncableVM to expose the progress of an ongoing state sync.
+type StateSyncProgressReporter interface {
+	// StateSyncProgress returns the progress of the ongoing state sync. If the
+	// VM isn't state syncing, Syncing is false.
+	StateSyncProgress(context.Context) (StateSyncProgress, error)
+}
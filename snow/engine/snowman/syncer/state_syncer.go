@@ -28,7 +28,10 @@ import (
 // outstanding when broadcasting.
 const maxOutstandingBroadcastRequests = 50
 
-var _ common.StateSyncer = (*stateSyncer)(nil)
+var (
+	_ common.StateSyncer              = (*stateSyncer)(nil)
+	_ block.StateSyncProgressReporter = (*stateSyncer)(nil)
+)
 
 // summary content as received from network, along with accumulated weight.
 type weightedSummary struct {
@@ -54,6 +57,7 @@ type stateSyncer struct {
 	requestID uint32
 
 	stateSyncVM        block.StateSyncableVM
+	progressVM         block.StateSyncProgressReporter
 	onDoneStateSyncing func(ctx context.Context, lastReqID uint32) error
 
 	// we track the (possibly nil) local summary to help engine
@@ -95,6 +99,7 @@ func New(
 	onDoneStateSyncing func(ctx context.Context, lastReqID uint32) error,
 ) common.StateSyncer {
 	ssVM, _ := cfg.VM.(block.StateSyncableVM)
+	progressVM, _ := cfg.VM.(block.StateSyncProgressReporter)
 	return &stateSyncer{
 		Config:                  cfg,
 		AcceptedFrontierHandler: common.NewNoOpAcceptedFrontierHandler(cfg.Ctx.Log),
@@ -105,6 +110,7 @@ func New(
 		ChitsHandler:            common.NewNoOpChitsHandler(cfg.Ctx.Log),
 		AppHandler:              cfg.VM,
 		stateSyncVM:             ssVM,
+		progressVM:              progressVM,
 		onDoneStateSyncing:      onDoneStateSyncing,
 	}
 }
@@ -613,9 +619,30 @@ func (ss *stateSyncer) HealthCheck(ctx context.Context) (interface{}, error) {
 		"consensus": struct{}{},
 		"vm":        vmIntf,
 	}
+	if progress, err := ss.stateSyncProgress(ctx); err == nil && progress.Syncing {
+		intf["stateSync"] = progress
+	}
 	return intf, vmErr
 }
 
+// StateSyncProgress returns the progress of the VM's ongoing state sync.
+//
+// If the VM doesn't report its progress,
+// [block.ErrStateSyncProgressNotImplemented] is returned.
+func (ss *stateSyncer) StateSyncProgress(ctx context.Context) (block.StateSyncProgress, error) {
+	ss.Ctx.Lock.Lock()
+	defer ss.Ctx.Lock.Unlock()
+
+	return ss.stateSyncProgress(ctx)
+}
+
+func (ss *stateSyncer) stateSyncProgress(ctx context.Context) (block.StateSyncProgress, error) {
+	if ss.progressVM == nil {
+		return block.StateSyncProgress{}, block.ErrStateSyncProgressNotImplemented
+	}
+	return ss.progressVM.StateSyncProgress(ctx)
+}
+
 func (ss *stateSyncer) IsEnabled(ctx context.Context) (bool, error) {
 	if ss.stateSyncVM == nil {
 		// state sync is not implemented
@@ -1302,3 +1302,60 @@ func TestStateSyncIsDoneOnceVMNotifies(t *testing.T) {
 	require.NoError(syncer.Notify(context.Background(), common.StateSyncDone))
 	require.True(stateSyncFullyDone)
 }
+
+type testProgressReporter func(context.Context) (block.StateSyncProgress, error)
+
+func (f testProgressReporter) StateSyncProgress(ctx context.Context) (block.StateSyncProgress, error) {
+	return f(ctx)
+}
+
+func TestStateSyncProgress(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	beacons := buildTestPeers(t, ctx.SubnetID)
+	totalWeight, err := beacons.TotalWeight(ctx.SubnetID)
+	require.NoError(err)
+
+	peers := tracker.NewPeers()
+	startup := tracker.NewStartup(peers, totalWeight)
+	syncer, fullVM, _ := buildTestsObjects(t, ctx, startup, beacons, totalWeight)
+	fullVM.HealthCheckF = func(context.Context) (interface{}, error) {
+		return nil, nil
+	}
+
+	// The VM doesn't report its progress.
+	_, err = syncer.StateSyncProgress(context.Background())
+	require.ErrorIs(err, block.ErrStateSyncProgressNotImplemented)
+
+	health, err := syncer.HealthCheck(context.Background())
+	require.NoError(err)
+	require.NotContains(health, "stateSync")
+
+	expectedProgress := block.StateSyncProgress{
+		Syncing:       true,
+		SummaryHeight: key,
+		BytesSynced:   1024,
+		LeavesSynced:  16,
+		ETA:           time.Minute,
+	}
+	syncer.progressVM = testProgressReporter(func(context.Context) (block.StateSyncProgress, error) {
+		return expectedProgress, nil
+	})
+
+	progress, err := syncer.StateSyncProgress(context.Background())
+	require.NoError(err)
+	require.Equal(expectedProgress, progress)
+
+	health, err = syncer.HealthCheck(context.Background())
+	require.NoError(err)
+	require.Equal(
+		map[string]interface{}{
+			"consensus": struct{}{},
+			"vm":        nil,
+			"stateSync": expectedProgress,
+		},
+		health,
+	)
+}
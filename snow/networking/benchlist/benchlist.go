@@ -23,6 +23,12 @@ import (
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
+var (
+	errNotBenched           = errors.New("node is not benched")
+	errNonPositiveDuration  = errors.New("bench duration must be > 0")
+	errExceedsBenchedWeight = errors.New("benched stake would exceed max")
+)
+
 // If a peer consistently does not respond to queries, it will
 // increase latencies on the network whenever that peer is polled.
 // If we cannot terminate the poll early, then the poll will wait
@@ -40,6 +46,34 @@ type Benchlist interface {
 	// IsBenched returns true if messages to [validatorID]
 	// should not be sent over the network and should immediately fail.
 	IsBenched(nodeID ids.NodeID) bool
+	// Benched returns the nodes that are currently benched
+	Benched() []BenchedNode
+	// Bench benches [nodeID] for [duration] regardless of its recent failures.
+	// If [nodeID] is already benched, it will be benched until [duration]
+	// from now.
+	Bench(nodeID ids.NodeID, duration time.Duration) error
+	// Unbench immediately removes [nodeID] from the benchlist
+	Unbench(nodeID ids.NodeID) error
+}
+
+// BenchedNode describes a node that is benched on a chain
+type BenchedNode struct {
+	NodeID  ids.NodeID
+	ChainID ids.ID
+	// Time the node was benched
+	Start time.Time
+	// Time the node will be removed from the benchlist
+	End time.Time
+	// Number of consecutive failed queries that caused the node to be benched.
+	// Zero if the node was benched manually.
+	Failures int
+}
+
+type benchStart struct {
+	// Time the node was benched
+	time time.Time
+	// Number of consecutive failed queries that caused the node to be benched
+	failures int
 }
 
 type failureStreak struct {
@@ -76,6 +110,9 @@ type benchlist struct {
 	// IDs of validators that are currently benched
 	benchlistSet set.Set[ids.NodeID]
 
+	// Validator ID --> Information about when and why the validator was benched
+	benchStarts map[ids.NodeID]benchStart
+
 	// Min heap of benched validators ordered by when they can be unbenched
 	benchedHeap heap.Map[ids.NodeID, time.Time]
 
@@ -121,6 +158,7 @@ func NewBenchlist(
 		resetTimer:             make(chan struct{}, 1),
 		failureStreaks:         make(map[ids.NodeID]failureStreak),
 		benchlistSet:           set.Set[ids.NodeID]{},
+		benchStarts:            make(map[ids.NodeID]benchStart),
 		benchable:              benchable,
 		benchedHeap:            heap.NewMap[ids.NodeID, time.Time](time.Time.Before),
 		vdrs:                   validators,
@@ -198,13 +236,29 @@ func (b *benchlist) removedExpiredNodes() {
 		}
 
 		nodeID, _, _ := b.benchedHeap.Pop()
-		b.ctx.Log.Debug("removing node from benchlist",
-			zap.Stringer("nodeID", nodeID),
-		)
-		b.benchlistSet.Remove(nodeID)
-		b.benchable.Unbenched(b.ctx.ChainID, nodeID)
+		b.unbench(nodeID, "bench expired")
 	}
 
+	b.updateMetrics()
+}
+
+// Assumes [b.lock] is held
+// Assumes [nodeID] has been removed from [b.benchedHeap]
+func (b *benchlist) unbench(nodeID ids.NodeID, reason string) {
+	start := b.benchStarts[nodeID]
+	b.ctx.Log.Info("removing node from benchlist",
+		zap.Stringer("nodeID", nodeID),
+		zap.Stringer("chainID", b.ctx.ChainID),
+		zap.String("reason", reason),
+		zap.Time("benchedAt", start.time),
+	)
+	b.benchlistSet.Remove(nodeID)
+	delete(b.benchStarts, nodeID)
+	b.benchable.Unbenched(b.ctx.ChainID, nodeID)
+}
+
+// Assumes [b.lock] is held
+func (b *benchlist) updateMetrics() {
 	b.numBenched.Set(float64(b.benchedHeap.Len()))
 	benchedStake, err := b.vdrs.SubsetWeight(b.ctx.SubnetID, b.benchlistSet)
 	if err != nil {
@@ -239,6 +293,59 @@ func (b *benchlist) IsBenched(nodeID ids.NodeID) bool {
 	return b.benchlistSet.Contains(nodeID)
 }
 
+func (b *benchlist) Benched() []BenchedNode {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	benched := make([]BenchedNode, 0, len(b.benchStarts))
+	for nodeID, start := range b.benchStarts {
+		end, _ := b.benchedHeap.Get(nodeID)
+		benched = append(benched, BenchedNode{
+			NodeID:   nodeID,
+			ChainID:  b.ctx.ChainID,
+			Start:    start.time,
+			End:      end,
+			Failures: start.failures,
+		})
+	}
+	return benched
+}
+
+func (b *benchlist) Bench(nodeID ids.NodeID, duration time.Duration) error {
+	if duration <= 0 {
+		return errNonPositiveDuration
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	benchedUntil := b.clock.Time().Add(duration)
+	if b.benchlistSet.Contains(nodeID) {
+		b.benchedHeap.Push(nodeID, benchedUntil)
+		b.ctx.Log.Info("updated benched node",
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("chainID", b.ctx.ChainID),
+			zap.String("reason", "manually benched"),
+			zap.Time("benchedUntil", benchedUntil),
+		)
+		b.resetBenchTimer()
+		return nil
+	}
+	return b.benchUntil(nodeID, benchedUntil, 0, "manually benched")
+}
+
+func (b *benchlist) Unbench(nodeID ids.NodeID) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, ok := b.benchedHeap.Remove(nodeID); !ok {
+		return errNotBenched
+	}
+	b.unbench(nodeID, "manually unbenched")
+	b.updateMetrics()
+	return nil
+}
+
 // RegisterResponse notes that we received a response from [nodeID]
 func (b *benchlist) RegisterResponse(nodeID ids.NodeID) {
 	b.streaklock.Lock()
@@ -271,13 +378,13 @@ func (b *benchlist) RegisterFailure(nodeID ids.NodeID) {
 	b.streaklock.Unlock()
 
 	if failureStreak.consecutive >= b.threshold && now.After(failureStreak.firstFailure.Add(b.minimumFailingDuration)) {
-		b.bench(nodeID)
+		b.bench(nodeID, failureStreak.consecutive)
 	}
 }
 
 // Assumes [b.lock] is held
 // Assumes [nodeID] is not already benched
-func (b *benchlist) bench(nodeID ids.NodeID) {
+func (b *benchlist) bench(nodeID ids.NodeID, failures int) {
 	validatorStake := b.vdrs.GetWeight(b.ctx.SubnetID, nodeID)
 	if validatorStake == 0 {
 		// We might want to bench a non-validator because they don't respond to
@@ -285,13 +392,33 @@ func (b *benchlist) bench(nodeID ids.NodeID) {
 		return
 	}
 
+	// Validator is benched for between [b.duration]/2 and [b.duration]
+	now := b.clock.Time()
+	minBenchDuration := b.duration / 2
+	minBenchedUntil := now.Add(minBenchDuration)
+	maxBenchedUntil := now.Add(b.duration)
+	diff := maxBenchedUntil.Sub(minBenchedUntil)
+	benchedUntil := minBenchedUntil.Add(time.Duration(rand.Float64() * float64(diff))) // #nosec G404
+
+	if err := b.benchUntil(nodeID, benchedUntil, failures, "consecutive failed queries"); err != nil {
+		b.ctx.Log.Debug("not benching node",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
+}
+
+// Assumes [b.lock] is held
+// Assumes [nodeID] is not already benched
+func (b *benchlist) benchUntil(nodeID ids.NodeID, benchedUntil time.Time, failures int, reason string) error {
+	validatorStake := b.vdrs.GetWeight(b.ctx.SubnetID, nodeID)
 	benchedStake, err := b.vdrs.SubsetWeight(b.ctx.SubnetID, b.benchlistSet)
 	if err != nil {
 		b.ctx.Log.Error("error calculating benched stake",
 			zap.Stringer("subnetID", b.ctx.SubnetID),
 			zap.Error(err),
 		)
-		return
+		return err
 	}
 
 	newBenchedStake, err := safemath.Add(benchedStake, validatorStake)
@@ -300,7 +427,7 @@ func (b *benchlist) bench(nodeID ids.NodeID) {
 		b.ctx.Log.Error("overflow calculating new benched stake",
 			zap.Stringer("nodeID", nodeID),
 		)
-		return
+		return err
 	}
 
 	totalStake, err := b.vdrs.TotalWeight(b.ctx.SubnetID)
@@ -309,37 +436,35 @@ func (b *benchlist) bench(nodeID ids.NodeID) {
 			zap.Stringer("subnetID", b.ctx.SubnetID),
 			zap.Error(err),
 		)
-		return
+		return err
 	}
 
 	maxBenchedStake := float64(totalStake) * b.maxPortion
 
 	if float64(newBenchedStake) > maxBenchedStake {
-		b.ctx.Log.Debug("not benching node",
-			zap.String("reason", "benched stake would exceed max"),
-			zap.Stringer("nodeID", nodeID),
-			zap.Float64("benchedStake", float64(newBenchedStake)),
-			zap.Float64("maxBenchedStake", maxBenchedStake),
+		return fmt.Errorf("%w: benched stake %d > max %f",
+			errExceedsBenchedWeight,
+			newBenchedStake,
+			maxBenchedStake,
 		)
-		return
 	}
 
-	// Validator is benched for between [b.duration]/2 and [b.duration]
 	now := b.clock.Time()
-	minBenchDuration := b.duration / 2
-	minBenchedUntil := now.Add(minBenchDuration)
-	maxBenchedUntil := now.Add(b.duration)
-	diff := maxBenchedUntil.Sub(minBenchedUntil)
-	benchedUntil := minBenchedUntil.Add(time.Duration(rand.Float64() * float64(diff))) // #nosec G404
-
-	b.ctx.Log.Debug("benching validator after consecutive failed queries",
+	b.ctx.Log.Info("benching node",
 		zap.Stringer("nodeID", nodeID),
+		zap.Stringer("chainID", b.ctx.ChainID),
+		zap.String("reason", reason),
 		zap.Duration("benchDuration", benchedUntil.Sub(now)),
-		zap.Int("numFailedQueries", b.threshold),
+		zap.Time("benchedUntil", benchedUntil),
+		zap.Int("numFailedQueries", failures),
 	)
 
 	// Add to benchlist times with randomized delay
 	b.benchlistSet.Add(nodeID)
+	b.benchStarts[nodeID] = benchStart{
+		time:     now,
+		failures: failures,
+	}
 	b.benchable.Benched(b.ctx.ChainID, nodeID)
 
 	b.streaklock.Lock()
@@ -349,12 +474,18 @@ func (b *benchlist) bench(nodeID ids.NodeID) {
 	b.benchedHeap.Push(nodeID, benchedUntil)
 
 	// Update the timer to account for the newly benched node.
-	select {
-	case b.resetTimer <- struct{}{}:
-	default:
-	}
+	b.resetBenchTimer()
 
 	// Update metrics
 	b.numBenched.Set(float64(b.benchedHeap.Len()))
 	b.weightBenched.Set(float64(newBenchedStake))
+	return nil
+}
+
+// Assumes [b.lock] is held
+func (b *benchlist) resetBenchTimer() {
+	select {
+	case b.resetTimer <- struct{}{}:
+	default:
+	}
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/snowtest"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 var minimumFailingDuration = 5 * time.Minute
@@ -365,3 +366,76 @@ func TestBenchlistRemove(t *testing.T) {
 
 	require.Equal(3, count)
 }
+
+// Test that validators can be manually benched and unbenched
+func TestBenchlistManual(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	vdrs := validators.NewManager()
+	vdrID0 := ids.GenerateTestNodeID()
+	vdrID1 := ids.GenerateTestNodeID()
+	vdrID2 := ids.GenerateTestNodeID()
+
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, vdrID0, nil, ids.Empty, 50))
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, vdrID1, nil, ids.Empty, 50))
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, vdrID2, nil, ids.Empty, 50))
+
+	benched := set.Set[ids.NodeID]{}
+	benchable := &TestBenchable{
+		T: t,
+		BenchedF: func(_ ids.ID, nodeID ids.NodeID) {
+			benched.Add(nodeID)
+		},
+		UnbenchedF: func(_ ids.ID, nodeID ids.NodeID) {
+			benched.Remove(nodeID)
+		},
+	}
+
+	benchIntf, err := NewBenchlist(
+		ctx,
+		benchable,
+		vdrs,
+		3,
+		minimumFailingDuration,
+		time.Minute,
+		0.5,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	b := benchIntf.(*benchlist)
+	now := time.Now()
+	b.clock.Set(now)
+
+	require.ErrorIs(b.Bench(vdrID0, 0), errNonPositiveDuration)
+	require.ErrorIs(b.Unbench(vdrID0), errNotBenched)
+
+	// Manually benching a validator shouldn't require any failures
+	require.NoError(b.Bench(vdrID0, time.Hour))
+	require.True(b.IsBenched(vdrID0))
+	require.Equal(
+		[]BenchedNode{
+			{
+				NodeID:  vdrID0,
+				ChainID: ctx.ChainID,
+				Start:   now,
+				End:     now.Add(time.Hour),
+			},
+		},
+		b.Benched(),
+	)
+
+	// Benching an already benched validator updates when it will be unbenched
+	require.NoError(b.Bench(vdrID0, 2*time.Hour))
+	require.Equal(now.Add(2*time.Hour), b.Benched()[0].End)
+
+	// Manual benching still respects the maximum benched stake
+	require.ErrorIs(b.Bench(vdrID1, time.Hour), errExceedsBenchedWeight)
+	require.False(b.IsBenched(vdrID1))
+
+	require.NoError(b.Unbench(vdrID0))
+	require.False(b.IsBenched(vdrID0))
+	require.Empty(b.Benched())
+	require.Empty(benched)
+}
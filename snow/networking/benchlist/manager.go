@@ -4,6 +4,8 @@
 package benchlist
 
 import (
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -13,7 +15,12 @@ import (
 	"github.com/ava-labs/avalanchego/snow/validators"
 )
 
-var _ Manager = (*manager)(nil)
+var (
+	_ Manager = (*manager)(nil)
+
+	errUnknownChain      = errors.New("unknown chain")
+	errBenchlistDisabled = errors.New("benchlist is disabled")
+)
 
 // Manager provides an interface for a benchlist to register whether
 // queries have been successful or unsuccessful and place validators with
@@ -36,6 +43,12 @@ type Manager interface {
 	// [nodeID] is benched. If called on an id.ShortID that does
 	// not map to a validator, it will return an empty array.
 	GetBenched(nodeID ids.NodeID) []ids.ID
+	// GetAllBenched returns every node that is benched on any chain
+	GetAllBenched() []BenchedNode
+	// Bench benches [nodeID] on chain [chainID] for [duration]
+	Bench(chainID ids.ID, nodeID ids.NodeID, duration time.Duration) error
+	// Unbench removes [nodeID] from the benchlist of chain [chainID]
+	Unbench(chainID ids.ID, nodeID ids.NodeID) error
 }
 
 // Config defines the configuration for a benchlist
@@ -102,6 +115,39 @@ func (m *manager) GetBenched(nodeID ids.NodeID) []ids.ID {
 	return benched
 }
 
+func (m *manager) GetAllBenched() []BenchedNode {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	benched := []BenchedNode{}
+	for _, benchlist := range m.chainBenchlists {
+		benched = append(benched, benchlist.Benched()...)
+	}
+	return benched
+}
+
+func (m *manager) Bench(chainID ids.ID, nodeID ids.NodeID, duration time.Duration) error {
+	m.lock.RLock()
+	benchlist, exists := m.chainBenchlists[chainID]
+	m.lock.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+	return benchlist.Bench(nodeID, duration)
+}
+
+func (m *manager) Unbench(chainID ids.ID, nodeID ids.NodeID) error {
+	m.lock.RLock()
+	benchlist, exists := m.chainBenchlists[chainID]
+	m.lock.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+	return benchlist.Unbench(nodeID)
+}
+
 func (m *manager) RegisterChain(ctx *snow.ConsensusContext) error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -180,3 +226,15 @@ func (noBenchlist) IsBenched(ids.NodeID, ids.ID) bool {
 func (noBenchlist) GetBenched(ids.NodeID) []ids.ID {
 	return []ids.ID{}
 }
+
+func (noBenchlist) GetAllBenched() []BenchedNode {
+	return []BenchedNode{}
+}
+
+func (noBenchlist) Bench(ids.ID, ids.NodeID, time.Duration) error {
+	return errBenchlistDisabled
+}
+
+func (noBenchlist) Unbench(ids.ID, ids.NodeID) error {
+	return errBenchlistDisabled
+}
@@ -135,6 +135,7 @@ func New(
 	msgFromVMChan <-chan common.Message,
 	gossipFrequency time.Duration,
 	threadPoolSize int,
+	priorityConfig PriorityConfig,
 	resourceTracker tracker.ResourceTracker,
 	subnet subnets.Subnet,
 	peerTracker commontracker.Peers,
@@ -171,6 +172,7 @@ func New(
 		h.ctx.SubnetID,
 		h.validators,
 		cpuTracker,
+		priorityConfig,
 		"sync",
 		reg,
 	)
@@ -182,6 +184,7 @@ func New(
 		h.ctx.SubnetID,
 		h.validators,
 		cpuTracker,
+		priorityConfig,
 		"async",
 		reg,
 	)
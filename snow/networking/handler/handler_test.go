@@ -72,6 +72,7 @@ func TestHandlerDropsTimedOutMessages(t *testing.T) {
 		nil,
 		time.Second,
 		testThreadPoolSize,
+		DefaultPriorityConfig,
 		resourceTracker,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -179,6 +180,7 @@ func TestHandlerClosesOnError(t *testing.T) {
 		nil,
 		time.Second,
 		testThreadPoolSize,
+		DefaultPriorityConfig,
 		resourceTracker,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -282,6 +284,7 @@ func TestHandlerDropsGossipDuringBootstrapping(t *testing.T) {
 		nil,
 		1,
 		testThreadPoolSize,
+		DefaultPriorityConfig,
 		resourceTracker,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -373,6 +376,7 @@ func TestHandlerDispatchInternal(t *testing.T) {
 		msgFromVMChan,
 		time.Second,
 		testThreadPoolSize,
+		DefaultPriorityConfig,
 		resourceTracker,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -423,6 +427,132 @@ func TestHandlerDispatchInternal(t *testing.T) {
 	wg.Wait()
 }
 
+// Tests that consensus messages are handled while the async message pool is
+// busy with slow app messages
+func TestHandlerSaturatedAsyncPoolDoesNotDelayConsensus(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	vdrs := validators.NewManager()
+	vdrID := ids.GenerateTestNodeID()
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, vdrID, nil, ids.Empty, 1))
+
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	require.NoError(err)
+
+	peerTracker, err := p2p.NewPeerTracker(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		nil,
+		version.CurrentApp,
+	)
+	require.NoError(err)
+
+	handler, err := New(
+		ctx,
+		vdrs,
+		nil,
+		time.Second,
+		1, // threadPoolSize
+		// App messages are weighted equally, so they are often next by weight.
+		PriorityConfig{
+			ConsensusWeight: 1,
+			BootstrapWeight: 1,
+			AppWeight:       1,
+		},
+		resourceTracker,
+		subnets.New(ctx.NodeID, subnets.Config{}),
+		commontracker.NewPeers(),
+		peerTracker,
+		prometheus.NewRegistry(),
+		func() {},
+	)
+	require.NoError(err)
+
+	bootstrapper := &enginetest.Bootstrapper{
+		Engine: enginetest.Engine{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+
+	var (
+		appGossipStarted = make(chan struct{}, 1)
+		releaseAppGossip = make(chan struct{})
+		chitsHandled     = make(chan struct{}, 2)
+	)
+	engine := &enginetest.Engine{T: t}
+	engine.Default(false)
+	engine.ContextF = func() *snow.ConsensusContext {
+		return ctx
+	}
+	engine.AppGossipF = func(context.Context, ids.NodeID, []byte) error {
+		select {
+		case appGossipStarted <- struct{}{}:
+		default:
+		}
+		<-releaseAppGossip
+		return nil
+	}
+	engine.ChitsF = func(context.Context, ids.NodeID, uint32, ids.ID, ids.ID, ids.ID, uint64) error {
+		chitsHandled <- struct{}{}
+		return nil
+	}
+
+	handler.SetEngineManager(&EngineManager{
+		Snowman: &Engine{
+			Bootstrapper: bootstrapper,
+			Consensus:    engine,
+		},
+	})
+	ctx.State.Set(snow.EngineState{
+		Type:  p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.NormalOp, // assumed bootstrap is done
+	})
+
+	bootstrapper.StartF = func(context.Context, uint32) error {
+		return nil
+	}
+
+	handler.Start(context.Background(), false)
+	defer func() {
+		close(releaseAppGossip)
+		handler.Stop(context.Background())
+	}()
+
+	// A burst of app gossip saturates the async message pool.
+	for i := 0; i < 4; i++ {
+		handler.Push(context.Background(), Message{
+			InboundMessage: message.InboundAppGossip(ctx.ChainID, nil, vdrID),
+			EngineType:     p2ppb.EngineType_ENGINE_TYPE_UNSPECIFIED,
+		})
+	}
+	<-appGossipStarted
+
+	// Consensus messages are still handled, even once app messages would be
+	// next by weight.
+	for i := 0; i < 2; i++ {
+		handler.Push(context.Background(), Message{
+			InboundMessage: message.InboundChits(ctx.ChainID, 0, ids.Empty, ids.Empty, ids.Empty, vdrID),
+			EngineType:     p2ppb.EngineType_ENGINE_TYPE_UNSPECIFIED,
+		})
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-chitsHandled:
+		case <-time.After(time.Second):
+			require.FailNow("chits weren't handled while the async message pool was saturated")
+		}
+	}
+}
+
 // Tests that messages are routed to the correct engine type
 func TestDynamicEngineTypeDispatch(t *testing.T) {
 	tests := []struct {
@@ -549,6 +679,7 @@ func TestDynamicEngineTypeDispatch(t *testing.T) {
 				nil,
 				time.Second,
 				testThreadPoolSize,
+				DefaultPriorityConfig,
 				resourceTracker,
 				subnets.New(ids.EmptyNodeID, subnets.Config{}),
 				commontracker.NewPeers(),
@@ -632,6 +763,7 @@ func TestHandlerStartError(t *testing.T) {
 		nil,
 		time.Second,
 		testThreadPoolSize,
+		DefaultPriorityConfig,
 		resourceTracker,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
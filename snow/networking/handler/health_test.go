@@ -88,6 +88,7 @@ func TestHealthCheckSubnet(t *testing.T) {
 				nil,
 				time.Second,
 				testThreadPoolSize,
+				DefaultPriorityConfig,
 				resourceTracker,
 				sb,
 				peerTracker,
@@ -6,6 +6,7 @@ package handler
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -52,9 +53,6 @@ type MessageQueue interface {
 	Shutdown()
 }
 
-// TODO: Use a better data structure for this.
-// We can do something better than pushing to the back of a queue. A multi-level
-// queue?
 type messageQueue struct {
 	// Useful for faking time in tests
 	clock   mockable.Clock
@@ -66,13 +64,21 @@ type messageQueue struct {
 	vdrs validators.Manager
 	// Tracks CPU utilization of each node
 	cpuTracker tracker.Tracker
+	// Determines the priority of each message
+	priorityConfig PriorityConfig
+	// Priority --> Relative share of dequeues given to the priority
+	weights [numPriorities]uint64
 
 	cond   *sync.Cond
 	closed bool
-	// Node ID --> Messages this node has in [msgs]
+	// Node ID --> Messages this node has in [msgAndCtxs]
 	nodeToUnprocessedMsgs map[ids.NodeID]int
-	// Unprocessed messages
-	msgAndCtxs buffer.Deque[*msgAndContext]
+	// Number of messages in [msgAndCtxs]
+	numMsgs int
+	// Priority --> Unprocessed messages of that priority
+	msgAndCtxs [numPriorities]buffer.Deque[*msgAndContext]
+	// Priority --> Smooth weighted round robin credit of that priority
+	credits [numPriorities]int64
 }
 
 func NewMessageQueue(
@@ -80,6 +86,7 @@ func NewMessageQueue(
 	subnetID ids.ID,
 	vdrs validators.Manager,
 	cpuTracker tracker.Tracker,
+	priorityConfig PriorityConfig,
 	metricsNamespace string,
 	reg prometheus.Registerer,
 ) (MessageQueue, error) {
@@ -88,9 +95,13 @@ func NewMessageQueue(
 		subnetID:              subnetID,
 		vdrs:                  vdrs,
 		cpuTracker:            cpuTracker,
+		priorityConfig:        priorityConfig,
+		weights:               priorityConfig.weights(),
 		cond:                  sync.NewCond(&sync.Mutex{}),
 		nodeToUnprocessedMsgs: make(map[ids.NodeID]int),
-		msgAndCtxs:            buffer.NewUnboundedDeque[*msgAndContext](1 /*=initSize*/),
+	}
+	for i := range m.msgAndCtxs {
+		m.msgAndCtxs[i] = buffer.NewUnboundedDeque[*msgAndContext](1 /*=initSize*/)
 	}
 	return m, m.metrics.initialize(metricsNamespace, reg)
 }
@@ -105,24 +116,32 @@ func (m *messageQueue) Push(ctx context.Context, msg Message) {
 	}
 
 	// Add the message to the queue
-	m.msgAndCtxs.PushRight(&msgAndContext{
-		msg: msg,
-		ctx: ctx,
+	priority := m.priorityConfig.Priority(msg.Op())
+	m.msgAndCtxs[priority].PushRight(&msgAndContext{
+		msg:    msg,
+		ctx:    ctx,
+		pushed: m.clock.Time(),
 	})
 	m.nodeToUnprocessedMsgs[msg.NodeID()]++
+	m.numMsgs++
 
 	// Update metrics
 	m.metrics.count.With(prometheus.Labels{
 		opLabel: msg.Op().String(),
 	}).Inc()
+	m.metrics.priorityCount.With(prometheus.Labels{
+		priorityLabel: priority.String(),
+	}).Inc()
 	m.metrics.nodesWithMessages.Set(float64(len(m.nodeToUnprocessedMsgs)))
 
 	// Signal a waiting thread
 	m.cond.Signal()
 }
 
-// FIFO, but skip over messages whose senders whose messages have caused us to
-// use excessive CPU recently.
+// Select a priority using weighted fair queueing across the priorities with
+// pending messages in this queue. Within the selected priority, messages are FIFO, but skip
+// over messages whose senders whose messages have caused us to use excessive
+// CPU recently.
 func (m *messageQueue) Pop() (context.Context, Message, bool) {
 	m.cond.L.Lock()
 	defer m.cond.L.Unlock()
@@ -131,14 +150,18 @@ func (m *messageQueue) Pop() (context.Context, Message, bool) {
 		if m.closed {
 			return nil, Message{}, false
 		}
-		if m.msgAndCtxs.Len() != 0 {
+		if m.numMsgs != 0 {
 			break
 		}
 		m.cond.Wait()
 	}
 
-	n := m.msgAndCtxs.Len() // note that n > 0
-	i := 0
+	var (
+		priority   = m.nextPriority()
+		msgAndCtxs = m.msgAndCtxs[priority]
+		n          = msgAndCtxs.Len() // note that n > 0
+		i          = 0
+	)
 	for {
 		if i == n {
 			m.log.Debug("canPop is false for all unprocessed messages",
@@ -147,7 +170,7 @@ func (m *messageQueue) Pop() (context.Context, Message, bool) {
 		}
 
 		var (
-			msgAndCtx, _ = m.msgAndCtxs.PopLeft()
+			msgAndCtx, _ = msgAndCtxs.PopLeft()
 			msg          = msgAndCtx.msg
 			ctx          = msgAndCtx.ctx
 			nodeID       = msg.NodeID()
@@ -159,15 +182,23 @@ func (m *messageQueue) Pop() (context.Context, Message, bool) {
 			if m.nodeToUnprocessedMsgs[nodeID] == 0 {
 				delete(m.nodeToUnprocessedMsgs, nodeID)
 			}
+			m.numMsgs--
+
+			priorityLabels := prometheus.Labels{
+				priorityLabel: priority.String(),
+			}
 			m.metrics.count.With(prometheus.Labels{
 				opLabel: msg.Op().String(),
 			}).Dec()
+			m.metrics.priorityCount.With(priorityLabels).Dec()
+			m.metrics.waitTimeCount.With(priorityLabels).Inc()
+			m.metrics.waitTimeSum.With(priorityLabels).Add(float64(m.clock.Time().Sub(msgAndCtx.pushed)))
 			m.metrics.nodesWithMessages.Set(float64(len(m.nodeToUnprocessedMsgs)))
 			return ctx, msg, true
 		}
 		// [msg.nodeID] is causing excessive CPU usage.
-		// Push [msg] to back of [m.msgs] and handle it later.
-		m.msgAndCtxs.PushRight(msgAndCtx)
+		// Push [msg] to back of [msgAndCtxs] and handle it later.
+		msgAndCtxs.PushRight(msgAndCtx)
 		i++
 		m.metrics.numExcessiveCPU.Inc()
 	}
@@ -177,7 +208,7 @@ func (m *messageQueue) Len() int {
 	m.cond.L.Lock()
 	defer m.cond.L.Unlock()
 
-	return m.msgAndCtxs.Len()
+	return m.numMsgs
 }
 
 func (m *messageQueue) Shutdown() {
@@ -185,14 +216,18 @@ func (m *messageQueue) Shutdown() {
 	defer m.cond.L.Unlock()
 
 	// Remove all the current messages from the queue
-	for m.msgAndCtxs.Len() > 0 {
-		msgAndCtx, _ := m.msgAndCtxs.PopLeft()
-		msgAndCtx.msg.OnFinishedHandling()
+	for _, msgAndCtxs := range m.msgAndCtxs {
+		for msgAndCtxs.Len() > 0 {
+			msgAndCtx, _ := msgAndCtxs.PopLeft()
+			msgAndCtx.msg.OnFinishedHandling()
+		}
 	}
 	m.nodeToUnprocessedMsgs = nil
+	m.numMsgs = 0
 
 	// Update metrics
 	m.metrics.count.Reset()
+	m.metrics.priorityCount.Reset()
 	m.metrics.nodesWithMessages.Set(0)
 
 	// Mark the queue as closed
@@ -200,7 +235,34 @@ func (m *messageQueue) Shutdown() {
 	m.cond.Broadcast()
 }
 
-// canPop will return true for at least one message in [m.msgs]
+// nextPriority returns the priority of the next message to pop using smooth
+// weighted round robin across all the priorities with pending messages.
+//
+// Assumes at least one message is pending.
+func (m *messageQueue) nextPriority() Priority {
+	var (
+		totalWeight int64
+		next        = -1
+	)
+	for i, msgAndCtxs := range m.msgAndCtxs {
+		if msgAndCtxs.Len() == 0 {
+			// Priorities without pending messages shouldn't accumulate credit.
+			m.credits[i] = 0
+			continue
+		}
+
+		weight := int64(m.weights[i])
+		m.credits[i] += weight
+		totalWeight += weight
+		if next == -1 || m.credits[i] > m.credits[next] {
+			next = i
+		}
+	}
+	m.credits[next] -= totalWeight
+	return Priority(next)
+}
+
+// canPop will return true for at least one message in [m.msgAndCtxs]
 func (m *messageQueue) canPop(msg message.InboundMessage) bool {
 	// Always pop connected and disconnected messages.
 	if op := msg.Op(); op == message.ConnectedOp || op == message.DisconnectedOp {
@@ -243,6 +305,7 @@ func (m *messageQueue) canPop(msg message.InboundMessage) bool {
 }
 
 type msgAndContext struct {
-	msg Message
-	ctx context.Context
+	msg    Message
+	ctx    context.Context
+	pushed time.Time
 }
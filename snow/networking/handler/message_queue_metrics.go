@@ -11,12 +11,21 @@ import (
 	"github.com/ava-labs/avalanchego/utils/metric"
 )
 
-const opLabel = "op"
+const (
+	opLabel       = "op"
+	priorityLabel = "priority"
+)
 
-var opLabels = []string{opLabel}
+var (
+	opLabels       = []string{opLabel}
+	priorityLabels = []string{priorityLabel}
+)
 
 type messageQueueMetrics struct {
-	count             *prometheus.GaugeVec
+	count             *prometheus.GaugeVec   // op
+	priorityCount     *prometheus.GaugeVec   // priority
+	waitTimeCount     *prometheus.CounterVec // priority
+	waitTimeSum       *prometheus.GaugeVec   // priority
 	nodesWithMessages prometheus.Gauge
 	numExcessiveCPU   prometheus.Counter
 }
@@ -34,6 +43,30 @@ func (m *messageQueueMetrics) initialize(
 		},
 		opLabels,
 	)
+	m.priorityCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "priority_count",
+			Help:      "messages in the queue of each priority",
+		},
+		priorityLabels,
+	)
+	m.waitTimeCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "wait_time_count",
+			Help:      "messages of each priority removed from the queue",
+		},
+		priorityLabels,
+	)
+	m.waitTimeSum = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "wait_time_sum",
+			Help:      "time messages of each priority spent in the queue (ns)",
+		},
+		priorityLabels,
+	)
 	m.nodesWithMessages = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
 		Name:      "nodes",
@@ -47,6 +80,9 @@ func (m *messageQueueMetrics) initialize(
 
 	return errors.Join(
 		metricsRegisterer.Register(m.count),
+		metricsRegisterer.Register(m.priorityCount),
+		metricsRegisterer.Register(m.waitTimeCount),
+		metricsRegisterer.Register(m.waitTimeSum),
 		metricsRegisterer.Register(m.nodesWithMessages),
 		metricsRegisterer.Register(m.numExcessiveCPU),
 	)
@@ -34,6 +34,7 @@ func TestQueue(t *testing.T) {
 		constants.PrimaryNetworkID,
 		vdrs,
 		cpuTracker,
+		DefaultPriorityConfig,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -168,3 +169,77 @@ func TestQueue(t *testing.T) {
 	require.Equal(msg3, gotMsg3)
 	require.Zero(u.Len())
 }
+
+func TestQueuePriorities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	cpuTracker := trackermock.NewTracker(ctrl)
+	cpuTracker.EXPECT().Usage(gomock.Any(), gomock.Any()).Return(0.0).AnyTimes()
+	vdrs := validators.NewManager()
+	vdrID := ids.GenerateTestNodeID()
+	require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, vdrID, nil, ids.Empty, 1))
+	mIntf, err := NewMessageQueue(
+		logging.NoLog{},
+		constants.PrimaryNetworkID,
+		vdrs,
+		cpuTracker,
+		PriorityConfig{
+			ConsensusWeight: 2,
+			BootstrapWeight: 1,
+			AppWeight:       1,
+			OpPriorities: map[message.Op]Priority{
+				message.PullQueryOp: BootstrapPriority,
+			},
+		},
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	u := mIntf.(*messageQueue)
+
+	var (
+		appRequest = Message{
+			InboundMessage: message.InboundAppRequest(ids.Empty, 0, time.Second, nil, vdrID),
+			EngineType:     p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+		}
+		chits = Message{
+			InboundMessage: message.InboundChits(ids.Empty, 0, ids.Empty, ids.Empty, ids.Empty, vdrID),
+			EngineType:     p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+		}
+		pullQuery = Message{
+			InboundMessage: message.InboundPullQuery(ids.Empty, 0, time.Second, ids.Empty, 0, vdrID),
+			EngineType:     p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+		}
+	)
+
+	// A flood of app messages is pushed before the consensus messages.
+	for i := 0; i < 4; i++ {
+		u.Push(context.Background(), appRequest)
+	}
+	for i := 0; i < 4; i++ {
+		u.Push(context.Background(), chits)
+	}
+	u.Push(context.Background(), pullQuery)
+	require.Equal(9, u.Len())
+
+	// Consensus messages are popped twice as often as app messages. The pull
+	// query was configured to be a bootstrap message.
+	expectedOps := []message.Op{
+		message.ChitsOp,
+		message.PullQueryOp,
+		message.AppRequestOp,
+		message.ChitsOp,
+		message.ChitsOp,
+		message.AppRequestOp,
+		message.ChitsOp,
+		message.AppRequestOp,
+		message.AppRequestOp,
+	}
+	for _, expectedOp := range expectedOps {
+		_, msg, ok := u.Pop()
+		require.True(ok)
+		require.Equal(expectedOp, msg.Op())
+	}
+	require.Zero(u.Len())
+	require.Empty(u.nodeToUnprocessedMsgs)
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package handler
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/message"
+)
+
+const (
+	// ConsensusPriority contains the messages required to make progress in
+	// consensus. These messages should be handled as soon as possible.
+	ConsensusPriority Priority = iota
+	// BootstrapPriority contains the messages used to bootstrap and state sync
+	// chains.
+	BootstrapPriority
+	// AppPriority contains the messages handled by the VM.
+	AppPriority
+
+	numPriorities = int(AppPriority) + 1
+)
+
+var (
+	DefaultPriorityConfig = PriorityConfig{
+		ConsensusWeight: 8,
+		BootstrapWeight: 4,
+		AppWeight:       1,
+	}
+
+	defaultOpPriorities = map[message.Op]Priority{
+		// State sync:
+		message.GetStateSummaryFrontierOp:       BootstrapPriority,
+		message.GetStateSummaryFrontierFailedOp: BootstrapPriority,
+		message.StateSummaryFrontierOp:          BootstrapPriority,
+		message.GetAcceptedStateSummaryOp:       BootstrapPriority,
+		message.GetAcceptedStateSummaryFailedOp: BootstrapPriority,
+		message.AcceptedStateSummaryOp:          BootstrapPriority,
+		// Bootstrapping:
+		message.GetAcceptedFrontierOp:       BootstrapPriority,
+		message.GetAcceptedFrontierFailedOp: BootstrapPriority,
+		message.AcceptedFrontierOp:          BootstrapPriority,
+		message.GetAcceptedOp:               BootstrapPriority,
+		message.GetAcceptedFailedOp:         BootstrapPriority,
+		message.AcceptedOp:                  BootstrapPriority,
+		message.GetAncestorsOp:              BootstrapPriority,
+		message.GetAncestorsFailedOp:        BootstrapPriority,
+		message.AncestorsOp:                 BootstrapPriority,
+		// Application:
+		message.AppRequestOp:  AppPriority,
+		message.AppErrorOp:    AppPriority,
+		message.AppGossipOp:   AppPriority,
+		message.AppResponseOp: AppPriority,
+	}
+
+	errUnknownPriority = errors.New("unknown priority")
+	errUnknownOp       = errors.New("unknown op")
+	errZeroWeight      = errors.New("priority weight must be > 0")
+)
+
+// Priority is the scheduling class of a message in the message queue.
+type Priority byte
+
+// ParsePriority returns the priority whose String representation is [s].
+func ParsePriority(s string) (Priority, error) {
+	for p := 0; p < numPriorities; p++ {
+		if priority := Priority(p); priority.String() == s {
+			return priority, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", errUnknownPriority, s)
+}
+
+func (p Priority) String() string {
+	switch p {
+	case ConsensusPriority:
+		return "consensus"
+	case BootstrapPriority:
+		return "bootstrap"
+	case AppPriority:
+		return "app"
+	default:
+		return "unknown"
+	}
+}
+
+// PriorityConfig configures how the message queue schedules messages of
+// different priorities.
+//
+// When messages of multiple priorities are pending, each priority is dequeued
+// in proportion to its weight. Within a priority, messages are dequeued
+// according to the recent CPU usage of their senders.
+type PriorityConfig struct {
+	ConsensusWeight uint64 `json:"consensusWeight"`
+	BootstrapWeight uint64 `json:"bootstrapWeight"`
+	AppWeight       uint64 `json:"appWeight"`
+
+	// OpPriorities overrides the default priority of the provided ops.
+	OpPriorities map[message.Op]Priority `json:"opPriorities"`
+}
+
+func (c *PriorityConfig) Verify() error {
+	switch {
+	case c.ConsensusWeight == 0:
+		return fmt.Errorf("%w: %s", errZeroWeight, ConsensusPriority)
+	case c.BootstrapWeight == 0:
+		return fmt.Errorf("%w: %s", errZeroWeight, BootstrapPriority)
+	case c.AppWeight == 0:
+		return fmt.Errorf("%w: %s", errZeroWeight, AppPriority)
+	}
+	for op, priority := range c.OpPriorities {
+		if int(priority) >= numPriorities {
+			return fmt.Errorf("%w %d for op %s", errUnknownPriority, priority, op)
+		}
+	}
+	return nil
+}
+
+// Priority returns the priority of [op].
+//
+// Ops that are not explicitly assigned a priority are treated as consensus
+// messages.
+func (c *PriorityConfig) Priority(op message.Op) Priority {
+	if priority, ok := c.OpPriorities[op]; ok {
+		return priority
+	}
+	return defaultOpPriorities[op]
+}
+
+func (c *PriorityConfig) weights() [numPriorities]uint64 {
+	return [numPriorities]uint64{
+		ConsensusPriority: c.ConsensusWeight,
+		BootstrapPriority: c.BootstrapWeight,
+		AppPriority:       c.AppWeight,
+	}
+}
+
+// ParseOpPriorities converts a mapping of op names to priority names into op
+// priorities.
+func ParseOpPriorities(opPriorities map[string]string) (map[message.Op]Priority, error) {
+	ops := make(map[string]message.Op, len(message.ConsensusOps))
+	for _, op := range message.ConsensusOps {
+		ops[op.String()] = op
+	}
+
+	parsed := make(map[message.Op]Priority, len(opPriorities))
+	for opStr, priorityStr := range opPriorities {
+		op, ok := ops[opStr]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", errUnknownOp, opStr)
+		}
+		priority, err := ParsePriority(priorityStr)
+		if err != nil {
+			return nil, err
+		}
+		parsed[op] = priority
+	}
+	return parsed, nil
+}
@@ -5,6 +5,7 @@ package sender
 
 import (
 	"context"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -78,12 +79,23 @@ func New(
 	return s, reg.Register(s.failedDueToBench)
 }
 
+// timeoutDuration returns the timeout to use for a request sent to [nodeIDs]
+// that expects a response of type [op]. Because a single message is sent to
+// all of [nodeIDs], the most generous of their timeouts is used.
+func (s *sender) timeoutDuration(op message.Op, nodeIDs set.Set[ids.NodeID]) time.Duration {
+	var maxTimeout time.Duration
+	for nodeID := range nodeIDs {
+		maxTimeout = max(maxTimeout, s.timeouts.TimeoutDurationFor(op, nodeID))
+	}
+	return maxTimeout
+}
+
 func (s *sender) SendGetStateSummaryFrontier(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32) {
 	ctx = context.WithoutCancel(ctx)
 
 	// Note that this timeout duration won't exactly match the one that gets
 	// registered. That's OK.
-	deadline := s.timeouts.TimeoutDuration()
+	deadline := s.timeoutDuration(message.StateSummaryFrontierOp, nodeIDs)
 
 	// Tell the router to expect a response message or a message notifying
 	// that we won't get a response from each of these nodes.
@@ -227,7 +239,7 @@ func (s *sender) SendGetAcceptedStateSummary(ctx context.Context, nodeIDs set.Se
 
 	// Note that this timeout duration won't exactly match the one that gets
 	// registered. That's OK.
-	deadline := s.timeouts.TimeoutDuration()
+	deadline := s.timeoutDuration(message.AcceptedStateSummaryOp, nodeIDs)
 
 	// Tell the router to expect a response message or a message notifying
 	// that we won't get a response from each of these nodes.
@@ -364,7 +376,7 @@ func (s *sender) SendGetAcceptedFrontier(ctx context.Context, nodeIDs set.Set[id
 
 	// Note that this timeout duration won't exactly match the one that gets
 	// registered. That's OK.
-	deadline := s.timeouts.TimeoutDuration()
+	deadline := s.timeoutDuration(message.AcceptedFrontierOp, nodeIDs)
 
 	// Tell the router to expect a response message or a message notifying
 	// that we won't get a response from each of these nodes.
@@ -499,7 +511,7 @@ func (s *sender) SendGetAccepted(ctx context.Context, nodeIDs set.Set[ids.NodeID
 
 	// Note that this timeout duration won't exactly match the one that gets
 	// registered. That's OK.
-	deadline := s.timeouts.TimeoutDuration()
+	deadline := s.timeoutDuration(message.AcceptedOp, nodeIDs)
 
 	// Tell the router to expect a response message or a message notifying
 	// that we won't get a response from each of these nodes.
@@ -661,14 +673,14 @@ func (s *sender) SendGetAncestors(ctx context.Context, nodeID ids.NodeID, reques
 		s.failedDueToBench.With(prometheus.Labels{
 			opLabel: message.GetAncestorsOp.String(),
 		}).Inc()
-		s.timeouts.RegisterRequestToUnreachableValidator()
+		s.timeouts.RegisterRequestToUnreachableValidator(nodeID, message.AncestorsOp)
 		go s.router.HandleInbound(ctx, inMsg)
 		return
 	}
 
 	// Note that this timeout duration won't exactly match the one that gets
 	// registered. That's OK.
-	deadline := s.timeouts.TimeoutDuration()
+	deadline := s.timeouts.TimeoutDurationFor(message.AncestorsOp, nodeID)
 	// Create the outbound message.
 	outMsg, err := s.msgCreator.GetAncestors(
 		s.ctx.ChainID,
@@ -709,7 +721,7 @@ func (s *sender) SendGetAncestors(ctx context.Context, nodeID ids.NodeID, reques
 			zap.Stringer("containerID", containerID),
 		)
 
-		s.timeouts.RegisterRequestToUnreachableValidator()
+		s.timeouts.RegisterRequestToUnreachableValidator(nodeID, message.AncestorsOp)
 		go s.router.HandleInbound(ctx, inMsg)
 	}
 }
@@ -781,14 +793,14 @@ func (s *sender) SendGet(ctx context.Context, nodeID ids.NodeID, requestID uint3
 		s.failedDueToBench.With(prometheus.Labels{
 			opLabel: message.GetOp.String(),
 		}).Inc()
-		s.timeouts.RegisterRequestToUnreachableValidator()
+		s.timeouts.RegisterRequestToUnreachableValidator(nodeID, message.PutOp)
 		go s.router.HandleInbound(ctx, inMsg)
 		return
 	}
 
 	// Note that this timeout duration won't exactly match the one that gets
 	// registered. That's OK.
-	deadline := s.timeouts.TimeoutDuration()
+	deadline := s.timeouts.TimeoutDurationFor(message.PutOp, nodeID)
 	// Create the outbound message.
 	outMsg, err := s.msgCreator.Get(
 		s.ctx.ChainID,
@@ -829,7 +841,7 @@ func (s *sender) SendGet(ctx context.Context, nodeID ids.NodeID, requestID uint3
 			zap.Stringer("containerID", containerID),
 		)
 
-		s.timeouts.RegisterRequestToUnreachableValidator()
+		s.timeouts.RegisterRequestToUnreachableValidator(nodeID, message.PutOp)
 		go s.router.HandleInbound(ctx, inMsg)
 	}
 }
@@ -911,7 +923,7 @@ func (s *sender) SendPushQuery(
 
 	// Note that this timeout duration won't exactly match the one that gets
 	// registered. That's OK.
-	deadline := s.timeouts.TimeoutDuration()
+	deadline := s.timeoutDuration(message.ChitsOp, nodeIDs)
 
 	// Sending a message to myself. No need to send it over the network. Just
 	// put it right into the router. Do so asynchronously to avoid deadlock.
@@ -937,7 +949,7 @@ func (s *sender) SendPushQuery(
 				opLabel: message.PushQueryOp.String(),
 			}).Inc()
 			nodeIDs.Remove(nodeID)
-			s.timeouts.RegisterRequestToUnreachableValidator()
+			s.timeouts.RegisterRequestToUnreachableValidator(nodeID, message.ChitsOp)
 
 			// Immediately register a failure. Do so asynchronously to avoid
 			// deadlock.
@@ -1004,7 +1016,7 @@ func (s *sender) SendPushQuery(
 			}
 
 			// Register failures for nodes we didn't send a request to.
-			s.timeouts.RegisterRequestToUnreachableValidator()
+			s.timeouts.RegisterRequestToUnreachableValidator(nodeID, message.ChitsOp)
 			inMsg := message.InternalQueryFailed(
 				nodeID,
 				s.ctx.ChainID,
@@ -1048,7 +1060,7 @@ func (s *sender) SendPullQuery(
 
 	// Note that this timeout duration won't exactly match the one that gets
 	// registered. That's OK.
-	deadline := s.timeouts.TimeoutDuration()
+	deadline := s.timeoutDuration(message.ChitsOp, nodeIDs)
 
 	// Sending a message to myself. No need to send it over the network. Just
 	// put it right into the router. Do so asynchronously to avoid deadlock.
@@ -1074,7 +1086,7 @@ func (s *sender) SendPullQuery(
 				opLabel: message.PullQueryOp.String(),
 			}).Inc()
 			nodeIDs.Remove(nodeID)
-			s.timeouts.RegisterRequestToUnreachableValidator()
+			s.timeouts.RegisterRequestToUnreachableValidator(nodeID, message.ChitsOp)
 			// Immediately register a failure. Do so asynchronously to avoid
 			// deadlock.
 			inMsg := message.InternalQueryFailed(
@@ -1130,7 +1142,7 @@ func (s *sender) SendPullQuery(
 			)
 
 			// Register failures for nodes we didn't send a request to.
-			s.timeouts.RegisterRequestToUnreachableValidator()
+			s.timeouts.RegisterRequestToUnreachableValidator(nodeID, message.ChitsOp)
 			inMsg := message.InternalQueryFailed(
 				nodeID,
 				s.ctx.ChainID,
@@ -1234,7 +1246,7 @@ func (s *sender) SendAppRequest(ctx context.Context, nodeIDs set.Set[ids.NodeID]
 
 	// Note that this timeout duration won't exactly match the one that gets
 	// registered. That's OK.
-	deadline := s.timeouts.TimeoutDuration()
+	deadline := s.timeoutDuration(message.AppResponseOp, nodeIDs)
 
 	// Sending a message to myself. No need to send it over the network. Just
 	// put it right into the router. Do so asynchronously to avoid deadlock.
@@ -1259,7 +1271,7 @@ func (s *sender) SendAppRequest(ctx context.Context, nodeIDs set.Set[ids.NodeID]
 				opLabel: message.AppRequestOp.String(),
 			}).Inc()
 			nodeIDs.Remove(nodeID)
-			s.timeouts.RegisterRequestToUnreachableValidator()
+			s.timeouts.RegisterRequestToUnreachableValidator(nodeID, message.AppResponseOp)
 
 			// Immediately register a failure. Do so asynchronously to avoid
 			// deadlock.
@@ -1324,7 +1336,7 @@ func (s *sender) SendAppRequest(ctx context.Context, nodeIDs set.Set[ids.NodeID]
 			}
 
 			// Register failures for nodes we didn't send a request to.
-			s.timeouts.RegisterRequestToUnreachableValidator()
+			s.timeouts.RegisterRequestToUnreachableValidator(nodeID, message.AppResponseOp)
 			inMsg := message.InboundAppError(
 				nodeID,
 				s.ctx.ChainID,
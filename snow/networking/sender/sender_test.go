@@ -135,6 +135,7 @@ func TestTimeout(t *testing.T) {
 		nil,
 		time.Hour,
 		testThreadPoolSize,
+		handler.DefaultPriorityConfig,
 		resourceTracker,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -394,6 +395,7 @@ func TestReliableMessages(t *testing.T) {
 		nil,
 		1,
 		testThreadPoolSize,
+		handler.DefaultPriorityConfig,
 		resourceTracker,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -551,6 +553,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 		nil,
 		time.Second,
 		testThreadPoolSize,
+		handler.DefaultPriorityConfig,
 		resourceTracker,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		commontracker.NewPeers(),
@@ -841,7 +844,7 @@ func TestSender_Bootstrap_Requests(t *testing.T) {
 			require.NoError(err)
 
 			// Set the timeout (deadline)
-			timeoutManager.EXPECT().TimeoutDuration().Return(deadline).AnyTimes()
+			timeoutManager.EXPECT().TimeoutDurationFor(gomock.Any(), gomock.Any()).Return(deadline).AnyTimes()
 
 			// Make sure we register requests with the router
 			for nodeID := range nodeIDs {
@@ -1055,7 +1058,7 @@ func TestSender_Bootstrap_Responses(t *testing.T) {
 			require.NoError(err)
 
 			// Set the timeout (deadline)
-			timeoutManager.EXPECT().TimeoutDuration().Return(deadline).AnyTimes()
+			timeoutManager.EXPECT().TimeoutDurationFor(gomock.Any(), gomock.Any()).Return(deadline).AnyTimes()
 
 			// Case: sending to ourselves
 			{
@@ -1222,7 +1225,7 @@ func TestSender_Single_Request(t *testing.T) {
 			require.NoError(err)
 
 			// Set the timeout (deadline)
-			timeoutManager.EXPECT().TimeoutDuration().Return(deadline).AnyTimes()
+			timeoutManager.EXPECT().TimeoutDurationFor(gomock.Any(), gomock.Any()).Return(deadline).AnyTimes()
 
 			// Case: sending to myself
 			{
@@ -1263,7 +1266,7 @@ func TestSender_Single_Request(t *testing.T) {
 			{
 				timeoutManager.EXPECT().IsBenched(destinationNodeID, ctx.ChainID).Return(true)
 
-				timeoutManager.EXPECT().RegisterRequestToUnreachableValidator()
+				timeoutManager.EXPECT().RegisterRequestToUnreachableValidator(destinationNodeID, tt.expectedResponseOp)
 
 				// Make sure we register requests with the router
 				expectedFailedMsg := tt.failedMsgF(destinationNodeID)
@@ -1298,7 +1301,7 @@ func TestSender_Single_Request(t *testing.T) {
 			{
 				timeoutManager.EXPECT().IsBenched(destinationNodeID, ctx.ChainID).Return(false)
 
-				timeoutManager.EXPECT().RegisterRequestToUnreachableValidator()
+				timeoutManager.EXPECT().RegisterRequestToUnreachableValidator(destinationNodeID, tt.expectedResponseOp)
 
 				// Make sure we register requests with the router
 				expectedFailedMsg := tt.failedMsgF(destinationNodeID)
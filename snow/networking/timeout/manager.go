@@ -10,13 +10,21 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 )
 
+// maxTrackedPeerLatencies is the maximum number of (peer, op class) pairs
+// whose latencies are tracked individually. When exceeded, the least recently
+// used pair is forgotten and falls back to the op class timeout.
+const maxTrackedPeerLatencies = 8192
+
 var _ Manager = (*manager)(nil)
 
 // Manages timeouts for requests sent to peers.
@@ -24,8 +32,15 @@ type Manager interface {
 	// Start the manager. Must be called before any other method.
 	// Should be called in a goroutine.
 	Dispatch()
-	// TimeoutDuration returns the current timeout duration.
+	// TimeoutDuration returns the current timeout duration across every op
+	// class and peer. Requests registered with [RegisterRequest] are instead
+	// timed out with [TimeoutDurationFor].
 	TimeoutDuration() time.Duration
+	// TimeoutDurationFor returns the current timeout duration for a request
+	// sent to [nodeID] that expects a response of type [op]. The duration is
+	// derived from the latencies previously observed for the class of [op]
+	// and, if known, for [nodeID] specifically.
+	TimeoutDurationFor(op message.Op, nodeID ids.NodeID) time.Duration
 	// IsBenched returns true if messages to [nodeID] regarding [chainID]
 	// should not be sent over the network and should immediately fail.
 	IsBenched(nodeID ids.NodeID, chainID ids.ID) bool
@@ -43,12 +58,13 @@ type Manager interface {
 		requestID ids.RequestID,
 		timeoutHandler func(),
 	)
-	// Registers that we would have sent a request to a validator but they
-	// are unreachable because they are benched or because of network conditions
-	// (e.g. we're not connected), so we didn't send the query. For the sake
-	// of calculating the average latency and network timeout, we act as
-	// though we sent the validator a request and it timed out.
-	RegisterRequestToUnreachableValidator()
+	// Registers that we would have sent a request to [nodeID] expecting a
+	// response of type [op], but they are unreachable because they are benched
+	// or because of network conditions (e.g. we're not connected), so we
+	// didn't send the query. For the sake of calculating the average latency
+	// and network timeout, we act as though we sent the validator a request
+	// and it timed out.
+	RegisterRequestToUnreachableValidator(nodeID ids.NodeID, op message.Op)
 	// Registers that [nodeID] sent us a response of type [op]
 	// for the given chain. The response corresponds to the given
 	// requestID we sent them. [latency] is the time between us
@@ -87,11 +103,27 @@ func NewManager(
 		return nil, fmt.Errorf("couldn't create timeout metrics: %w", err)
 	}
 
-	return &manager{
-		tm:           tm,
-		benchlistMgr: benchlistMgr,
-		metrics:      m,
-	}, nil
+	mgr := &manager{
+		tm:            tm,
+		benchlistMgr:  benchlistMgr,
+		metrics:       m,
+		timeoutConfig: *timeoutConfig,
+		peerTimeouts: &cache.LRU[peerOpClass, *timer.AdaptiveDuration]{
+			Size: maxTrackedPeerLatencies,
+		},
+	}
+	now := mgr.clock.Time()
+	for i := range mgr.classTimeouts {
+		mgr.classTimeouts[i] = timer.NewAdaptiveDuration(timeoutConfig, timeoutConfig.InitialTimeout, now)
+	}
+	return mgr, nil
+}
+
+// peerOpClass identifies the latencies of requests of a given class sent to a
+// given peer.
+type peerOpClass struct {
+	nodeID ids.NodeID
+	class  OpClass
 }
 
 type manager struct {
@@ -99,6 +131,19 @@ type manager struct {
 	benchlistMgr benchlist.Manager
 	metrics      *timeoutMetrics
 	stopOnce     sync.Once
+
+	// Tells the time. Can be faked for testing.
+	clock         mockable.Clock
+	timeoutConfig timer.AdaptiveTimeoutConfig
+
+	// latencyLock protects all the fields below it.
+	latencyLock sync.Mutex
+	// Requests whose response latencies should be measured.
+	measuredRequests set.Set[ids.RequestID]
+	// Adaptive timeouts for each op class across all peers.
+	classTimeouts [numOpClasses]*timer.AdaptiveDuration
+	// Adaptive timeouts for each op class of recently used peers.
+	peerTimeouts *cache.LRU[peerOpClass, *timer.AdaptiveDuration]
 }
 
 func (m *manager) Dispatch() {
@@ -109,6 +154,46 @@ func (m *manager) TimeoutDuration() time.Duration {
 	return m.tm.TimeoutDuration()
 }
 
+func (m *manager) TimeoutDurationFor(op message.Op, nodeID ids.NodeID) time.Duration {
+	m.latencyLock.Lock()
+	defer m.latencyLock.Unlock()
+
+	return m.timeoutDurationFor(OpClassOf(op), nodeID)
+}
+
+// Assumes [m.latencyLock] is held.
+func (m *manager) timeoutDurationFor(class OpClass, nodeID ids.NodeID) time.Duration {
+	if peerTimeout, ok := m.peerTimeouts.Get(peerOpClass{nodeID: nodeID, class: class}); ok {
+		return peerTimeout.Timeout()
+	}
+	return m.classTimeouts[class].Timeout()
+}
+
+// observeLatency records that a request to [nodeID] expecting a response of
+// type [op] took [latency].
+//
+// Assumes [m.latencyLock] is held.
+func (m *manager) observeLatency(op message.Op, nodeID ids.NodeID, latency time.Duration) {
+	var (
+		now          = m.clock.Time()
+		class        = OpClassOf(op)
+		classTimeout = m.classTimeouts[class]
+		key          = peerOpClass{nodeID: nodeID, class: class}
+	)
+	if peerTimeout, ok := m.peerTimeouts.Get(key); ok {
+		peerTimeout.Observe(latency, now)
+	} else {
+		// The first latency observed from a peer initializes its average.
+		peerTimeout = timer.NewAdaptiveDuration(&m.timeoutConfig, latency, now)
+		peerTimeout.Observe(latency, now)
+		m.peerTimeouts.Put(key, peerTimeout)
+	}
+	classTimeout.Observe(latency, now)
+
+	m.metrics.ObserveClass(class, classTimeout.Timeout(), classTimeout.AverageLatency())
+	m.metrics.SetTrackedPeers(m.peerTimeouts.Len())
+}
+
 // IsBenched returns true if messages to [nodeID] regarding [chainID]
 // should not be sent over the network and should immediately fail.
 func (m *manager) IsBenched(nodeID ids.NodeID, chainID ids.ID) bool {
@@ -135,15 +220,31 @@ func (m *manager) RegisterRequest(
 	requestID ids.RequestID,
 	timeoutHandler func(),
 ) {
+	op := message.Op(requestID.Op)
+
+	m.latencyLock.Lock()
+	timeout := m.timeoutDurationFor(OpClassOf(op), nodeID)
+	if measureLatency {
+		m.measuredRequests.Add(requestID)
+	}
+	m.latencyLock.Unlock()
+
 	newTimeoutHandler := func() {
-		if requestID.Op != byte(message.AppResponseOp) {
+		m.latencyLock.Lock()
+		if m.measuredRequests.Contains(requestID) {
+			m.measuredRequests.Remove(requestID)
+			m.observeLatency(op, nodeID, timeout)
+		}
+		m.latencyLock.Unlock()
+
+		if op != message.AppResponseOp {
 			// If the request timed out and wasn't an AppRequest, tell the
 			// benchlist manager.
 			m.benchlistMgr.RegisterFailure(chainID, nodeID)
 		}
 		timeoutHandler()
 	}
-	m.tm.Put(requestID, measureLatency, newTimeoutHandler)
+	m.tm.PutWithTimeout(requestID, measureLatency, timeout, newTimeoutHandler)
 }
 
 // RegisterResponse registers that we received a response from [nodeID]
@@ -157,15 +258,32 @@ func (m *manager) RegisterResponse(
 ) {
 	m.metrics.Observe(chainID, op, latency)
 	m.benchlistMgr.RegisterResponse(chainID, nodeID)
+
+	m.latencyLock.Lock()
+	if m.measuredRequests.Contains(requestID) {
+		m.measuredRequests.Remove(requestID)
+		m.observeLatency(op, nodeID, latency)
+	}
+	m.latencyLock.Unlock()
+
 	m.tm.Remove(requestID)
 }
 
 func (m *manager) RemoveRequest(requestID ids.RequestID) {
+	m.latencyLock.Lock()
+	m.measuredRequests.Remove(requestID)
+	m.latencyLock.Unlock()
+
 	m.tm.Remove(requestID)
 }
 
-func (m *manager) RegisterRequestToUnreachableValidator() {
+func (m *manager) RegisterRequestToUnreachableValidator(nodeID ids.NodeID, op message.Op) {
 	m.tm.ObserveLatency(m.TimeoutDuration())
+
+	m.latencyLock.Lock()
+	defer m.latencyLock.Unlock()
+
+	m.observeLatency(op, nodeID, m.timeoutDurationFor(OpClassOf(op), nodeID))
 }
 
 func (m *manager) Stop() {
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/utils/timer"
 )
@@ -47,3 +48,118 @@ func TestManagerFire(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestManagerTimeoutDurationFor(t *testing.T) {
+	require := require.New(t)
+
+	benchlist := benchlist.NewNoBenchlist()
+	timeoutManager, err := NewManager(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     time.Second,
+			MinimumTimeout:     time.Millisecond,
+			MaximumTimeout:     10 * time.Second,
+			TimeoutCoefficient: 2,
+			TimeoutHalflife:    time.Nanosecond,
+		},
+		benchlist,
+		prometheus.NewRegistry(),
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	go timeoutManager.Dispatch()
+	defer timeoutManager.Stop()
+
+	// Advance the clock before every observation so that, given the tiny
+	// halflife, the average latency is always the last observed latency.
+	m := timeoutManager.(*manager)
+	now := time.Now()
+
+	var (
+		fastNodeID = ids.GenerateTestNodeID()
+		slowNodeID = ids.GenerateTestNodeID()
+		newNodeID  = ids.GenerateTestNodeID()
+	)
+	register := func(nodeID ids.NodeID, op message.Op, latency time.Duration) {
+		requestID := ids.RequestID{
+			NodeID: nodeID,
+			Op:     byte(op),
+		}
+		now = now.Add(time.Second)
+		m.clock.Set(now)
+		timeoutManager.RegisterRequest(nodeID, ids.Empty, true, requestID, func() {})
+		timeoutManager.RegisterResponse(nodeID, ids.Empty, requestID, op, latency)
+	}
+
+	// A slow GetAncestors should not impact the timeout of queries.
+	register(slowNodeID, message.AncestorsOp, 4*time.Second)
+	register(fastNodeID, message.ChitsOp, 10*time.Millisecond)
+
+	require.Equal(20*time.Millisecond, timeoutManager.TimeoutDurationFor(message.ChitsOp, fastNodeID))
+	require.Equal(20*time.Millisecond, timeoutManager.TimeoutDurationFor(message.PullQueryOp, newNodeID))
+	require.Equal(8*time.Second, timeoutManager.TimeoutDurationFor(message.AncestorsOp, slowNodeID))
+	require.Equal(8*time.Second, timeoutManager.TimeoutDurationFor(message.GetAncestorsOp, newNodeID))
+
+	// Peer specific latencies should only impact that peer.
+	register(slowNodeID, message.ChitsOp, 3*time.Second)
+
+	require.Equal(6*time.Second, timeoutManager.TimeoutDurationFor(message.ChitsOp, slowNodeID))
+	require.Equal(6*time.Second, timeoutManager.TimeoutDurationFor(message.ChitsOp, newNodeID))
+	require.Equal(20*time.Millisecond, timeoutManager.TimeoutDurationFor(message.ChitsOp, fastNodeID))
+
+	// Responses that aren't measured shouldn't impact the timeout.
+	requestID := ids.RequestID{
+		NodeID: fastNodeID,
+		Op:     byte(message.ChitsOp),
+	}
+	timeoutManager.RegisterRequest(fastNodeID, ids.Empty, false, requestID, func() {})
+	timeoutManager.RegisterResponse(fastNodeID, ids.Empty, requestID, message.ChitsOp, time.Second)
+	require.Equal(20*time.Millisecond, timeoutManager.TimeoutDurationFor(message.ChitsOp, fastNodeID))
+
+	// Unknown ops default to the consensus class.
+	require.Equal(ConsensusOpClass, OpClassOf(message.PingOp))
+}
+
+func TestManagerRegisterRequestUsesTimeoutDurationFor(t *testing.T) {
+	require := require.New(t)
+
+	benchlist := benchlist.NewNoBenchlist()
+	timeoutManager, err := NewManager(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     time.Minute,
+			MinimumTimeout:     time.Millisecond,
+			MaximumTimeout:     time.Minute,
+			TimeoutCoefficient: 2,
+			TimeoutHalflife:    time.Nanosecond,
+		},
+		benchlist,
+		prometheus.NewRegistry(),
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	go timeoutManager.Dispatch()
+	defer timeoutManager.Stop()
+
+	m := timeoutManager.(*manager)
+	m.clock.Set(time.Now().Add(time.Second))
+
+	nodeID := ids.GenerateTestNodeID()
+	requestID := ids.RequestID{
+		NodeID: nodeID,
+		Op:     byte(message.ChitsOp),
+	}
+	timeoutManager.RegisterRequest(nodeID, ids.Empty, true, requestID, func() {})
+	timeoutManager.RegisterResponse(nodeID, ids.Empty, requestID, message.ChitsOp, 10*time.Millisecond)
+
+	// The engines' requests are timed out by the router with the duration of
+	// their op class and peer, rather than with the initial timeout.
+	fired := make(chan struct{})
+	requestID.RequestID++
+	timeoutManager.RegisterRequest(nodeID, ids.Empty, false, requestID, func() {
+		close(fired)
+	})
+	select {
+	case <-fired:
+	case <-time.After(30 * time.Second):
+		require.FailNow("request wasn't timed out with its peer's timeout")
+	}
+}
@@ -18,14 +18,22 @@ import (
 const (
 	chainLabel = "chain"
 	opLabel    = "op"
+	classLabel = "class"
 )
 
-var opLabels = []string{chainLabel, opLabel}
+var (
+	opLabels    = []string{chainLabel, opLabel}
+	classLabels = []string{classLabel}
+)
 
 type timeoutMetrics struct {
 	messages         *prometheus.CounterVec // chain + op
 	messageLatencies *prometheus.GaugeVec   // chain + op
 
+	classTimeouts       *prometheus.GaugeVec // class
+	classAvgLatencies   *prometheus.GaugeVec // class
+	trackedPeerTimeouts prometheus.Gauge
+
 	lock           sync.RWMutex
 	chainIDToAlias map[ids.ID]string
 }
@@ -46,11 +54,32 @@ func newTimeoutMetrics(reg prometheus.Registerer) (*timeoutMetrics, error) {
 			},
 			opLabels,
 		),
+		classTimeouts: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "class_timeout",
+				Help: "current network timeout of each op class (ns)",
+			},
+			classLabels,
+		),
+		classAvgLatencies: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "class_average_latency",
+				Help: "average network latency of each op class (ns)",
+			},
+			classLabels,
+		),
+		trackedPeerTimeouts: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "tracked_peer_timeouts",
+			Help: "number of (peer, op class) pairs whose timeouts are tracked individually",
+		}),
 		chainIDToAlias: make(map[ids.ID]string),
 	}
 	return m, errors.Join(
 		reg.Register(m.messages),
 		reg.Register(m.messageLatencies),
+		reg.Register(m.classTimeouts),
+		reg.Register(m.classAvgLatencies),
+		reg.Register(m.trackedPeerTimeouts),
 	)
 }
 
@@ -74,3 +103,17 @@ func (m *timeoutMetrics) Observe(chainID ids.ID, op message.Op, latency time.Dur
 	m.messages.With(labels).Inc()
 	m.messageLatencies.With(labels).Add(float64(latency))
 }
+
+// Record the current [timeout] and [avgLatency] of [class]
+func (m *timeoutMetrics) ObserveClass(class OpClass, timeout time.Duration, avgLatency float64) {
+	labels := prometheus.Labels{
+		classLabel: class.String(),
+	}
+	m.classTimeouts.With(labels).Set(float64(timeout))
+	m.classAvgLatencies.With(labels).Set(avgLatency)
+}
+
+// Record the number of (peer, op class) pairs with individual timeouts
+func (m *timeoutMetrics) SetTrackedPeers(numTracked int) {
+	m.trackedPeerTimeouts.Set(float64(numTracked))
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package timeout
+
+import "github.com/ava-labs/avalanchego/message"
+
+const (
+	// ConsensusOpClass contains the requests issued while running consensus.
+	// These are expected to be answered quickly.
+	ConsensusOpClass OpClass = iota
+	// BootstrapOpClass contains the requests issued while bootstrapping or
+	// state syncing. Responses may be large and take longer to produce.
+	BootstrapOpClass
+	// AppOpClass contains the requests issued by the VM.
+	AppOpClass
+
+	numOpClasses = int(AppOpClass) + 1
+)
+
+var opClasses = map[message.Op]OpClass{
+	// State sync:
+	message.GetStateSummaryFrontierOp: BootstrapOpClass,
+	message.StateSummaryFrontierOp:    BootstrapOpClass,
+	message.GetAcceptedStateSummaryOp: BootstrapOpClass,
+	message.AcceptedStateSummaryOp:    BootstrapOpClass,
+	// Bootstrapping:
+	message.GetAcceptedFrontierOp: BootstrapOpClass,
+	message.AcceptedFrontierOp:    BootstrapOpClass,
+	message.GetAcceptedOp:         BootstrapOpClass,
+	message.AcceptedOp:            BootstrapOpClass,
+	message.GetAncestorsOp:        BootstrapOpClass,
+	message.AncestorsOp:           BootstrapOpClass,
+	// Consensus:
+	message.GetOp:       ConsensusOpClass,
+	message.PutOp:       ConsensusOpClass,
+	message.PushQueryOp: ConsensusOpClass,
+	message.PullQueryOp: ConsensusOpClass,
+	message.ChitsOp:     ConsensusOpClass,
+	// Application:
+	message.AppRequestOp:  AppOpClass,
+	message.AppResponseOp: AppOpClass,
+	message.AppErrorOp:    AppOpClass,
+}
+
+// OpClass groups together request types whose response latencies are tracked
+// together.
+type OpClass byte
+
+// OpClassOf returns the class of the provided request or response [op].
+// Unknown ops are treated as consensus messages.
+func OpClassOf(op message.Op) OpClass {
+	return opClasses[op]
+}
+
+func (c OpClass) String() string {
+	switch c {
+	case ConsensusOpClass:
+		return "consensus"
+	case BootstrapOpClass:
+		return "bootstrap"
+	case AppOpClass:
+		return "app"
+	default:
+		return "unknown"
+	}
+}
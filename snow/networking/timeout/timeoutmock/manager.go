@@ -95,15 +95,15 @@ func (mr *ManagerMockRecorder) RegisterRequest(arg0, arg1, arg2, arg3, arg4 any)
 }
 
 // RegisterRequestToUnreachableValidator mocks base method.
-func (m *Manager) RegisterRequestToUnreachableValidator() {
+func (m *Manager) RegisterRequestToUnreachableValidator(arg0 ids.NodeID, arg1 message.Op) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "RegisterRequestToUnreachableValidator")
+	m.ctrl.Call(m, "RegisterRequestToUnreachableValidator", arg0, arg1)
 }
 
 // RegisterRequestToUnreachableValidator indicates an expected call of RegisterRequestToUnreachableValidator.
-func (mr *ManagerMockRecorder) RegisterRequestToUnreachableValidator() *gomock.Call {
+func (mr *ManagerMockRecorder) RegisterRequestToUnreachableValidator(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRequestToUnreachableValidator", reflect.TypeOf((*Manager)(nil).RegisterRequestToUnreachableValidator))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRequestToUnreachableValidator", reflect.TypeOf((*Manager)(nil).RegisterRequestToUnreachableValidator), arg0, arg1)
 }
 
 // RegisterResponse mocks base method.
@@ -155,3 +155,17 @@ func (mr *ManagerMockRecorder) TimeoutDuration() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TimeoutDuration", reflect.TypeOf((*Manager)(nil).TimeoutDuration))
 }
+
+// TimeoutDurationFor mocks base method.
+func (m *Manager) TimeoutDurationFor(arg0 message.Op, arg1 ids.NodeID) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TimeoutDurationFor", arg0, arg1)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// TimeoutDurationFor indicates an expected call of TimeoutDurationFor.
+func (mr *ManagerMockRecorder) TimeoutDurationFor(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TimeoutDurationFor", reflect.TypeOf((*Manager)(nil).TimeoutDurationFor), arg0, arg1)
+}
@@ -49,6 +49,11 @@ type Config struct {
 	// TODO: Move this flag once the proposervm is configurable on a per-chain
 	// basis.
 	ProposerNumHistoricalBlocks uint64 `json:"proposerNumHistoricalBlocks" yaml:"proposerNumHistoricalBlocks"`
+	// ProposerMetricsMaxValidators is the maximum number of validators whose
+	// proposer window counters this node reports as metrics labelled by their
+	// node IDs. The validators that missed the most slots are reported. If set
+	// to 0, per-validator metrics are disabled.
+	ProposerMetricsMaxValidators uint `json:"proposerMetricsMaxValidators" yaml:"proposerMetricsMaxValidators"`
 }
 
 func (c *Config) Valid() error {
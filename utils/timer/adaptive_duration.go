@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package timer
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/math"
+)
+
+// AdaptiveDuration tracks a continuous time exponential moving average of
+// observed latencies and derives a timeout from it. The timeout is
+// [timeoutCoefficient] * average latency, clamped to
+// [minimumTimeout, maximumTimeout].
+//
+// AdaptiveDuration is not safe for concurrent use.
+type AdaptiveDuration struct {
+	averager           math.Averager
+	timeoutCoefficient float64
+	minimumTimeout     time.Duration
+	maximumTimeout     time.Duration
+	currentTimeout     time.Duration
+}
+
+// NewAdaptiveDuration returns a new AdaptiveDuration whose average latency and
+// timeout are both initialized to [initialLatency].
+//
+// [config] is assumed to have already been verified.
+func NewAdaptiveDuration(
+	config *AdaptiveTimeoutConfig,
+	initialLatency time.Duration,
+	now time.Time,
+) *AdaptiveDuration {
+	d := &AdaptiveDuration{
+		averager:           math.NewAverager(float64(initialLatency), config.TimeoutHalflife, now),
+		timeoutCoefficient: config.TimeoutCoefficient,
+		minimumTimeout:     config.MinimumTimeout,
+		maximumTimeout:     config.MaximumTimeout,
+	}
+	d.currentTimeout = d.clamp(initialLatency)
+	return d
+}
+
+// Observe registers a response latency and returns the updated timeout.
+func (d *AdaptiveDuration) Observe(latency time.Duration, now time.Time) time.Duration {
+	d.averager.Observe(float64(latency), now)
+	d.currentTimeout = d.clamp(time.Duration(d.timeoutCoefficient * d.averager.Read()))
+	return d.currentTimeout
+}
+
+// Timeout returns the current timeout.
+func (d *AdaptiveDuration) Timeout() time.Duration {
+	return d.currentTimeout
+}
+
+// AverageLatency returns the current average latency in nanoseconds.
+func (d *AdaptiveDuration) AverageLatency() float64 {
+	return d.averager.Read()
+}
+
+func (d *AdaptiveDuration) clamp(timeout time.Duration) time.Duration {
+	switch {
+	case timeout > d.maximumTimeout:
+		return d.maximumTimeout
+	case timeout < d.minimumTimeout:
+		return d.minimumTimeout
+	default:
+		return timeout
+	}
+}
@@ -13,7 +13,6 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/heap"
-	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 )
 
@@ -61,6 +60,9 @@ type AdaptiveTimeoutManager interface {
 	// Registers a timeout for the item with the given [id].
 	// If the timeout occurs before the item is Removed, [timeoutHandler] is called.
 	Put(id ids.RequestID, measureLatency bool, timeoutHandler func())
+	// PutWithTimeout is the same as Put, except the timeout fires after
+	// [timeout] rather than after the current network timeout.
+	PutWithTimeout(id ids.RequestID, measureLatency bool, timeout time.Duration, timeoutHandler func())
 	// Remove the timeout associated with [id].
 	// Its timeout handler will not be called.
 	Remove(id ids.RequestID)
@@ -77,16 +79,11 @@ type adaptiveTimeoutManager struct {
 	networkTimeoutMetric, avgLatency prometheus.Gauge
 	numTimeouts                      prometheus.Counter
 	numPendingTimeouts               prometheus.Gauge
-	// Averages the response time from all peers
-	averager math.Averager
-	// Timeout is [timeoutCoefficient] * average response time
-	// [timeoutCoefficient] must be > 1
-	timeoutCoefficient float64
-	minimumTimeout     time.Duration
-	maximumTimeout     time.Duration
-	currentTimeout     time.Duration // Amount of time before a timeout
-	timeoutHeap        heap.Map[ids.RequestID, *adaptiveTimeout]
-	timer              *Timer // Timer that will fire to clear the timeouts
+	// Averages the response time from all peers and derives the current
+	// network timeout from it
+	currentTimeout *AdaptiveDuration
+	timeoutHeap    heap.Map[ids.RequestID, *adaptiveTimeout]
+	timer          *Timer // Timer that will fire to clear the timeouts
 }
 
 func NewAdaptiveTimeoutManager(
@@ -121,16 +118,12 @@ func NewAdaptiveTimeoutManager(
 			Name: "pending_timeouts",
 			Help: "Number of pending timeouts",
 		}),
-		minimumTimeout:     config.MinimumTimeout,
-		maximumTimeout:     config.MaximumTimeout,
-		currentTimeout:     config.InitialTimeout,
-		timeoutCoefficient: config.TimeoutCoefficient,
 		timeoutHeap: heap.NewMap[ids.RequestID, *adaptiveTimeout](func(a, b *adaptiveTimeout) bool {
 			return a.deadline.Before(b.deadline)
 		}),
 	}
 	tm.timer = NewTimer(tm.timeout)
-	tm.averager = math.NewAverager(float64(config.InitialTimeout), config.TimeoutHalflife, tm.clock.Time())
+	tm.currentTimeout = NewAdaptiveDuration(config, config.InitialTimeout, tm.clock.Time())
 
 	err := errors.Join(
 		reg.Register(tm.networkTimeoutMetric),
@@ -145,7 +138,7 @@ func (tm *adaptiveTimeoutManager) TimeoutDuration() time.Duration {
 	tm.lock.Lock()
 	defer tm.lock.Unlock()
 
-	return tm.currentTimeout
+	return tm.currentTimeout.Timeout()
 }
 
 func (tm *adaptiveTimeoutManager) Dispatch() {
@@ -160,19 +153,26 @@ func (tm *adaptiveTimeoutManager) Put(id ids.RequestID, measureLatency bool, tim
 	tm.lock.Lock()
 	defer tm.lock.Unlock()
 
-	tm.put(id, measureLatency, timeoutHandler)
+	tm.put(id, measureLatency, tm.currentTimeout.Timeout(), timeoutHandler)
+}
+
+func (tm *adaptiveTimeoutManager) PutWithTimeout(id ids.RequestID, measureLatency bool, timeout time.Duration, timeoutHandler func()) {
+	tm.lock.Lock()
+	defer tm.lock.Unlock()
+
+	tm.put(id, measureLatency, timeout, timeoutHandler)
 }
 
 // Assumes [tm.lock] is held
-func (tm *adaptiveTimeoutManager) put(id ids.RequestID, measureLatency bool, handler func()) {
+func (tm *adaptiveTimeoutManager) put(id ids.RequestID, measureLatency bool, duration time.Duration, handler func()) {
 	now := tm.clock.Time()
 	tm.remove(id, now)
 
 	timeout := &adaptiveTimeout{
 		id:             id,
 		handler:        handler,
-		duration:       tm.currentTimeout,
-		deadline:       now.Add(tm.currentTimeout),
+		duration:       duration,
+		deadline:       now.Add(duration),
 		measureLatency: measureLatency,
 	}
 	tm.timeoutHeap.Push(id, timeout)
@@ -235,17 +235,10 @@ func (tm *adaptiveTimeoutManager) ObserveLatency(latency time.Duration) {
 
 // Assumes [tm.lock] is held
 func (tm *adaptiveTimeoutManager) observeLatencyAndUpdateTimeout(latency time.Duration, now time.Time) {
-	tm.averager.Observe(float64(latency), now)
-	avgLatency := tm.averager.Read()
-	tm.currentTimeout = time.Duration(tm.timeoutCoefficient * avgLatency)
-	if tm.currentTimeout > tm.maximumTimeout {
-		tm.currentTimeout = tm.maximumTimeout
-	} else if tm.currentTimeout < tm.minimumTimeout {
-		tm.currentTimeout = tm.minimumTimeout
-	}
+	currentTimeout := tm.currentTimeout.Observe(latency, now)
 	// Update the metrics
-	tm.networkTimeoutMetric.Set(float64(tm.currentTimeout))
-	tm.avgLatency.Set(avgLatency)
+	tm.networkTimeoutMetric.Set(float64(currentTimeout))
+	tm.avgLatency.Set(tm.currentTimeout.AverageLatency())
 }
 
 // Returns the handler function associated with the next timeout.
@@ -42,6 +42,19 @@ type Client interface {
 	GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (choices.Status, error)
 	// GetTx returns the byte representation of [txID]
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
+	// GetAddressTxs returns the IDs of the accepted txs that touched [addr]
+	// with [assetID], starting from the [cursor]th such tx, and the cursor of
+	// the next page. If [assetID] is empty, AVAX txs are returned.
+	//
+	// If the node doesn't index address txs, no txs are returned.
+	GetAddressTxs(
+		ctx context.Context,
+		addr ids.ShortID,
+		assetID string,
+		cursor uint64,
+		pageSize uint64,
+		options ...rpc.Option,
+	) ([]ids.ID, uint64, error)
 	// GetUTXOs returns the byte representation of the UTXOs controlled by [addrs]
 	GetUTXOs(
 		ctx context.Context,
@@ -296,6 +309,24 @@ func (c *client) GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option)
 	return formatting.Decode(res.Encoding, res.Tx)
 }
 
+func (c *client) GetAddressTxs(
+	ctx context.Context,
+	addr ids.ShortID,
+	assetID string,
+	cursor uint64,
+	pageSize uint64,
+	options ...rpc.Option,
+) ([]ids.ID, uint64, error) {
+	res := &GetAddressTxsReply{}
+	err := c.requester.SendRequest(ctx, "avm.getAddressTxs", &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: addr.String()},
+		Cursor:      json.Uint64(cursor),
+		PageSize:    json.Uint64(pageSize),
+		AssetID:     assetID,
+	}, res, options...)
+	return res.TxIDs, uint64(res.Cursor), err
+}
+
 func (c *client) GetUTXOs(
 	ctx context.Context,
 	addrs []ids.ShortID,
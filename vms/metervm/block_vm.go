@@ -22,6 +22,7 @@ var (
 	_ block.BuildBlockWithContextChainVM = (*blockVM)(nil)
 	_ block.BatchedChainVM               = (*blockVM)(nil)
 	_ block.StateSyncableVM              = (*blockVM)(nil)
+	_ block.StateSyncProgressReporter    = (*blockVM)(nil)
 )
 
 type blockVM struct {
@@ -29,6 +30,7 @@ type blockVM struct {
 	buildBlockVM block.BuildBlockWithContextChainVM
 	batchedVM    block.BatchedChainVM
 	ssVM         block.StateSyncableVM
+	progressVM   block.StateSyncProgressReporter
 
 	blockMetrics
 	registry prometheus.Registerer
@@ -42,11 +44,13 @@ func NewBlockVM(
 	buildBlockVM, _ := vm.(block.BuildBlockWithContextChainVM)
 	batchedVM, _ := vm.(block.BatchedChainVM)
 	ssVM, _ := vm.(block.StateSyncableVM)
+	progressVM, _ := vm.(block.StateSyncProgressReporter)
 	return &blockVM{
 		ChainVM:      vm,
 		buildBlockVM: buildBlockVM,
 		batchedVM:    batchedVM,
 		ssVM:         ssVM,
+		progressVM:   progressVM,
 		registry:     reg,
 	}
 }
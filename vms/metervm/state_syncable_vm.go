@@ -78,3 +78,10 @@ func (vm *blockVM) GetStateSummary(ctx context.Context, height uint64) (block.St
 	vm.blockMetrics.getStateSummary.Observe(duration)
 	return summary, nil
 }
+
+func (vm *blockVM) StateSyncProgress(ctx context.Context) (block.StateSyncProgress, error) {
+	if vm.progressVM == nil {
+		return block.StateSyncProgress{}, block.ErrStateSyncProgressNotImplemented
+	}
+	return vm.progressVM.StateSyncProgress(ctx)
+}
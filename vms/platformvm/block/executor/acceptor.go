@@ -9,8 +9,10 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/index"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/validators"
@@ -30,6 +32,8 @@ type acceptor struct {
 	metrics      metrics.Metrics
 	validators   validators.Manager
 	bootstrapped *utils.Atomic[bool]
+	// If nil, txs aren't indexed.
+	txIndexer index.Indexer
 }
 
 func (a *acceptor) BanffAbortBlock(b *block.BanffAbortBlock) error {
@@ -79,6 +83,13 @@ func (a *acceptor) ApricotAtomicBlock(b *block.ApricotAtomicBlock) error {
 		return fmt.Errorf("%w %s", errMissingBlockState, blkID)
 	}
 
+	// The txs are indexed before [a.state] is updated so that the UTXOs they
+	// consume can be read.
+	batches, err := a.indexTxs(b)
+	if err != nil {
+		return err
+	}
+
 	// Update the state to reflect the changes made in [onAcceptState].
 	if err := blkState.onAcceptState.Apply(a.state); err != nil {
 		return err
@@ -95,7 +106,7 @@ func (a *acceptor) ApricotAtomicBlock(b *block.ApricotAtomicBlock) error {
 	}
 
 	// Note that this method writes [batch] to the database.
-	if err := a.ctx.SharedMemory.Apply(blkState.atomicRequests, batch); err != nil {
+	if err := a.ctx.SharedMemory.Apply(blkState.atomicRequests, append(batches, batch)...); err != nil {
 		return fmt.Errorf(
 			"failed to atomically accept tx %s in block %s: %w",
 			b.Tx.ID(),
@@ -140,6 +151,13 @@ func (a *acceptor) optionBlock(b block.Block, blockType string) error {
 		return err
 	}
 
+	// The txs are indexed before [a.state] is updated so that the UTXOs they
+	// consume can be read.
+	batches, err := a.indexTxs(parentState.statelessBlock)
+	if err != nil {
+		return err
+	}
+
 	if parentState.onDecisionState != nil {
 		if err := parentState.onDecisionState.Apply(a.state); err != nil {
 			return err
@@ -165,7 +183,7 @@ func (a *acceptor) optionBlock(b block.Block, blockType string) error {
 	}
 
 	// Note that this method writes [batch] to the database.
-	if err := a.ctx.SharedMemory.Apply(parentState.atomicRequests, batch); err != nil {
+	if err := a.ctx.SharedMemory.Apply(parentState.atomicRequests, append(batches, batch)...); err != nil {
 		return fmt.Errorf("failed to apply vm's state to shared memory: %w", err)
 	}
 
@@ -228,6 +246,13 @@ func (a *acceptor) standardBlock(b block.Block, blockType string) error {
 		return fmt.Errorf("%w %s", errMissingBlockState, blkID)
 	}
 
+	// The txs are indexed before [a.state] is updated so that the UTXOs they
+	// consume can be read.
+	batches, err := a.indexTxs(b)
+	if err != nil {
+		return err
+	}
+
 	// Update the state to reflect the changes made in [onAcceptState].
 	if err := blkState.onAcceptState.Apply(a.state); err != nil {
 		return err
@@ -244,7 +269,7 @@ func (a *acceptor) standardBlock(b block.Block, blockType string) error {
 	}
 
 	// Note that this method writes [batch] to the database.
-	if err := a.ctx.SharedMemory.Apply(blkState.atomicRequests, batch); err != nil {
+	if err := a.ctx.SharedMemory.Apply(blkState.atomicRequests, append(batches, batch)...); err != nil {
 		return fmt.Errorf("failed to apply vm's state to shared memory: %w", err)
 	}
 
@@ -264,6 +289,25 @@ func (a *acceptor) standardBlock(b block.Block, blockType string) error {
 	return nil
 }
 
+// indexTxs returns the batches that index the txs of [b], which must be
+// written atomically with the acceptance of [b].
+//
+// Assumes [a.state] hasn't been updated to reflect the execution of [b].
+func (a *acceptor) indexTxs(b block.Block) ([]database.Batch, error) {
+	if a.txIndexer == nil {
+		return nil, nil
+	}
+
+	batch, err := a.txIndexer.Accept(a.state, b.Txs())
+	if err != nil {
+		return nil, fmt.Errorf("failed to index txs: %w", err)
+	}
+	if batch == nil {
+		return nil, nil
+	}
+	return []database.Batch{batch}, nil
+}
+
 func (a *acceptor) commonAccept(b block.Block) error {
 	blkID := b.ID()
 
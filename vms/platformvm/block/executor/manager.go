@@ -10,6 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/index"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
@@ -52,6 +53,7 @@ func NewManager(
 	s state.State,
 	txExecutorBackend *executor.Backend,
 	validatorManager validators.Manager,
+	txIndexer index.Indexer,
 ) Manager {
 	lastAccepted := s.GetLastAccepted()
 	backend := &backend{
@@ -69,6 +71,7 @@ func NewManager(
 			metrics:      metrics,
 			validators:   validatorManager,
 			bootstrapped: txExecutorBackend.Bootstrapped,
+			txIndexer:    txIndexer,
 		},
 		rejector: &rejector{
 			backend:         backend,
@@ -110,6 +110,25 @@ type Client interface {
 	//
 	// Deprecated: GetRewardUTXOs should be fetched from a dedicated indexer.
 	GetRewardUTXOs(context.Context, *api.GetTxArgs, ...rpc.Option) ([][]byte, error)
+	// GetAddressTxs returns the IDs of the accepted txs that touched [address],
+	// starting from the [cursor]th such tx, and the cursor of the next page.
+	GetAddressTxs(
+		ctx context.Context,
+		address ids.ShortID,
+		cursor uint64,
+		pageSize uint64,
+		options ...rpc.Option,
+	) ([]ids.ID, uint64, error)
+	// GetSubnetTxs returns the IDs of the accepted txs that touched
+	// [subnetID], starting from the [cursor]th such tx, and the cursor of the
+	// next page.
+	GetSubnetTxs(
+		ctx context.Context,
+		subnetID ids.ID,
+		cursor uint64,
+		pageSize uint64,
+		options ...rpc.Option,
+	) ([]ids.ID, uint64, error)
 	// GetTimestamp returns the current chain timestamp
 	GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error)
 	// GetValidatorsAt returns the weights of the validator set of a provided
@@ -484,6 +503,38 @@ func (c *client) GetRewardUTXOs(ctx context.Context, args *api.GetTxArgs, option
 	return utxos, err
 }
 
+func (c *client) GetAddressTxs(
+	ctx context.Context,
+	address ids.ShortID,
+	cursor uint64,
+	pageSize uint64,
+	options ...rpc.Option,
+) ([]ids.ID, uint64, error) {
+	res := &GetIndexedTxsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getAddressTxs", &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: address.String()},
+		Cursor:      json.Uint64(cursor),
+		PageSize:    json.Uint64(pageSize),
+	}, res, options...)
+	return res.TxIDs, uint64(res.Cursor), err
+}
+
+func (c *client) GetSubnetTxs(
+	ctx context.Context,
+	subnetID ids.ID,
+	cursor uint64,
+	pageSize uint64,
+	options ...rpc.Option,
+) ([]ids.ID, uint64, error) {
+	res := &GetIndexedTxsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getSubnetTxs", &GetSubnetTxsArgs{
+		SubnetID: subnetID,
+		Cursor:   json.Uint64(cursor),
+		PageSize: json.Uint64(pageSize),
+	}, res, options...)
+	return res.TxIDs, uint64(res.Cursor), err
+}
+
 func (c *client) GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error) {
 	res := &GetTimestampReply{}
 	err := c.requester.SendRequest(ctx, "platform.getTimestamp", struct{}{}, res, options...)
@@ -24,6 +24,8 @@ var DefaultExecutionConfig = ExecutionConfig{
 	SubnetManagerCacheSize:       4 * units.MiB,
 	ChecksumsEnabled:             false,
 	MempoolPruneFrequency:        30 * time.Minute,
+	IndexTransactions:            false,
+	IndexAllowIncomplete:         false,
 }
 
 // ExecutionConfig provides execution parameters of PlatformVM
@@ -40,6 +42,8 @@ type ExecutionConfig struct {
 	SubnetManagerCacheSize       int            `json:"subnet-manager-cache-size"`
 	ChecksumsEnabled             bool           `json:"checksums-enabled"`
 	MempoolPruneFrequency        time.Duration  `json:"mempool-prune-frequency"`
+	IndexTransactions            bool           `json:"index-transactions"`
+	IndexAllowIncomplete         bool           `json:"index-allow-incomplete"`
 }
 
 // GetExecutionConfig returns an ExecutionConfig
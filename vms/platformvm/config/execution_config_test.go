@@ -92,6 +92,8 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			SubnetManagerCacheSize:       10,
 			ChecksumsEnabled:             true,
 			MempoolPruneFrequency:        time.Minute,
+			IndexTransactions:            true,
+			IndexAllowIncomplete:         true,
 		}
 		verifyInitializedStruct(t, *expected)
 		verifyInitializedStruct(t, expected.Network)
@@ -0,0 +1,222 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+
+	platformpb "github.com/ava-labs/avalanchego/proto/pb/platform"
+	platformapi "github.com/ava-labs/avalanchego/vms/platformvm/api"
+)
+
+var _ platformpb.PlatformServer = (*grpcService)(nil)
+
+// grpcService serves the read-only methods of the platform API over gRPC. It
+// returns the same results as [Service].
+type grpcService struct {
+	platformpb.UnsafePlatformServer
+	service *Service
+}
+
+func (g *grpcService) GetHeight(ctx context.Context, _ *emptypb.Empty) (*platformpb.GetHeightResponse, error) {
+	vm := g.service.vm
+	vm.ctx.Log.Debug("gRPC called",
+		zap.String("service", "platform"),
+		zap.String("method", "getHeight"),
+	)
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	height, err := vm.GetCurrentHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &platformpb.GetHeightResponse{
+		Height: height,
+	}, nil
+}
+
+func (g *grpcService) GetCurrentValidators(_ context.Context, req *platformpb.GetCurrentValidatorsRequest) (*platformpb.GetCurrentValidatorsResponse, error) {
+	args := &GetCurrentValidatorsArgs{
+		NodeIDs: make([]ids.NodeID, len(req.NodeIds)),
+	}
+	if len(req.SubnetId) != 0 {
+		subnetID, err := ids.ToID(req.SubnetId)
+		if err != nil {
+			return nil, err
+		}
+		args.SubnetID = subnetID
+	}
+	for i, nodeIDBytes := range req.NodeIds {
+		nodeID, err := ids.ToNodeID(nodeIDBytes)
+		if err != nil {
+			return nil, err
+		}
+		args.NodeIDs[i] = nodeID
+	}
+
+	var reply GetCurrentValidatorsReply
+	if err := g.service.GetCurrentValidators(nil, args, &reply); err != nil {
+		return nil, err
+	}
+
+	validators := make([]*platformpb.Validator, len(reply.Validators))
+	for i, vdrIntf := range reply.Validators {
+		switch vdr := vdrIntf.(type) {
+		case platformapi.PermissionlessValidator:
+			validator := newPBValidator(vdr.Staker, vdr.Uptime, vdr.Connected)
+			if vdr.PotentialReward != nil {
+				validator.PotentialReward = uint64(*vdr.PotentialReward)
+			}
+			if vdr.AccruedDelegateeReward != nil {
+				validator.AccruedDelegateeReward = uint64(*vdr.AccruedDelegateeReward)
+			}
+			validator.DelegationFee = float32(vdr.DelegationFee)
+			if vdr.DelegatorCount != nil {
+				validator.DelegatorCount = uint64(*vdr.DelegatorCount)
+			}
+			if vdr.DelegatorWeight != nil {
+				validator.DelegatorWeight = uint64(*vdr.DelegatorWeight)
+			}
+			if vdr.Signer != nil {
+				validator.PublicKey = vdr.Signer.PublicKey[:]
+			}
+			validators[i] = validator
+		case platformapi.PermissionedValidator:
+			validators[i] = newPBValidator(vdr.Staker, vdr.Uptime, vdr.Connected)
+		default:
+			return nil, fmt.Errorf("unexpected validator type %T", vdrIntf)
+		}
+	}
+	return &platformpb.GetCurrentValidatorsResponse{
+		Validators: validators,
+	}, nil
+}
+
+func (g *grpcService) GetSubnet(_ context.Context, req *platformpb.GetSubnetRequest) (*platformpb.GetSubnetResponse, error) {
+	subnetID, err := ids.ToID(req.SubnetId)
+	if err != nil {
+		return nil, err
+	}
+
+	var reply GetSubnetResponse
+	err = g.service.GetSubnet(
+		nil,
+		&GetSubnetArgs{
+			SubnetID: subnetID,
+		},
+		&reply,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &platformpb.GetSubnetResponse{
+		IsPermissioned: reply.IsPermissioned,
+		ControlKeys:    reply.ControlKeys,
+		Threshold:      uint32(reply.Threshold),
+		Locktime:       uint64(reply.Locktime),
+		ManagerAddress: reply.ManagerAddress,
+	}
+	if reply.SubnetTransformationTxID != ids.Empty {
+		response.SubnetTransformationTxId = reply.SubnetTransformationTxID[:]
+	}
+	if reply.ManagerChainID != ids.Empty {
+		response.ManagerChainId = reply.ManagerChainID[:]
+	}
+	return response, nil
+}
+
+func (g *grpcService) GetBlock(_ context.Context, req *platformpb.GetBlockRequest) (*platformpb.GetBlockResponse, error) {
+	blockID, err := ids.ToID(req.BlockId)
+	if err != nil {
+		return nil, err
+	}
+
+	vm := g.service.vm
+	vm.ctx.Log.Debug("gRPC called",
+		zap.String("service", "platform"),
+		zap.String("method", "getBlock"),
+		zap.Stringer("blkID", blockID),
+	)
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	block, err := vm.manager.GetStatelessBlock(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get block with id %s: %w", blockID, err)
+	}
+	return &platformpb.GetBlockResponse{
+		Block: block.Bytes(),
+	}, nil
+}
+
+func (g *grpcService) GetBlockByHeight(_ context.Context, req *platformpb.GetBlockByHeightRequest) (*platformpb.GetBlockResponse, error) {
+	vm := g.service.vm
+	vm.ctx.Log.Debug("gRPC called",
+		zap.String("service", "platform"),
+		zap.String("method", "getBlockByHeight"),
+		zap.Uint64("height", req.Height),
+	)
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	blockID, err := vm.state.GetBlockIDAtHeight(req.Height)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get block at height %d: %w", req.Height, err)
+	}
+	block, err := vm.manager.GetStatelessBlock(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get block with id %s: %w", blockID, err)
+	}
+	return &platformpb.GetBlockResponse{
+		Block: block.Bytes(),
+	}, nil
+}
+
+func (g *grpcService) GetFeeState(context.Context, *emptypb.Empty) (*platformpb.GetFeeStateResponse, error) {
+	var reply GetFeeStateReply
+	if err := g.service.GetFeeState(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &platformpb.GetFeeStateResponse{
+		Capacity:  uint64(reply.Capacity),
+		Excess:    uint64(reply.Excess),
+		Price:     uint64(reply.Price),
+		Timestamp: timestamppb.New(reply.Time),
+	}, nil
+}
+
+func newPBValidator(
+	staker platformapi.Staker,
+	uptime *json.Float32,
+	connected *bool,
+) *platformpb.Validator {
+	validator := &platformpb.Validator{
+		TxId:      staker.TxID[:],
+		NodeId:    staker.NodeID.Bytes(),
+		StartTime: timestamppb.New(time.Unix(int64(staker.StartTime), 0)),
+		EndTime:   timestamppb.New(time.Unix(int64(staker.EndTime), 0)),
+		Weight:    uint64(staker.Weight),
+	}
+	if uptime != nil {
+		validator.Uptime = float32(*uptime)
+	}
+	if connected != nil {
+		validator.Connected = *connected
+	}
+	return validator
+}
@@ -0,0 +1,241 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var (
+	ErrIndexingRequiredFromGenesis = errors.New("running would create incomplete tx index. Allow incomplete indices or re-sync from genesis with tx indexing enabled")
+	ErrCausesIncompleteIndex       = errors.New("running would create incomplete tx index. Allow incomplete indices or enable tx indexing")
+	ErrIndexingDisabled            = errors.New("tx indexing is disabled")
+
+	addressTxsPrefix = []byte{0x00}
+	subnetTxsPrefix  = []byte{0x01}
+	completeKey      = []byte{0x02}
+
+	_ Indexer = (*indexer)(nil)
+	_ Indexer = (*noIndexer)(nil)
+)
+
+// Chain is the state that txs are indexed against.
+type Chain interface {
+	avax.UTXOGetter
+
+	GetTx(txID ids.ID) (*txs.Tx, status.Status, error)
+}
+
+// Indexer maintains which accepted transactions touched which addresses and
+// subnets.
+//
+// A transaction touches an address if the address is an owner of:
+// 1) A UTXO that the transaction consumes from the P-chain.
+// 2) An output that the transaction produces, stakes or exports.
+// 3) An owner that the transaction sets, such as a rewards owner or a subnet
+// owner.
+//
+// Staking rewards are attributed to the RewardValidatorTx that issues them.
+//
+// A transaction touches a subnet if it creates, modifies, or modifies the
+// validators or chains of, the subnet.
+//
+// Transactions in the genesis aren't indexed.
+type Indexer interface {
+	// Accept returns a batch that indexes [txs], which were accepted in the
+	// given order. [chain] must be the state before [txs] were executed.
+	// The returned batch should be written atomically with the acceptance of
+	// [txs]. If nothing needs to be written, the returned batch is nil.
+	Accept(chain Chain, txs []*txs.Tx) (database.Batch, error)
+
+	// GetAddressTxs returns the IDs of the transactions that touched
+	// [address], in the order they were accepted, starting from the
+	// [cursor]th such transaction. The length of the returned slice is at
+	// most [pageSize].
+	GetAddressTxs(address ids.ShortID, cursor, pageSize uint64) ([]ids.ID, error)
+
+	// GetSubnetTxs returns the IDs of the transactions that touched
+	// [subnetID], in the order they were accepted, starting from the
+	// [cursor]th such transaction. The length of the returned slice is at
+	// most [pageSize].
+	GetSubnetTxs(subnetID ids.ID, cursor, pageSize uint64) ([]ids.ID, error)
+}
+
+type indexer struct {
+	db database.Database
+}
+
+// NewIndexer returns an Indexer that indexes transactions into [db].
+//
+// [isNewChain] should be true iff no blocks have been accepted after the
+// genesis.
+func NewIndexer(db database.Database, isNewChain bool, allowIncomplete bool) (Indexer, error) {
+	return &indexer{
+		db: db,
+	}, checkIndexStatus(db, true, isNewChain, allowIncomplete)
+}
+
+// Accept persists the transactions in [txs] under the addresses and subnets
+// they touched. The database structure is:
+//
+// [prefix][address or subnet ID]                => 2 (the number of txs)
+// [prefix][address or subnet ID][BigEndian(0)]  => txID1
+// [prefix][address or subnet ID][BigEndian(1)]  => txID2
+func (i *indexer) Accept(chain Chain, txs []*txs.Tx) (database.Batch, error) {
+	var (
+		batch = i.db.NewBatch()
+		// The number of txs indexed under each key, including those in
+		// [batch].
+		numTxs = make(map[string]uint64)
+		// The UTXOs produced by the txs that have been indexed, which may
+		// be consumed by the txs after them.
+		produced = make(map[ids.ID]*avax.UTXO)
+	)
+	for _, tx := range txs {
+		keys := txKeys{
+			chain:    chain,
+			produced: produced,
+			txID:     tx.ID(),
+		}
+		if err := tx.Unsigned.Visit(&keys); err != nil {
+			return nil, fmt.Errorf("failed to find the keys of tx %s: %w", keys.txID, err)
+		}
+
+		for address := range keys.addresses {
+			key := prefixedKey(addressTxsPrefix, address[:])
+			if err := i.put(batch, numTxs, key, keys.txID); err != nil {
+				return nil, err
+			}
+		}
+		for subnetID := range keys.subnetIDs {
+			key := prefixedKey(subnetTxsPrefix, subnetID[:])
+			if err := i.put(batch, numTxs, key, keys.txID); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, utxo := range tx.UTXOs() {
+			produced[utxo.InputID()] = utxo
+		}
+	}
+	return batch, nil
+}
+
+// put writes [txID] to [batch] as the next tx indexed under [key].
+func (i *indexer) put(batch database.Batch, numTxs map[string]uint64, key []byte, txID ids.ID) error {
+	n, ok := numTxs[string(key)]
+	if !ok {
+		var err error
+		n, err = database.GetUInt64(i.db, key)
+		if err != nil && err != database.ErrNotFound {
+			return fmt.Errorf("failed to get the number of txs indexed: %w", err)
+		}
+	}
+
+	if err := batch.Put(indexKey(key, n), txID[:]); err != nil {
+		return err
+	}
+	n++
+	numTxs[string(key)] = n
+	return database.PutUInt64(batch, key, n)
+}
+
+func (i *indexer) GetAddressTxs(address ids.ShortID, cursor, pageSize uint64) ([]ids.ID, error) {
+	return i.read(prefixedKey(addressTxsPrefix, address[:]), cursor, pageSize)
+}
+
+func (i *indexer) GetSubnetTxs(subnetID ids.ID, cursor, pageSize uint64) ([]ids.ID, error) {
+	return i.read(prefixedKey(subnetTxsPrefix, subnetID[:]), cursor, pageSize)
+}
+
+// read returns at most [pageSize] of the txs indexed under [key], starting
+// from the [cursor]th.
+func (i *indexer) read(key []byte, cursor, pageSize uint64) ([]ids.ID, error) {
+	// The number of txs under [key] is stored at [key], which sorts before
+	// every tx under [key].
+	iter := i.db.NewIteratorWithStartAndPrefix(indexKey(key, cursor), key)
+	defer iter.Release()
+
+	var txIDs []ids.ID
+	for uint64(len(txIDs)) < pageSize && iter.Next() {
+		txID, err := ids.ToID(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		txIDs = append(txIDs, txID)
+	}
+	return txIDs, iter.Error()
+}
+
+func prefixedKey(prefix []byte, key []byte) []byte {
+	prefixedKey := make([]byte, len(prefix)+len(key))
+	copy(prefixedKey, prefix)
+	copy(prefixedKey[len(prefix):], key)
+	return prefixedKey
+}
+
+func indexKey(key []byte, index uint64) []byte {
+	indexKey := make([]byte, len(key)+wrappers.LongLen)
+	copy(indexKey, key)
+	binary.BigEndian.PutUint64(indexKey[len(key):], index)
+	return indexKey
+}
+
+// checkIndexStatus checks that running with indexing [enabled] doesn't make a
+// complete index incomplete, and records whether the index is complete.
+func checkIndexStatus(db database.KeyValueReaderWriter, enabled, isNewChain, allowIncomplete bool) error {
+	complete, err := database.GetBool(db, completeKey)
+	if err == database.ErrNotFound {
+		// We haven't run with this index before. The index is only complete
+		// if it's been enabled since genesis.
+		complete = enabled && isNewChain
+		if enabled && !complete && !allowIncomplete {
+			return ErrIndexingRequiredFromGenesis
+		}
+		return database.PutBool(db, completeKey, complete)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !complete && enabled && !allowIncomplete:
+		return ErrIndexingRequiredFromGenesis
+	case complete && !enabled && !allowIncomplete:
+		return ErrCausesIncompleteIndex
+	case complete && !enabled:
+		return database.PutBool(db, completeKey, false)
+	default:
+		return nil
+	}
+}
+
+type noIndexer struct{}
+
+// NewNoIndexer returns an Indexer that doesn't index transactions. The index
+// in [db], if any, is marked as incomplete.
+func NewNoIndexer(db database.Database, isNewChain bool, allowIncomplete bool) (Indexer, error) {
+	return noIndexer{}, checkIndexStatus(db, false, isNewChain, allowIncomplete)
+}
+
+func (noIndexer) Accept(Chain, []*txs.Tx) (database.Batch, error) {
+	return nil, nil
+}
+
+func (noIndexer) GetAddressTxs(ids.ShortID, uint64, uint64) ([]ids.ID, error) {
+	return nil, ErrIndexingDisabled
+}
+
+func (noIndexer) GetSubnetTxs(ids.ID, uint64, uint64) ([]ids.ID, error) {
+	return nil, ErrIndexingDisabled
+}
@@ -0,0 +1,356 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+type testChain struct {
+	utxos map[ids.ID]*avax.UTXO
+	txs   map[ids.ID]*txs.Tx
+}
+
+func (c *testChain) GetUTXO(utxoID ids.ID) (*avax.UTXO, error) {
+	utxo, ok := c.utxos[utxoID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return utxo, nil
+}
+
+func (c *testChain) GetTx(txID ids.ID) (*txs.Tx, status.Status, error) {
+	tx, ok := c.txs[txID]
+	if !ok {
+		return nil, status.Unknown, database.ErrNotFound
+	}
+	return tx, status.Committed, nil
+}
+
+func owners(addr ids.ShortID) *secp256k1fx.OutputOwners {
+	return &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{addr},
+	}
+}
+
+func output(addr ids.ShortID) *avax.TransferableOutput {
+	return &avax.TransferableOutput{
+		Asset: avax.Asset{ID: ids.Empty},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          1,
+			OutputOwners: *owners(addr),
+		},
+	}
+}
+
+func input(utxoID avax.UTXOID) *avax.TransferableInput {
+	return &avax.TransferableInput{
+		UTXOID: utxoID,
+		Asset:  avax.Asset{ID: ids.Empty},
+		In: &secp256k1fx.TransferInput{
+			Amt: 1,
+			Input: secp256k1fx.Input{
+				SigIndices: []uint32{0},
+			},
+		},
+	}
+}
+
+func baseTx(ins []*avax.TransferableInput, outs []*avax.TransferableOutput) *txs.BaseTx {
+	return &txs.BaseTx{
+		BaseTx: avax.BaseTx{
+			Ins:  ins,
+			Outs: outs,
+		},
+	}
+}
+
+func newTx(t *testing.T, utx txs.UnsignedTx) *txs.Tx {
+	tx, err := txs.NewSigned(utx, txs.Codec, nil)
+	require.NoError(t, err)
+	return tx
+}
+
+func accept(t *testing.T, indexer Indexer, chain Chain, txs ...*txs.Tx) {
+	require := require.New(t)
+
+	batch, err := indexer.Accept(chain, txs)
+	require.NoError(err)
+	require.NotNil(batch)
+	require.NoError(batch.Write())
+}
+
+func TestIndexerAccept(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		addr0 = ids.GenerateTestShortID()
+		addr1 = ids.GenerateTestShortID()
+		addr2 = ids.GenerateTestShortID()
+
+		genesisUTXO = &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Asset: avax.Asset{ID: ids.Empty},
+			Out:   output(addr0).Out,
+		}
+		chain = &testChain{
+			utxos: map[ids.ID]*avax.UTXO{
+				genesisUTXO.InputID(): genesisUTXO,
+			},
+		}
+	)
+
+	indexer, err := NewIndexer(memdb.New(), true, false)
+	require.NoError(err)
+
+	// [createSubnetTx] consumes the UTXO produced by [sendTx] in the same
+	// block.
+	sendTx := newTx(t, baseTx(
+		[]*avax.TransferableInput{input(genesisUTXO.UTXOID)},
+		[]*avax.TransferableOutput{output(addr1)},
+	))
+	createSubnetTx := newTx(t, &txs.CreateSubnetTx{
+		BaseTx: *baseTx(
+			[]*avax.TransferableInput{input(avax.UTXOID{TxID: sendTx.ID()})},
+			[]*avax.TransferableOutput{output(addr1)},
+		),
+		Owner: owners(addr2),
+	})
+	accept(t, indexer, chain, sendTx, createSubnetTx)
+
+	subnetID := createSubnetTx.ID()
+	createChainTx := newTx(t, &txs.CreateChainTx{
+		BaseTx: *baseTx(
+			[]*avax.TransferableInput{input(avax.UTXOID{TxID: createSubnetTx.ID()})},
+			nil,
+		),
+		SubnetID:   subnetID,
+		SubnetAuth: &secp256k1fx.Input{},
+	})
+	chain.utxos = map[ids.ID]*avax.UTXO{}
+	for _, utxo := range createSubnetTx.UTXOs() {
+		chain.utxos[utxo.InputID()] = utxo
+	}
+	accept(t, indexer, chain, createChainTx)
+
+	tests := []struct {
+		name     string
+		read     func(cursor, pageSize uint64) ([]ids.ID, error)
+		cursor   uint64
+		pageSize uint64
+		expected []ids.ID
+	}{
+		{
+			name: "address that only consumed",
+			read: func(cursor, pageSize uint64) ([]ids.ID, error) {
+				return indexer.GetAddressTxs(addr0, cursor, pageSize)
+			},
+			pageSize: 10,
+			expected: []ids.ID{sendTx.ID()},
+		},
+		{
+			name: "address across blocks",
+			read: func(cursor, pageSize uint64) ([]ids.ID, error) {
+				return indexer.GetAddressTxs(addr1, cursor, pageSize)
+			},
+			pageSize: 10,
+			expected: []ids.ID{sendTx.ID(), createSubnetTx.ID(), createChainTx.ID()},
+		},
+		{
+			name: "address page",
+			read: func(cursor, pageSize uint64) ([]ids.ID, error) {
+				return indexer.GetAddressTxs(addr1, cursor, pageSize)
+			},
+			cursor:   1,
+			pageSize: 1,
+			expected: []ids.ID{createSubnetTx.ID()},
+		},
+		{
+			name: "address cursor past the end",
+			read: func(cursor, pageSize uint64) ([]ids.ID, error) {
+				return indexer.GetAddressTxs(addr1, cursor, pageSize)
+			},
+			cursor:   3,
+			pageSize: 10,
+			expected: nil,
+		},
+		{
+			name: "subnet owner",
+			read: func(cursor, pageSize uint64) ([]ids.ID, error) {
+				return indexer.GetAddressTxs(addr2, cursor, pageSize)
+			},
+			pageSize: 10,
+			expected: []ids.ID{createSubnetTx.ID()},
+		},
+		{
+			name: "subnet",
+			read: func(cursor, pageSize uint64) ([]ids.ID, error) {
+				return indexer.GetSubnetTxs(subnetID, cursor, pageSize)
+			},
+			pageSize: 10,
+			expected: []ids.ID{createSubnetTx.ID(), createChainTx.ID()},
+		},
+		{
+			name: "unknown subnet",
+			read: func(cursor, pageSize uint64) ([]ids.ID, error) {
+				return indexer.GetSubnetTxs(ids.GenerateTestID(), cursor, pageSize)
+			},
+			pageSize: 10,
+			expected: nil,
+		},
+	}
+	for _, test := range tests {
+		txIDs, err := test.read(test.cursor, test.pageSize)
+		require.NoError(err, test.name)
+		require.Equal(test.expected, txIDs, test.name)
+	}
+}
+
+func TestIndexerAcceptMissingUTXO(t *testing.T) {
+	require := require.New(t)
+
+	indexer, err := NewIndexer(memdb.New(), true, false)
+	require.NoError(err)
+
+	tx := newTx(t, baseTx(
+		[]*avax.TransferableInput{input(avax.UTXOID{TxID: ids.GenerateTestID()})},
+		nil,
+	))
+	_, err = indexer.Accept(&testChain{}, []*txs.Tx{tx})
+	require.ErrorIs(err, errMissingUTXO)
+}
+
+func TestIndexerRewardValidatorTx(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		stakeAddr  = ids.GenerateTestShortID()
+		rewardAddr = ids.GenerateTestShortID()
+	)
+	delegatorTx := newTx(t, &txs.AddDelegatorTx{
+		Validator: txs.Validator{
+			NodeID: ids.GenerateTestNodeID(),
+		},
+		StakeOuts:              []*avax.TransferableOutput{output(stakeAddr)},
+		DelegationRewardsOwner: owners(rewardAddr),
+	})
+	rewardTx := newTx(t, &txs.RewardValidatorTx{
+		TxID: delegatorTx.ID(),
+	})
+	chain := &testChain{
+		txs: map[ids.ID]*txs.Tx{
+			delegatorTx.ID(): delegatorTx,
+		},
+	}
+
+	indexer, err := NewIndexer(memdb.New(), true, false)
+	require.NoError(err)
+	accept(t, indexer, chain, rewardTx)
+
+	for _, addr := range []ids.ShortID{stakeAddr, rewardAddr} {
+		txIDs, err := indexer.GetAddressTxs(addr, 0, 10)
+		require.NoError(err)
+		require.Equal([]ids.ID{rewardTx.ID()}, txIDs)
+	}
+
+	txIDs, err := indexer.GetSubnetTxs(ids.Empty, 0, 10)
+	require.NoError(err)
+	require.Equal([]ids.ID{rewardTx.ID()}, txIDs)
+}
+
+func TestNoIndexer(t *testing.T) {
+	require := require.New(t)
+
+	indexer, err := NewNoIndexer(memdb.New(), true, false)
+	require.NoError(err)
+
+	batch, err := indexer.Accept(&testChain{}, nil)
+	require.NoError(err)
+	require.Nil(batch)
+
+	_, err = indexer.GetAddressTxs(ids.GenerateTestShortID(), 0, 10)
+	require.ErrorIs(err, ErrIndexingDisabled)
+
+	_, err = indexer.GetSubnetTxs(ids.GenerateTestID(), 0, 10)
+	require.ErrorIs(err, ErrIndexingDisabled)
+}
+
+func TestCheckIndexStatus(t *testing.T) {
+	type run struct {
+		enabled         bool
+		isNewChain      bool
+		allowIncomplete bool
+		expectedErr     error
+	}
+	tests := []struct {
+		name string
+		runs []run
+	}{
+		{
+			name: "disabled on a new chain",
+			runs: []run{
+				{enabled: false, isNewChain: true},
+			},
+		},
+		{
+			name: "enabled on a new chain",
+			runs: []run{
+				{enabled: true, isNewChain: true},
+				{enabled: true},
+			},
+		},
+		{
+			name: "enabled on an existing chain",
+			runs: []run{
+				{enabled: true, expectedErr: ErrIndexingRequiredFromGenesis},
+			},
+		},
+		{
+			name: "enabled on an existing chain allowing incomplete",
+			runs: []run{
+				{enabled: true, allowIncomplete: true},
+				{enabled: true, expectedErr: ErrIndexingRequiredFromGenesis},
+				{enabled: true, allowIncomplete: true},
+			},
+		},
+		{
+			name: "enabled after being disabled",
+			runs: []run{
+				{enabled: false, isNewChain: true},
+				{enabled: true, expectedErr: ErrIndexingRequiredFromGenesis},
+			},
+		},
+		{
+			name: "disabled after being enabled",
+			runs: []run{
+				{enabled: true, isNewChain: true},
+				{enabled: false, expectedErr: ErrCausesIncompleteIndex},
+				{enabled: false, allowIncomplete: true},
+				{enabled: true, expectedErr: ErrIndexingRequiredFromGenesis},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := memdb.New()
+			for _, run := range test.runs {
+				err := checkIndexStatus(db, run.enabled, run.isNewChain, run.allowIncomplete)
+				require.ErrorIs(t, err, run.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	errMissingUTXO = errors.New("missing consumed UTXO")
+
+	_ txs.Visitor = (*txKeys)(nil)
+)
+
+// txKeys finds the addresses and subnets that a transaction touched.
+type txKeys struct {
+	chain    Chain
+	produced map[ids.ID]*avax.UTXO
+	txID     ids.ID
+
+	addresses set.Set[ids.ShortID]
+	subnetIDs set.Set[ids.ID]
+}
+
+func (k *txKeys) AddValidatorTx(tx *txs.AddValidatorTx) error {
+	if err := k.baseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	k.validator(tx)
+	return nil
+}
+
+func (k *txKeys) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
+	k.subnetIDs.Add(tx.SubnetValidator.Subnet)
+	return k.baseTx(&tx.BaseTx)
+}
+
+func (k *txKeys) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
+	if err := k.baseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	k.delegator(tx)
+	return nil
+}
+
+func (k *txKeys) CreateChainTx(tx *txs.CreateChainTx) error {
+	k.subnetIDs.Add(tx.SubnetID)
+	return k.baseTx(&tx.BaseTx)
+}
+
+func (k *txKeys) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
+	k.subnetIDs.Add(k.txID)
+	k.owner(tx.Owner)
+	return k.baseTx(&tx.BaseTx)
+}
+
+func (k *txKeys) ImportTx(tx *txs.ImportTx) error {
+	// The UTXOs that are imported aren't on the P-chain, so only the UTXOs
+	// consumed from the P-chain are indexed.
+	return k.baseTx(&tx.BaseTx)
+}
+
+func (k *txKeys) ExportTx(tx *txs.ExportTx) error {
+	k.outputs(tx.ExportedOutputs)
+	return k.baseTx(&tx.BaseTx)
+}
+
+func (*txKeys) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
+	return nil
+}
+
+// RewardValidatorTx returns the stake to, and rewards, the owners of the
+// staker being removed.
+func (k *txKeys) RewardValidatorTx(tx *txs.RewardValidatorTx) error {
+	stakerTx, _, err := k.chain.GetTx(tx.TxID)
+	if err != nil {
+		return fmt.Errorf("failed to get staker tx %s: %w", tx.TxID, err)
+	}
+
+	switch staker := stakerTx.Unsigned.(type) {
+	case txs.ValidatorTx:
+		k.validator(staker)
+	case txs.DelegatorTx:
+		k.delegator(staker)
+	default:
+		return fmt.Errorf("unexpected staker tx type %T", staker)
+	}
+	return nil
+}
+
+func (k *txKeys) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	k.subnetIDs.Add(tx.Subnet)
+	return k.baseTx(&tx.BaseTx)
+}
+
+func (k *txKeys) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	k.subnetIDs.Add(tx.Subnet)
+	return k.baseTx(&tx.BaseTx)
+}
+
+func (k *txKeys) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	if err := k.baseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	k.validator(tx)
+	return nil
+}
+
+func (k *txKeys) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	if err := k.baseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	k.delegator(tx)
+	return nil
+}
+
+func (k *txKeys) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	k.subnetIDs.Add(tx.Subnet)
+	k.owner(tx.Owner)
+	return k.baseTx(&tx.BaseTx)
+}
+
+func (k *txKeys) ConvertSubnetTx(tx *txs.ConvertSubnetTx) error {
+	k.subnetIDs.Add(tx.Subnet)
+	return k.baseTx(&tx.BaseTx)
+}
+
+func (k *txKeys) BaseTx(tx *txs.BaseTx) error {
+	return k.baseTx(tx)
+}
+
+// baseTx adds the owners of the UTXOs [tx] consumes and the outputs it
+// produces.
+func (k *txKeys) baseTx(tx *txs.BaseTx) error {
+	for _, in := range tx.Ins {
+		utxoID := in.InputID()
+		utxo, ok := k.produced[utxoID]
+		if !ok {
+			var err error
+			utxo, err = k.chain.GetUTXO(utxoID)
+			if err == database.ErrNotFound {
+				return fmt.Errorf("%w %s", errMissingUTXO, utxoID)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get consumed UTXO %s: %w", utxoID, err)
+			}
+		}
+		k.addressable(utxo.Out)
+	}
+	k.outputs(tx.Outs)
+	return nil
+}
+
+// validator adds the owners of the stake and rewards of [tx], and the subnet
+// it validates.
+func (k *txKeys) validator(tx txs.ValidatorTx) {
+	k.subnetIDs.Add(tx.SubnetID())
+	k.outputs(tx.Stake())
+	k.owner(tx.ValidationRewardsOwner())
+	k.owner(tx.DelegationRewardsOwner())
+}
+
+// delegator adds the owners of the stake and rewards of [tx], and the subnet
+// it delegates on.
+func (k *txKeys) delegator(tx txs.DelegatorTx) {
+	k.subnetIDs.Add(tx.SubnetID())
+	k.outputs(tx.Stake())
+	k.owner(tx.RewardsOwner())
+}
+
+func (k *txKeys) outputs(outs []*avax.TransferableOutput) {
+	for _, out := range outs {
+		k.addressable(out.Out)
+	}
+}
+
+func (k *txKeys) owner(owner fx.Owner) {
+	if owners, ok := owner.(*secp256k1fx.OutputOwners); ok {
+		k.addresses.Add(owners.Addrs...)
+	}
+}
+
+// addressable adds the addresses of [out], if it has any.
+func (k *txKeys) addressable(out interface{}) {
+	addressable, ok := out.(avax.Addressable)
+	if !ok {
+		return
+	}
+	for _, addressBytes := range addressable.Addresses() {
+		address, err := ids.ToShortID(addressBytes)
+		if err != nil {
+			continue
+		}
+		k.addresses.Add(address)
+	}
+}
@@ -65,6 +65,7 @@ var (
 	errPrimaryNetworkIsNotASubnet = errors.New("the primary network isn't a subnet")
 	errNoAddresses                = errors.New("no addresses provided")
 	errMissingBlockchainID        = errors.New("argument 'blockchainID' not given")
+	errPageSizeTooLarge           = errors.New("page size is too large")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -1664,6 +1665,107 @@ func (s *Service) GetRewardUTXOs(_ *http.Request, args *api.GetTxArgs, reply *Ge
 	return nil
 }
 
+// GetAddressTxsArgs are the arguments for calling GetAddressTxs
+type GetAddressTxsArgs struct {
+	api.JSONAddress
+	// Cursor used as a page index / offset
+	Cursor avajson.Uint64 `json:"cursor"`
+	// PageSize num of items per page
+	PageSize avajson.Uint64 `json:"pageSize"`
+}
+
+// GetSubnetTxsArgs are the arguments for calling GetSubnetTxs
+type GetSubnetTxsArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+	// Cursor used as a page index / offset
+	Cursor avajson.Uint64 `json:"cursor"`
+	// PageSize num of items per page
+	PageSize avajson.Uint64 `json:"pageSize"`
+}
+
+// GetIndexedTxsReply is the response from calling GetAddressTxs or
+// GetSubnetTxs
+type GetIndexedTxsReply struct {
+	TxIDs []ids.ID `json:"txIDs"`
+	// Cursor used as a page index / offset
+	Cursor avajson.Uint64 `json:"cursor"`
+}
+
+// GetAddressTxs returns the IDs of the accepted transactions that touched the
+// given address, in the order they were accepted.
+func (s *Service) GetAddressTxs(_ *http.Request, args *GetAddressTxsArgs, reply *GetIndexedTxsReply) error {
+	cursor := uint64(args.Cursor)
+	pageSize := uint64(args.PageSize)
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getAddressTxs"),
+		logging.UserString("address", args.Address),
+		zap.Uint64("cursor", cursor),
+		zap.Uint64("pageSize", pageSize),
+	)
+
+	pageSize, err := getPageSize(pageSize)
+	if err != nil {
+		return err
+	}
+
+	address, err := avax.ParseServiceAddress(s.addrManager, args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse argument 'address' to address: %w", err)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	reply.TxIDs, err = s.vm.txIndexer.GetAddressTxs(address, cursor, pageSize)
+	if err != nil {
+		return fmt.Errorf("couldn't get address txs: %w", err)
+	}
+	reply.Cursor = avajson.Uint64(cursor + uint64(len(reply.TxIDs)))
+	return nil
+}
+
+// GetSubnetTxs returns the IDs of the accepted transactions that touched the
+// given subnet, in the order they were accepted.
+func (s *Service) GetSubnetTxs(_ *http.Request, args *GetSubnetTxsArgs, reply *GetIndexedTxsReply) error {
+	cursor := uint64(args.Cursor)
+	pageSize := uint64(args.PageSize)
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getSubnetTxs"),
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Uint64("cursor", cursor),
+		zap.Uint64("pageSize", pageSize),
+	)
+
+	pageSize, err := getPageSize(pageSize)
+	if err != nil {
+		return err
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	reply.TxIDs, err = s.vm.txIndexer.GetSubnetTxs(args.SubnetID, cursor, pageSize)
+	if err != nil {
+		return fmt.Errorf("couldn't get subnet txs: %w", err)
+	}
+	reply.Cursor = avajson.Uint64(cursor + uint64(len(reply.TxIDs)))
+	return nil
+}
+
+// getPageSize returns the number of txs to fetch for a requested [pageSize].
+func getPageSize(pageSize uint64) (uint64, error) {
+	switch {
+	case pageSize > maxPageSize:
+		return 0, fmt.Errorf("%w: %d > %d", errPageSizeTooLarge, pageSize, maxPageSize)
+	case pageSize == 0:
+		return maxPageSize, nil
+	default:
+		return pageSize, nil
+	}
+}
+
 // GetTimestampReply is the response from GetTimestamp
 type GetTimestampReply struct {
 	// Current timestamp
@@ -41,6 +41,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block/executor/executormock"
 	"github.com/ava-labs/avalanchego/vms/platformvm/genesis/genesistest"
+	"github.com/ava-labs/avalanchego/vms/platformvm/index"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
@@ -1197,3 +1198,20 @@ func FuzzGetFeeState(f *testing.F) {
 		require.Equal(expectedReply, reply)
 	})
 }
+
+func TestGetIndexedTxsDisabled(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t, upgradetest.Latest)
+
+	reply := GetIndexedTxsReply{}
+	err := service.GetSubnetTxs(nil, &GetSubnetTxsArgs{
+		SubnetID: constants.PrimaryNetworkID,
+	}, &reply)
+	require.ErrorIs(err, index.ErrIndexingDisabled)
+
+	err = service.GetSubnetTxs(nil, &GetSubnetTxsArgs{
+		SubnetID: constants.PrimaryNetworkID,
+		PageSize: maxPageSize + 1,
+	}, &reply)
+	require.ErrorIs(err, errPageSizeTooLarge)
+}
@@ -12,12 +12,14 @@ import (
 
 	"github.com/gorilla/rpc/v2"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
 	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
@@ -34,6 +36,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/index"
 	"github.com/ava-labs/avalanchego/vms/platformvm/network"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
@@ -42,6 +45,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/avalanchego/vms/txs/mempool"
 
+	platformpb "github.com/ava-labs/avalanchego/proto/pb/platform"
 	snowmanblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	blockbuilder "github.com/ava-labs/avalanchego/vms/platformvm/block/builder"
 	blockexecutor "github.com/ava-labs/avalanchego/vms/platformvm/block/executor"
@@ -55,6 +59,8 @@ var (
 	_ snowmanblock.ChainVM = (*VM)(nil)
 	_ secp256k1fx.VM       = (*VM)(nil)
 	_ validators.State     = (*VM)(nil)
+
+	txIndexPrefix = []byte("txIndex")
 )
 
 type VM struct {
@@ -76,6 +82,9 @@ type VM struct {
 
 	state state.State
 
+	// Indexes accepted txs by the addresses and subnets they touch
+	txIndexer index.Indexer
+
 	fx            fx.Fx
 	codecRegistry codec.Registry
 
@@ -149,6 +158,11 @@ func (vm *VM) Initialize(
 		return err
 	}
 
+	vm.txIndexer, err = vm.newTxIndexer(execConfig)
+	if err != nil {
+		return fmt.Errorf("failed to initialize tx indexer: %w", err)
+	}
+
 	validatorManager := pvalidators.NewManager(chainCtx.Log, vm.Config, vm.state, vm.metrics, &vm.clock)
 	vm.State = validatorManager
 	utxoVerifier := utxo.NewVerifier(vm.ctx, &vm.clock, vm.fx)
@@ -177,6 +191,7 @@ func (vm *VM) Initialize(
 		vm.state,
 		txExecutorBackend,
 		validatorManager,
+		vm.txIndexer,
 	)
 
 	txVerifier := network.NewLockedTxVerifier(&txExecutorBackend.Ctx.Lock, vm.manager)
@@ -243,6 +258,23 @@ func (vm *VM) Initialize(
 	return nil
 }
 
+// newTxIndexer returns the tx indexer described by [execConfig].
+func (vm *VM) newTxIndexer(execConfig *config.ExecutionConfig) (index.Indexer, error) {
+	lastAccepted, err := vm.state.GetStatelessBlock(vm.state.GetLastAccepted())
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		db         = prefixdb.New(txIndexPrefix, vm.db)
+		isNewChain = lastAccepted.Height() == 0
+	)
+	if execConfig.IndexTransactions {
+		return index.NewIndexer(db, isNewChain, execConfig.IndexAllowIncomplete)
+	}
+	return index.NewNoIndexer(db, isNewChain, execConfig.IndexAllowIncomplete)
+}
+
 func (vm *VM) periodicallyPruneMempool(frequency time.Duration) {
 	ticker := time.NewTicker(frequency)
 	defer ticker.Stop()
@@ -455,10 +487,20 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 			Size: stakerAttributesCacheSize,
 		},
 	}
-	err := server.RegisterService(service, "platform")
+	if err := server.RegisterService(service, "platform"); err != nil {
+		return nil, err
+	}
+
+	// The gRPC service is served at the path of its service rather than at
+	// this extension.
+	grpcServer := grpc.NewServer()
+	platformpb.RegisterPlatformServer(grpcServer, &grpcService{
+		service: service,
+	})
 	return map[string]http.Handler{
-		"": server,
-	}, err
+		"":      server,
+		"/grpc": grpcServer,
+	}, nil
 }
 
 func (vm *VM) Connected(ctx context.Context, nodeID ids.NodeID, version *version.Application) error {
@@ -1351,6 +1351,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 		msgChan,
 		time.Hour,
 		2,
+		handler.DefaultPriorityConfig,
 		cpuTracker,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 		tracker.NewPeers(),
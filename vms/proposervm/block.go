@@ -367,6 +367,7 @@ func (p *postForkCommonComponents) verifyPostDurangoBlockDelay(
 	)
 	// populate the slot for the block.
 	blk.slot = &currentSlot
+	blk.parentPChainHeight = parentPChainHeight
 
 	// find the expected proposer
 	expectedProposerID, err := p.vm.Windower.ExpectedProposer(
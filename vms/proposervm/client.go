@@ -0,0 +1,41 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+var _ Client = (*client)(nil)
+
+// Client interface for interacting with the proposervm API of a chain
+type Client interface {
+	// GetProposerStats returns the proposer window counters of [nodeIDs]. If
+	// [nodeIDs] is empty, the counters of every tracked validator are returned.
+	GetProposerStats(ctx context.Context, nodeIDs []ids.NodeID, options ...rpc.Option) ([]ProposerStats, error)
+}
+
+// Client implementation for interacting with the proposervm API of a chain
+type client struct {
+	requester rpc.EndpointRequester
+}
+
+// NewClient returns a Client for interacting with the proposervm API of
+// [chain]
+func NewClient(uri, chain string) Client {
+	return &client{requester: rpc.NewEndpointRequester(
+		uri + "/ext/bc/" + chain + apiEndpoint,
+	)}
+}
+
+func (c *client) GetProposerStats(ctx context.Context, nodeIDs []ids.NodeID, options ...rpc.Option) ([]ProposerStats, error) {
+	res := &GetProposerStatsReply{}
+	err := c.requester.SendRequest(ctx, "proposervm.getProposerStats", &GetProposerStatsArgs{
+		NodeIDs: nodeIDs,
+	}, res, options...)
+	return res.Stats, err
+}
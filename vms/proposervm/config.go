@@ -29,6 +29,11 @@ type Config struct {
 	// Block certificate
 	StakingCertLeaf *staking.Certificate
 
+	// Maximum number of validators whose proposer window counters are
+	// reported as metrics labelled by their node IDs.
+	// Zero signals per-validator metrics are disabled.
+	MetricsMaxValidators int
+
 	// Registerer for prometheus metrics
 	Registerer prometheus.Registerer
 }
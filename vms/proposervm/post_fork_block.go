@@ -21,6 +21,10 @@ type postForkBlock struct {
 	// It is populated in verifyPostDurangoBlockDelay.
 	// It is used to report metrics during Accept.
 	slot *uint64
+	// parentPChainHeight is the P-chain height of this block's parent.
+	// It is populated in verifyPostDurangoBlockDelay.
+	// It is used to report proposer stats during Accept.
+	parentPChainHeight uint64
 }
 
 // Accept:
@@ -28,6 +32,11 @@ type postForkBlock struct {
 // 2) Persists this block in storage
 // 3) Calls Reject() on siblings of this block and their descendants.
 func (b *postForkBlock) Accept(ctx context.Context) error {
+	// Proposer stats are written before the outer block is committed so that
+	// they are persisted atomically with it.
+	if err := b.vm.recordProposerStats(ctx, b); err != nil {
+		return err
+	}
 	if err := b.acceptOuterBlk(); err != nil {
 		return err
 	}
@@ -71,6 +71,21 @@ func (mr *WindowerMockRecorder) ExpectedProposer(arg0, arg1, arg2, arg3 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpectedProposer", reflect.TypeOf((*Windower)(nil).ExpectedProposer), arg0, arg1, arg2, arg3)
 }
 
+// ExpectedProposers mocks base method.
+func (m *Windower) ExpectedProposers(arg0 context.Context, arg1, arg2, arg3 uint64) ([]ids.NodeID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpectedProposers", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]ids.NodeID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpectedProposers indicates an expected call of ExpectedProposers.
+func (mr *WindowerMockRecorder) ExpectedProposers(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpectedProposers", reflect.TypeOf((*Windower)(nil).ExpectedProposers), arg0, arg1, arg2, arg3)
+}
+
 // MinDelayForProposer mocks base method.
 func (m *Windower) MinDelayForProposer(arg0 context.Context, arg1, arg2 uint64, arg3 ids.NodeID, arg4 uint64) (time.Duration, error) {
 	m.ctrl.T.Helper()
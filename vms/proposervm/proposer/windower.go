@@ -77,6 +77,19 @@ type Windower interface {
 		slot uint64,
 	) (ids.NodeID, error)
 
+	// ExpectedProposers returns the nodeIDs that are scheduled to propose a
+	// block of height [blockHeight] in each of the first [numSlots] slots.
+	// It's equivalent to calling [ExpectedProposer] for every slot, but the
+	// validator set is only sampled once.
+	// If no validators are currently available, [ErrAnyoneCanPropose] is
+	// returned.
+	ExpectedProposers(
+		ctx context.Context,
+		blockHeight,
+		pChainHeight,
+		numSlots uint64,
+	) ([]ids.NodeID, error)
+
 	// In the Post-Durango windowing scheme, every validator active at
 	// [pChainHeight] gets specific slots it can propose in (instead of being
 	// able to propose from a given time on as it happens Pre-Durango).
@@ -188,6 +201,37 @@ func (w *windower) ExpectedProposer(
 	)
 }
 
+func (w *windower) ExpectedProposers(
+	ctx context.Context,
+	blockHeight,
+	pChainHeight,
+	numSlots uint64,
+) ([]ids.NodeID, error) {
+	source := prng.NewMT19937_64()
+	sampler, validators, err := w.makeSampler(ctx, pChainHeight, source)
+	if err != nil {
+		return nil, err
+	}
+	if len(validators) == 0 {
+		return nil, ErrAnyoneCanPropose
+	}
+
+	proposers := make([]ids.NodeID, numSlots)
+	for slot := range proposers {
+		proposers[slot], err = w.expectedProposer(
+			validators,
+			source,
+			sampler,
+			blockHeight,
+			uint64(slot),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return proposers, nil
+}
+
 func (w *windower) MinDelayForProposer(
 	ctx context.Context,
 	blockHeight,
@@ -45,6 +45,10 @@ func TestWindowerNoValidators(t *testing.T) {
 	require.ErrorIs(err, ErrAnyoneCanPropose)
 	require.Equal(ids.EmptyNodeID, proposer)
 
+	proposers, err := w.ExpectedProposers(context.Background(), chainHeight, pChainHeight, slot)
+	require.ErrorIs(err, ErrAnyoneCanPropose)
+	require.Empty(proposers)
+
 	delay, err = w.MinDelayForProposer(context.Background(), chainHeight, pChainHeight, nodeID, slot)
 	require.ErrorIs(err, ErrAnyoneCanPropose)
 	require.Zero(delay)
@@ -280,6 +284,28 @@ func TestExpectedProposerChangeBySlot(t *testing.T) {
 	}
 }
 
+func TestCoherenceOfExpectedProposerAndExpectedProposers(t *testing.T) {
+	require := require.New(t)
+
+	_, vdrState := makeValidators(t, 10)
+	w := New(vdrState, subnetID, fixedChainID)
+
+	var (
+		dummyCtx            = context.Background()
+		chainHeight  uint64 = 1
+		pChainHeight uint64 = 0
+	)
+
+	proposers, err := w.ExpectedProposers(dummyCtx, chainHeight, pChainHeight, MaxBuildWindows)
+	require.NoError(err)
+	require.Len(proposers, MaxBuildWindows)
+	for slot, proposerID := range proposers {
+		expectedProposerID, err := w.ExpectedProposer(dummyCtx, chainHeight, pChainHeight, uint64(slot))
+		require.NoError(err)
+		require.Equal(expectedProposerID, proposerID)
+	}
+}
+
 func TestCoherenceOfExpectedProposerAndMinDelayForProposer(t *testing.T) {
 	require := require.New(t)
 
@@ -0,0 +1,271 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+	"github.com/ava-labs/avalanchego/vms/proposervm/state"
+)
+
+const (
+	nodeIDLabel = "nodeID"
+
+	// maxProposerStats is the maximum number of validators whose proposer
+	// window counters are persisted.
+	maxProposerStats = 4096
+	// maxProposerStatsSlots is the maximum number of slots preceding an
+	// accepted block whose expected proposers are charged with a miss.
+	maxProposerStatsSlots = proposer.MaxBuildWindows
+)
+
+var (
+	_ prometheus.Collector = (*proposerStatsMetrics)(nil)
+
+	nodeIDLabels = []string{nodeIDLabel}
+)
+
+// proposerStatsMetrics reports the totals of the proposer window counters of
+// every validator, and the counters of the validators that missed the most
+// slots, labelled by their node IDs.
+//
+// To limit the cardinality of the metrics, at most [maxValidators] validators
+// are reported. The counters of every validator are served by the API.
+type proposerStatsMetrics struct {
+	expected  prometheus.Counter
+	proposed  prometheus.Counter
+	missed    prometheus.Counter
+	late      prometheus.Counter
+	totalSlot prometheus.Counter
+
+	maxValidators   int
+	validatorMissed *prometheus.Desc
+	validatorLate   *prometheus.Desc
+
+	// lock protects [validators], which is read while metrics are gathered.
+	lock sync.Mutex
+	// validators are the counters of the tracked validators. They are only
+	// tracked if [maxValidators] is positive.
+	validators map[ids.NodeID]state.ProposerStats
+}
+
+func newProposerStatsMetrics(reg prometheus.Registerer, maxValidators int) (*proposerStatsMetrics, error) {
+	m := &proposerStatsMetrics{
+		expected: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "proposer_expected",
+			Help: "number of times validators were scheduled to propose at or before an accepted block",
+		}),
+		proposed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "proposer_proposed",
+			Help: "number of accepted blocks proposed by scheduled validators",
+		}),
+		missed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "proposer_missed",
+			Help: "number of times validators didn't propose an accepted block proposed by another validator after their slot started",
+		}),
+		late: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "proposer_late",
+			Help: "number of accepted blocks proposed after one of their proposer's earlier slots passed",
+		}),
+		totalSlot: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "proposer_slot_sum",
+			Help: "sum of the slots of the accepted blocks proposed by scheduled validators",
+		}),
+		maxValidators: maxValidators,
+		validatorMissed: prometheus.NewDesc(
+			"proposer_validator_missed",
+			"number of times the validator didn't propose an accepted block proposed by another validator after its slot started",
+			nodeIDLabels,
+			nil,
+		),
+		validatorLate: prometheus.NewDesc(
+			"proposer_validator_late",
+			"number of accepted blocks the validator proposed after one of its earlier slots passed",
+			nodeIDLabels,
+			nil,
+		),
+		validators: make(map[ids.NodeID]state.ProposerStats),
+	}
+	return m, errors.Join(
+		reg.Register(m.expected),
+		reg.Register(m.proposed),
+		reg.Register(m.missed),
+		reg.Register(m.late),
+		reg.Register(m.totalSlot),
+		reg.Register(m),
+	)
+}
+
+// add increases the counters by the difference between [before] and [after],
+// the counters of [nodeID].
+func (m *proposerStatsMetrics) add(nodeID ids.NodeID, before, after state.ProposerStats) {
+	m.expected.Add(float64(after.Expected - before.Expected))
+	m.proposed.Add(float64(after.Proposed - before.Proposed))
+	m.missed.Add(float64(after.Missed - before.Missed))
+	m.late.Add(float64(after.Late - before.Late))
+	m.totalSlot.Add(float64(after.TotalSlot - before.TotalSlot))
+
+	if m.maxValidators <= 0 {
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.validators[nodeID] = after
+}
+
+// remove stops reporting the counters of [nodeID], which is no longer tracked.
+// The totals are unchanged.
+func (m *proposerStatsMetrics) remove(nodeID ids.NodeID) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.validators, nodeID)
+}
+
+func (m *proposerStatsMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.validatorMissed
+	ch <- m.validatorLate
+}
+
+// Collect reports the counters of the [maxValidators] validators that missed
+// the most slots. Ties are broken by the number of late blocks, and then by
+// node ID.
+func (m *proposerStatsMetrics) Collect(ch chan<- prometheus.Metric) {
+	type validator struct {
+		nodeID ids.NodeID
+		stats  state.ProposerStats
+	}
+
+	m.lock.Lock()
+	validators := make([]validator, 0, len(m.validators))
+	for nodeID, stats := range m.validators {
+		validators = append(validators, validator{
+			nodeID: nodeID,
+			stats:  stats,
+		})
+	}
+	m.lock.Unlock()
+
+	slices.SortFunc(validators, func(a, b validator) int {
+		if c := cmp.Compare(b.stats.Missed, a.stats.Missed); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.stats.Late, a.stats.Late); c != 0 {
+			return c
+		}
+		return a.nodeID.Compare(b.nodeID)
+	})
+	if len(validators) > m.maxValidators {
+		validators = validators[:m.maxValidators]
+	}
+
+	for _, v := range validators {
+		nodeID := v.nodeID.String()
+		ch <- prometheus.MustNewConstMetric(m.validatorMissed, prometheus.CounterValue, float64(v.stats.Missed), nodeID)
+		ch <- prometheus.MustNewConstMetric(m.validatorLate, prometheus.CounterValue, float64(v.stats.Late), nodeID)
+	}
+}
+
+// recordProposerStats updates the proposer window counters with the outcome of
+// accepting [blk].
+//
+// Every validator that was scheduled to propose in a slot before [blk]'s slot,
+// other than the proposer of [blk], is charged with a miss. If the proposer of
+// [blk] was scheduled in an earlier slot, it is charged with building late.
+//
+// Only blocks that were verified after the Durango activation during normal
+// operation are recorded, as the slot of the block is otherwise unknown.
+func (vm *VM) recordProposerStats(ctx context.Context, blk *postForkBlock) error {
+	if blk.slot == nil {
+		return nil
+	}
+	proposerID := blk.Proposer()
+	if proposerID == ids.EmptyNodeID {
+		return nil
+	}
+
+	var (
+		height   = blk.Height()
+		slot     = *blk.slot
+		numSlots = min(slot, maxProposerStatsSlots)
+		late     bool
+		missed   = make(map[ids.NodeID]struct{})
+	)
+	expectedProposerIDs, err := vm.Windower.ExpectedProposers(
+		ctx,
+		height,
+		blk.parentPChainHeight,
+		numSlots,
+	)
+	switch {
+	case errors.Is(err, proposer.ErrAnyoneCanPropose):
+		// No validator was scheduled, so no validator is charged.
+		return nil
+	case err != nil:
+		vm.ctx.Log.Warn("failed to record proposer stats",
+			zap.String("reason", "failed to calculate expected proposers"),
+			zap.Stringer("blkID", blk.ID()),
+			zap.Error(err),
+		)
+		return nil
+	}
+	for _, expectedProposerID := range expectedProposerIDs {
+		if expectedProposerID == proposerID {
+			late = true
+			continue
+		}
+		missed[expectedProposerID] = struct{}{}
+	}
+
+	for nodeID := range missed {
+		err := vm.updateProposerStats(nodeID, func(stats *state.ProposerStats) {
+			stats.Expected++
+			stats.Missed++
+			stats.LastHeight = height
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return vm.updateProposerStats(proposerID, func(stats *state.ProposerStats) {
+		stats.Expected++
+		stats.Proposed++
+		if late {
+			stats.Late++
+		}
+		stats.TotalSlot += slot
+		stats.LastHeight = height
+	})
+}
+
+func (vm *VM) updateProposerStats(nodeID ids.NodeID, update func(*state.ProposerStats)) error {
+	before, err := vm.proposerStats.GetProposerStats(nodeID)
+	if err != nil && err != database.ErrNotFound {
+		return err
+	}
+
+	after := before
+	update(&after)
+	evictedNodeID, evicted, err := vm.proposerStats.PutProposerStats(nodeID, after)
+	if err != nil {
+		return err
+	}
+	if evicted {
+		vm.proposerStatsMetrics.remove(evictedNodeID)
+	}
+	vm.proposerStatsMetrics.add(nodeID, before, after)
+	return nil
+}
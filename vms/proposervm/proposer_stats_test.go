@@ -0,0 +1,165 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman/snowmantest"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer/proposermock"
+	"github.com/ava-labs/avalanchego/vms/proposervm/state"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+	statelessblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
+)
+
+func TestRecordProposerStats(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	var (
+		activationTime = time.Unix(0, 0)
+		durangoTime    = activationTime
+	)
+	_, _, proVM, _ := initTestProposerVM(t, activationTime, durangoTime, 0)
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+
+	coreBlk := snowmantest.BuildChild(snowmantest.Genesis)
+	statelessBlk, err := statelessblock.Build(
+		snowmantest.GenesisID,
+		proVM.Time(),
+		0,
+		proVM.StakingCertLeaf,
+		coreBlk.Bytes(),
+		proVM.ctx.ChainID,
+		proVM.StakingLeafSigner,
+	)
+	require.NoError(err)
+
+	var (
+		proposerID         = statelessBlk.Proposer()
+		missedID           = ids.GenerateTestNodeID()
+		slot               = uint64(3)
+		parentPChainHeight = uint64(7)
+	)
+	blk := &postForkBlock{
+		SignedBlock: statelessBlk,
+		postForkCommonComponents: postForkCommonComponents{
+			vm:       proVM,
+			innerBlk: coreBlk,
+		},
+		slot:               &slot,
+		parentPChainHeight: parentPChainHeight,
+	}
+
+	// [missedID] is scheduled before and after the proposer's first slot.
+	windower := proposermock.NewWindower(ctrl)
+	windower.EXPECT().ExpectedProposers(gomock.Any(), blk.Height(), parentPChainHeight, slot).Return(
+		[]ids.NodeID{missedID, proposerID, missedID},
+		nil,
+	)
+	proVM.Windower = windower
+
+	require.NoError(proVM.recordProposerStats(context.Background(), blk))
+
+	service := &Service{vm: proVM}
+	reply := &GetProposerStatsReply{}
+	require.NoError(service.GetProposerStats(
+		nil,
+		&GetProposerStatsArgs{
+			NodeIDs: []ids.NodeID{
+				proposerID,
+				missedID,
+				ids.GenerateTestNodeID(), // untracked validators are omitted
+			},
+		},
+		reply,
+	))
+	require.Equal(
+		[]ProposerStats{
+			{
+				NodeID:     proposerID,
+				Expected:   1,
+				Proposed:   1,
+				Late:       1,
+				TotalSlot:  avajson.Uint64(slot),
+				LastHeight: avajson.Uint64(blk.Height()),
+			},
+			{
+				NodeID:     missedID,
+				Expected:   1,
+				Missed:     1,
+				LastHeight: avajson.Uint64(blk.Height()),
+			},
+		},
+		reply.Stats,
+	)
+	require.Equal(2.0, testutil.ToFloat64(proVM.proposerStatsMetrics.expected))
+	require.Equal(1.0, testutil.ToFloat64(proVM.proposerStatsMetrics.missed))
+
+	// While anyone can propose, no validator is charged.
+	windower.EXPECT().ExpectedProposers(gomock.Any(), blk.Height(), parentPChainHeight, slot).Return(
+		nil,
+		proposer.ErrAnyoneCanPropose,
+	)
+	require.NoError(proVM.recordProposerStats(context.Background(), blk))
+	require.Equal(2.0, testutil.ToFloat64(proVM.proposerStatsMetrics.expected))
+}
+
+func TestProposerStatsMetrics(t *testing.T) {
+	require := require.New(t)
+
+	m, err := newProposerStatsMetrics(prometheus.NewRegistry(), 2)
+	require.NoError(err)
+
+	var (
+		worstID   = ids.BuildTestNodeID([]byte{1})
+		lateID    = ids.BuildTestNodeID([]byte{2})
+		fewerID   = ids.BuildTestNodeID([]byte{3})
+		evictedID = ids.BuildTestNodeID([]byte{4})
+	)
+	m.add(fewerID, state.ProposerStats{}, state.ProposerStats{Expected: 1, Missed: 1})
+	m.add(lateID, state.ProposerStats{}, state.ProposerStats{Expected: 3, Proposed: 1, Missed: 2, Late: 1})
+	m.add(worstID, state.ProposerStats{}, state.ProposerStats{Expected: 2, Missed: 2})
+	m.add(worstID, state.ProposerStats{Expected: 2, Missed: 2}, state.ProposerStats{Expected: 3, Missed: 3})
+	m.add(evictedID, state.ProposerStats{}, state.ProposerStats{Expected: 5, Missed: 5})
+	m.remove(evictedID)
+
+	// The totals include every validator.
+	require.Equal(11.0, testutil.ToFloat64(m.missed))
+
+	// Only the validators that missed the most slots are reported.
+	expected := strings.NewReplacer(
+		"WORST_ID", worstID.String(),
+		"LATE_ID", lateID.String(),
+	).Replace(`
+# HELP proposer_validator_late number of accepted blocks the validator proposed after one of its earlier slots passed
+# TYPE proposer_validator_late counter
+proposer_validator_late{nodeID="LATE_ID"} 1
+proposer_validator_late{nodeID="WORST_ID"} 0
+# HELP proposer_validator_missed number of times the validator didn't propose an accepted block proposed by another validator after its slot started
+# TYPE proposer_validator_missed counter
+proposer_validator_missed{nodeID="LATE_ID"} 2
+proposer_validator_missed{nodeID="WORST_ID"} 3
+`)
+	require.NoError(testutil.CollectAndCompare(m, strings.NewReader(expected)))
+
+	// Per-validator metrics can be disabled.
+	m, err = newProposerStatsMetrics(prometheus.NewRegistry(), 0)
+	require.NoError(err)
+	m.add(worstID, state.ProposerStats{}, state.ProposerStats{Expected: 1, Missed: 1})
+	require.Zero(testutil.CollectAndCount(m))
+}
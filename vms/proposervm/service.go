@@ -0,0 +1,97 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/vms/proposervm/state"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+// apiEndpoint is the extension of the chain's API endpoint that serves the
+// proposervm API.
+const apiEndpoint = "/proposervm"
+
+// Service defines the API calls that can be made to the proposervm
+type Service struct {
+	vm *VM
+}
+
+type GetProposerStatsArgs struct {
+	// NodeIDs limits the reply to the provided validators. If empty, every
+	// tracked validator is returned.
+	NodeIDs []ids.NodeID `json:"nodeIDs"`
+}
+
+// ProposerStats are the proposer window counters of a validator.
+type ProposerStats struct {
+	NodeID     ids.NodeID     `json:"nodeID"`
+	Expected   avajson.Uint64 `json:"expected"`
+	Proposed   avajson.Uint64 `json:"proposed"`
+	Missed     avajson.Uint64 `json:"missed"`
+	Late       avajson.Uint64 `json:"late"`
+	TotalSlot  avajson.Uint64 `json:"totalSlot"`
+	LastHeight avajson.Uint64 `json:"lastHeight"`
+}
+
+type GetProposerStatsReply struct {
+	Stats []ProposerStats `json:"stats"`
+}
+
+// GetProposerStats returns how often validators proposed in, or missed, the
+// slots they were scheduled in.
+func (s *Service) GetProposerStats(_ *http.Request, args *GetProposerStatsArgs, reply *GetProposerStatsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "proposervm"),
+		zap.String("method", "getProposerStats"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	if len(args.NodeIDs) == 0 {
+		for nodeID, stats := range s.vm.proposerStats.GetAllProposerStats() {
+			reply.Stats = append(reply.Stats, newProposerStats(nodeID, stats))
+		}
+		utils.Sort(reply.Stats)
+		return nil
+	}
+
+	reply.Stats = make([]ProposerStats, 0, len(args.NodeIDs))
+	for _, nodeID := range args.NodeIDs {
+		stats, err := s.vm.proposerStats.GetProposerStats(nodeID)
+		switch err {
+		case nil:
+			reply.Stats = append(reply.Stats, newProposerStats(nodeID, stats))
+		case database.ErrNotFound:
+			// Validators that aren't tracked are omitted from the reply.
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+func newProposerStats(nodeID ids.NodeID, stats state.ProposerStats) ProposerStats {
+	return ProposerStats{
+		NodeID:     nodeID,
+		Expected:   avajson.Uint64(stats.Expected),
+		Proposed:   avajson.Uint64(stats.Proposed),
+		Missed:     avajson.Uint64(stats.Missed),
+		Late:       avajson.Uint64(stats.Late),
+		TotalSlot:  avajson.Uint64(stats.TotalSlot),
+		LastHeight: avajson.Uint64(stats.LastHeight),
+	}
+}
+
+func (s ProposerStats) Compare(o ProposerStats) int {
+	return s.NodeID.Compare(o.NodeID)
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var _ ProposerStatsState = (*proposerStatsState)(nil)
+
+// ProposerStats are the proposer window counters tracked for a validator.
+type ProposerStats struct {
+	// Expected is the number of accepted blocks for which the validator was
+	// scheduled to propose in a slot at, or before, the slot the block was
+	// proposed in.
+	Expected uint64 `serialize:"true"`
+	// Proposed is the number of accepted blocks proposed by the validator.
+	Proposed uint64 `serialize:"true"`
+	// Missed is the number of accepted blocks that were proposed by another
+	// validator after the validator's slot had started.
+	Missed uint64 `serialize:"true"`
+	// Late is the number of accepted blocks the validator proposed after one
+	// of its earlier slots for the same height had passed.
+	Late uint64 `serialize:"true"`
+	// TotalSlot is the sum of the slots of the accepted blocks proposed by the
+	// validator.
+	TotalSlot uint64 `serialize:"true"`
+	// LastHeight is the height of the last accepted block that updated these
+	// counters.
+	LastHeight uint64 `serialize:"true"`
+}
+
+// ProposerStatsState stores the proposer window counters of at most a fixed
+// number of validators. When the limit is reached, the validator that was
+// updated least recently is evicted.
+type ProposerStatsState interface {
+	// GetProposerStats returns the counters of [nodeID]. If the validator isn't
+	// tracked, [database.ErrNotFound] is returned.
+	GetProposerStats(nodeID ids.NodeID) (ProposerStats, error)
+	// GetAllProposerStats returns the counters of every tracked validator.
+	GetAllProposerStats() map[ids.NodeID]ProposerStats
+	// PutProposerStats sets the counters of [nodeID]. If a validator was
+	// evicted to make room for [nodeID], it is returned.
+	PutProposerStats(nodeID ids.NodeID, stats ProposerStats) (ids.NodeID, bool, error)
+}
+
+type proposerStatsState struct {
+	maxSize int
+	stats   map[ids.NodeID]ProposerStats
+	db      database.Database
+}
+
+// NewProposerStatsState loads the counters stored in [db] and limits the
+// number of tracked validators to [maxSize].
+func NewProposerStatsState(db database.Database, maxSize int) (ProposerStatsState, error) {
+	s := &proposerStatsState{
+		maxSize: maxSize,
+		stats:   make(map[ids.NodeID]ProposerStats),
+		db:      db,
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		nodeID, err := ids.ToNodeID(it.Key())
+		if err != nil {
+			return nil, err
+		}
+
+		var stats ProposerStats
+		if _, err := Codec.Unmarshal(it.Value(), &stats); err != nil {
+			return nil, err
+		}
+		s.stats[nodeID] = stats
+	}
+	return s, it.Error()
+}
+
+func (s *proposerStatsState) GetProposerStats(nodeID ids.NodeID) (ProposerStats, error) {
+	stats, ok := s.stats[nodeID]
+	if !ok {
+		return ProposerStats{}, database.ErrNotFound
+	}
+	return stats, nil
+}
+
+func (s *proposerStatsState) GetAllProposerStats() map[ids.NodeID]ProposerStats {
+	allStats := make(map[ids.NodeID]ProposerStats, len(s.stats))
+	for nodeID, stats := range s.stats {
+		allStats[nodeID] = stats
+	}
+	return allStats
+}
+
+func (s *proposerStatsState) PutProposerStats(nodeID ids.NodeID, stats ProposerStats) (ids.NodeID, bool, error) {
+	var (
+		evictedNodeID ids.NodeID
+		evicted       bool
+	)
+	if _, ok := s.stats[nodeID]; !ok && len(s.stats) >= s.maxSize {
+		evictedNodeID, evicted = s.oldest()
+		if evicted {
+			delete(s.stats, evictedNodeID)
+			if err := s.db.Delete(evictedNodeID.Bytes()); err != nil {
+				return ids.EmptyNodeID, false, err
+			}
+		}
+	}
+
+	bytes, err := Codec.Marshal(CodecVersion, &stats)
+	if err != nil {
+		return ids.EmptyNodeID, false, err
+	}
+
+	s.stats[nodeID] = stats
+	return evictedNodeID, evicted, s.db.Put(nodeID.Bytes(), bytes)
+}
+
+// oldest returns the tracked validator that was updated least recently.
+func (s *proposerStatsState) oldest() (ids.NodeID, bool) {
+	var (
+		oldestNodeID ids.NodeID
+		oldestHeight uint64
+		found        bool
+	)
+	for nodeID, stats := range s.stats {
+		isOlder := stats.LastHeight < oldestHeight ||
+			(stats.LastHeight == oldestHeight && nodeID.Compare(oldestNodeID) < 0)
+		if !found || isOlder {
+			oldestNodeID = nodeID
+			oldestHeight = stats.LastHeight
+			found = true
+		}
+	}
+	return oldestNodeID, found
+}
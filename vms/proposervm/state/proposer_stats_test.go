@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestProposerStatsState(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	s, err := NewProposerStatsState(db, 2)
+	require.NoError(err)
+
+	var (
+		nodeID0 = ids.GenerateTestNodeID()
+		nodeID1 = ids.GenerateTestNodeID()
+		nodeID2 = ids.GenerateTestNodeID()
+		stats0  = ProposerStats{Expected: 2, Proposed: 1, Missed: 1, LastHeight: 5}
+		stats1  = ProposerStats{Expected: 1, Proposed: 1, TotalSlot: 3, LastHeight: 3}
+		stats2  = ProposerStats{Expected: 1, Missed: 1, LastHeight: 6}
+	)
+
+	_, err = s.GetProposerStats(nodeID0)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	_, evicted, err := s.PutProposerStats(nodeID0, stats0)
+	require.NoError(err)
+	require.False(evicted)
+
+	_, evicted, err = s.PutProposerStats(nodeID1, stats1)
+	require.NoError(err)
+	require.False(evicted)
+
+	// Updating a tracked validator never evicts.
+	stats0.Late++
+	_, evicted, err = s.PutProposerStats(nodeID0, stats0)
+	require.NoError(err)
+	require.False(evicted)
+
+	// The least recently updated validator is evicted once the limit is
+	// reached.
+	evictedNodeID, evicted, err := s.PutProposerStats(nodeID2, stats2)
+	require.NoError(err)
+	require.True(evicted)
+	require.Equal(nodeID1, evictedNodeID)
+
+	_, err = s.GetProposerStats(nodeID1)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	fetchedStats, err := s.GetProposerStats(nodeID0)
+	require.NoError(err)
+	require.Equal(stats0, fetchedStats)
+
+	// The tracked validators are reloaded from the database.
+	s, err = NewProposerStatsState(db, 2)
+	require.NoError(err)
+	require.Equal(
+		map[ids.NodeID]ProposerStats{
+			nodeID0: stats0,
+			nodeID2: stats2,
+		},
+		s.GetAllProposerStats(),
+	)
+}
@@ -35,6 +35,14 @@ func (vm *VM) GetOngoingSyncStateSummary(ctx context.Context) (block.StateSummar
 	return vm.buildStateSummary(ctx, innerSummary)
 }
 
+func (vm *VM) StateSyncProgress(ctx context.Context) (block.StateSyncProgress, error) {
+	if vm.progressVM == nil {
+		return block.StateSyncProgress{}, block.ErrStateSyncProgressNotImplemented
+	}
+
+	return vm.progressVM.StateSyncProgress(ctx)
+}
+
 func (vm *VM) GetLastStateSummary(ctx context.Context) (block.StateSummary, error) {
 	if vm.ssVM == nil {
 		return nil, block.ErrStateSyncableVMNotImplemented
@@ -7,8 +7,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
+	"github.com/gorilla/rpc/v2"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
@@ -23,6 +25,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/units"
@@ -47,11 +50,13 @@ const (
 )
 
 var (
-	_ block.ChainVM         = (*VM)(nil)
-	_ block.BatchedChainVM  = (*VM)(nil)
-	_ block.StateSyncableVM = (*VM)(nil)
+	_ block.ChainVM                   = (*VM)(nil)
+	_ block.BatchedChainVM            = (*VM)(nil)
+	_ block.StateSyncableVM           = (*VM)(nil)
+	_ block.StateSyncProgressReporter = (*VM)(nil)
 
-	dbPrefix = []byte("proposervm")
+	dbPrefix            = []byte("proposervm")
+	proposerStatsPrefix = []byte("proposerStats")
 )
 
 func cachedBlockSize(_ ids.ID, blk snowman.Block) int {
@@ -64,6 +69,7 @@ type VM struct {
 	blockBuilderVM block.BuildBlockWithContextChainVM
 	batchedVM      block.BatchedChainVM
 	ssVM           block.StateSyncableVM
+	progressVM     block.StateSyncProgressReporter
 
 	state.State
 
@@ -105,6 +111,11 @@ type VM struct {
 	// acceptedBlocksSlotHistogram reports the slots that accepted blocks were
 	// proposed in.
 	acceptedBlocksSlotHistogram prometheus.Histogram
+
+	// proposerStats tracks how often validators propose in, or miss, the
+	// slots they are scheduled in.
+	proposerStats        state.ProposerStatsState
+	proposerStatsMetrics *proposerStatsMetrics
 }
 
 // New performs best when [minBlkDelay] is whole seconds. This is because block
@@ -116,12 +127,14 @@ func New(
 	blockBuilderVM, _ := vm.(block.BuildBlockWithContextChainVM)
 	batchedVM, _ := vm.(block.BatchedChainVM)
 	ssVM, _ := vm.(block.StateSyncableVM)
+	progressVM, _ := vm.(block.StateSyncProgressReporter)
 	return &VM{
 		ChainVM:        vm,
 		Config:         config,
 		blockBuilderVM: blockBuilderVM,
 		batchedVM:      batchedVM,
 		ssVM:           ssVM,
+		progressVM:     progressVM,
 	}
 }
 
@@ -143,6 +156,13 @@ func (vm *VM) Initialize(
 		return err
 	}
 	vm.State = baseState
+	vm.proposerStats, err = state.NewProposerStatsState(
+		prefixdb.New(proposerStatsPrefix, vm.db),
+		maxProposerStats,
+	)
+	if err != nil {
+		return err
+	}
 	vm.Windower = proposer.New(chainCtx.ValidatorState, chainCtx.SubnetID, chainCtx.ChainID)
 	vm.Tree = tree.New()
 	innerBlkCache, err := metercacher.New(
@@ -232,12 +252,44 @@ func (vm *VM) Initialize(
 		Buckets: []float64{0.5, 1.5, 2.5},
 	})
 
+	vm.proposerStatsMetrics, err = newProposerStatsMetrics(
+		vm.Config.Registerer,
+		vm.Config.MetricsMaxValidators,
+	)
+	if err != nil {
+		return err
+	}
+	for nodeID, stats := range vm.proposerStats.GetAllProposerStats() {
+		vm.proposerStatsMetrics.add(nodeID, state.ProposerStats{}, stats)
+	}
+
 	return errors.Join(
 		vm.Config.Registerer.Register(vm.proposerBuildSlotGauge),
 		vm.Config.Registerer.Register(vm.acceptedBlocksSlotHistogram),
 	)
 }
 
+// CreateHandlers returns the handlers of the inner VM along with the
+// proposervm API.
+func (vm *VM) CreateHandlers(ctx context.Context) (map[string]http.Handler, error) {
+	handlers, err := vm.ChainVM.CreateHandlers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if handlers == nil {
+		handlers = make(map[string]http.Handler)
+	}
+
+	server := rpc.NewServer()
+	server.RegisterCodec(json.NewCodec(), "application/json")
+	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
+	if err := server.RegisterService(&Service{vm: vm}, "proposervm"); err != nil {
+		return nil, err
+	}
+	handlers[apiEndpoint] = server
+	return handlers, nil
+}
+
 // shutdown ops then propagate shutdown to innerVM
 func (vm *VM) Shutdown(ctx context.Context) error {
 	vm.onShutdown()
@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+
+	bip32 "github.com/tyler-smith/go-bip32"
+	bip39 "github.com/tyler-smith/go-bip39"
+)
+
+const (
+	// MnemonicEntropyBits is the entropy of the mnemonics returned by
+	// NewMnemonic, which results in 24 words.
+	MnemonicEntropyBits = 256
+
+	// bip44Purpose and avaxCoinType are the hardened levels of the BIP-44
+	// derivation path m/44'/9000'/0'/0/i, which is also used by the ledger.
+	bip44Purpose = 44
+	avaxCoinType = 9000
+)
+
+var (
+	_ keychain.Keychain = (*HDKeychain)(nil)
+
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+)
+
+// HDKeychain is a Keychain whose keys are derived from a BIP-39 mnemonic along
+// the BIP-44 paths m/44'/9000'/0'/0/i.
+type HDKeychain struct {
+	*Keychain
+
+	// externalChain is the extended key at m/44'/9000'/0'/0.
+	externalChain *bip32.Key
+	addrToIndex   map[ids.ShortID]uint32
+}
+
+// NewMnemonic returns a new randomly generated mnemonic.
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(MnemonicEntropyBits)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// NewHDKeychain returns a new HDKeychain holding the first [numToDerive] keys
+// of [mnemonic]. [passphrase] is the optional BIP-39 passphrase.
+func NewHDKeychain(mnemonic, passphrase string, numToDerive int) (*HDKeychain, error) {
+	if numToDerive < 1 {
+		return nil, keychain.ErrInvalidNumAddrsToDerive
+	}
+
+	indices := make([]uint32, numToDerive)
+	for i := range indices {
+		indices[i] = uint32(i)
+	}
+	return NewHDKeychainFromIndices(mnemonic, passphrase, indices)
+}
+
+// NewHDKeychainFromIndices returns a new HDKeychain holding the keys of
+// [mnemonic] at the given [indices].
+func NewHDKeychainFromIndices(mnemonic, passphrase string, indices []uint32) (*HDKeychain, error) {
+	if len(indices) == 0 {
+		return nil, keychain.ErrInvalidIndicesLength
+	}
+
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMnemonic, err)
+	}
+	key, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range []uint32{
+		bip32.FirstHardenedChild + bip44Purpose,
+		bip32.FirstHardenedChild + avaxCoinType,
+		bip32.FirstHardenedChild, // account
+		0,                        // external chain
+	} {
+		key, err = key.NewChildKey(index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	kc := &HDKeychain{
+		Keychain:      NewKeychain(),
+		externalChain: key,
+		addrToIndex:   make(map[ids.ShortID]uint32),
+	}
+	if _, err := kc.Derive(indices...); err != nil {
+		return nil, err
+	}
+	return kc, nil
+}
+
+// Derive adds the keys at [indices] to the keychain and returns their
+// addresses.
+func (kc *HDKeychain) Derive(indices ...uint32) ([]ids.ShortID, error) {
+	addrs := make([]ids.ShortID, len(indices))
+	for i, index := range indices {
+		key, err := kc.externalChain.NewChildKey(index)
+		if err != nil {
+			return nil, err
+		}
+		sk, err := secp256k1.ToPrivateKey(key.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		addr := sk.Address()
+		kc.Add(sk)
+		kc.addrToIndex[addr] = index
+		addrs[i] = addr
+	}
+	return addrs, nil
+}
+
+// Index returns the index of the path that the key of [addr] was derived
+// along.
+func (kc *HDKeychain) Index(addr ids.ShortID) (uint32, bool) {
+	index, ok := kc.addrToIndex[addr]
+	return index, ok
+}
@@ -0,0 +1,180 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+
+	bip32 "github.com/tyler-smith/go-bip32"
+	bip39 "github.com/tyler-smith/go-bip39"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestNewHDKeychain(t *testing.T) {
+	require := require.New(t)
+
+	kc, err := NewHDKeychain(testMnemonic, "", 3)
+	require.NoError(err)
+	require.Equal(3, kc.Addresses().Len())
+	require.Equal(3, kc.EthAddresses().Len())
+
+	// Deriving the same mnemonic again must produce the same addresses.
+	other, err := NewHDKeychainFromIndices(testMnemonic, "", []uint32{2, 0, 1})
+	require.NoError(err)
+	require.Equal(kc.Addresses(), other.Addresses())
+
+	// The passphrase changes the derived keys.
+	other, err = NewHDKeychain(testMnemonic, "passphrase", 3)
+	require.NoError(err)
+	kcAddrs := kc.Addresses()
+	require.False(kcAddrs.Overlaps(other.Addresses()))
+
+	for addr := range kc.Addresses() {
+		index, ok := kc.Index(addr)
+		require.True(ok)
+		require.Less(index, uint32(3))
+
+		signer, ok := kc.Get(addr)
+		require.True(ok)
+		require.Equal(addr, signer.Address())
+	}
+}
+
+// The addresses of the first indices are fixed vectors at m/44'/9000'/0'/0/i,
+// which were derived independently of the BIP-32 library used by the
+// keychain. The same derivation gives the well known Bitcoin address
+// 1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA at m/44'/0'/0'/0/0.
+func TestHDKeychainKnownAddresses(t *testing.T) {
+	require := require.New(t)
+
+	kc, err := NewHDKeychain(testMnemonic, "", 2)
+	require.NoError(err)
+
+	expectedAddrs := [][]string{
+		{
+			"X-avax1p9575chzhvcwvmvzaqh7yeld76r3af0ha56phl",
+			"P-avax1p9575chzhvcwvmvzaqh7yeld76r3af0ha56phl",
+		},
+		{
+			"X-avax1saceyycp6klllavjmt5xd9dxzk7mffzp6fzwtu",
+			"P-avax1saceyycp6klllavjmt5xd9dxzk7mffzp6fzwtu",
+		},
+	}
+	for expectedIndex, addrStrs := range expectedAddrs {
+		for _, addrStr := range addrStrs {
+			addr, err := address.ParseToID(addrStr)
+			require.NoError(err)
+
+			index, ok := kc.Index(addr)
+			require.True(ok, addrStr)
+			require.Equal(uint32(expectedIndex), index)
+		}
+	}
+}
+
+// Addresses must match the ones derived by the ledger, which derives them from
+// the extended public key at m/44'/9000'/0'.
+func TestHDKeychainMatchesPublicDerivation(t *testing.T) {
+	require := require.New(t)
+
+	key, err := bip32.NewMasterKey(bip39.NewSeed(testMnemonic, ""))
+	require.NoError(err)
+	for _, index := range []uint32{
+		bip32.FirstHardenedChild + 44,
+		bip32.FirstHardenedChild + 9000,
+		bip32.FirstHardenedChild,
+	} {
+		key, err = key.NewChildKey(index)
+		require.NoError(err)
+	}
+	externalChain, err := key.PublicKey().NewChildKey(0)
+	require.NoError(err)
+
+	indices := []uint32{0, 1, 5, 1000}
+	kc, err := NewHDKeychainFromIndices(testMnemonic, "", indices)
+	require.NoError(err)
+
+	for _, index := range indices {
+		child, err := externalChain.NewChildKey(index)
+		require.NoError(err)
+
+		addr, err := ids.ToShortID(hashing.PubkeyBytesToAddress(child.Key))
+		require.NoError(err)
+
+		derivedIndex, ok := kc.Index(addr)
+		require.True(ok)
+		require.Equal(index, derivedIndex)
+	}
+}
+
+func TestHDKeychainDerive(t *testing.T) {
+	require := require.New(t)
+
+	kc, err := NewHDKeychain(testMnemonic, "", 1)
+	require.NoError(err)
+
+	addrs, err := kc.Derive(1, 2)
+	require.NoError(err)
+	require.Len(addrs, 2)
+	require.Equal(3, kc.Addresses().Len())
+
+	// Deriving an index again doesn't add a new key.
+	again, err := kc.Derive(1)
+	require.NoError(err)
+	require.Equal(addrs[:1], again)
+	require.Equal(3, kc.Addresses().Len())
+}
+
+func TestNewHDKeychainErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		mnemonic    string
+		numToDerive int
+		expectedErr error
+	}{
+		{
+			name:        "no addresses",
+			mnemonic:    testMnemonic,
+			numToDerive: 0,
+			expectedErr: keychain.ErrInvalidNumAddrsToDerive,
+		},
+		{
+			name:        "invalid checksum",
+			mnemonic:    "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+			numToDerive: 1,
+			expectedErr: ErrInvalidMnemonic,
+		},
+		{
+			name:        "unknown word",
+			mnemonic:    "avalanche abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			numToDerive: 1,
+			expectedErr: ErrInvalidMnemonic,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewHDKeychain(test.mnemonic, "", test.numToDerive)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestNewMnemonic(t *testing.T) {
+	require := require.New(t)
+
+	mnemonic, err := NewMnemonic()
+	require.NoError(err)
+	require.True(bip39.IsMnemonicValid(mnemonic))
+
+	_, err = NewHDKeychain(mnemonic, "", 1)
+	require.NoError(err)
+}
@@ -71,3 +71,14 @@ func (vm *blockVM) GetStateSummary(ctx context.Context, height uint64) (block.St
 
 	return vm.ssVM.GetStateSummary(ctx, height)
 }
+
+func (vm *blockVM) StateSyncProgress(ctx context.Context) (block.StateSyncProgress, error) {
+	if vm.progressVM == nil {
+		return block.StateSyncProgress{}, block.ErrStateSyncProgressNotImplemented
+	}
+
+	ctx, span := vm.tracer.Start(ctx, vm.stateSyncProgressTag)
+	defer span.End()
+
+	return vm.progressVM.StateSyncProgress(ctx)
+}
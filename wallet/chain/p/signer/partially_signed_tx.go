@@ -0,0 +1,274 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+
+	stdcontext "context"
+)
+
+var (
+	_ Backend = (*recordingBackend)(nil)
+	_ Backend = (*partiallySignedTxBackend)(nil)
+
+	ErrMissingUTXO = errors.New("missing UTXO")
+	ErrNilTx       = errors.New("nil tx")
+)
+
+// PartiallySignedTx is a tx whose signatures are collected from multiple
+// parties, which may be offline.
+//
+// It includes everything needed to sign the tx, so it can be signed without
+// access to the network.
+type PartiallySignedTx struct {
+	// Tx is the tx being signed. Its credentials hold the signatures that were
+	// collected so far. Signatures that are still missing are empty.
+	Tx *txs.Tx `serialize:"true"`
+	// UTXOs consumed by Tx.
+	UTXOs []*avax.UTXO `serialize:"true"`
+	// SubnetOwners are the owners of the subnets that Tx must be authorized
+	// by.
+	SubnetOwners map[ids.ID]fx.Owner `serialize:"true"`
+	// Signers are, for each credential of Tx, the addresses that must provide
+	// each of its signatures. They are derived from the signature indices of
+	// Tx, which are typically chosen by [common.MatchOwners].
+	Signers [][]ids.ShortID `serialize:"true"`
+}
+
+// NewPartiallySignedTx returns a PartiallySignedTx of [utx] without any
+// signatures.
+//
+// [backend] must provide every UTXO consumed by [utx] and the owner of every
+// subnet that [utx] must be authorized by.
+func NewPartiallySignedTx(
+	ctx stdcontext.Context,
+	backend Backend,
+	utx txs.UnsignedTx,
+) (*PartiallySignedTx, error) {
+	recorder := &recordingBackend{
+		backend:      backend,
+		subnetOwners: make(map[ids.ID]fx.Owner),
+	}
+	signers, err := getSigners(ctx, recorder, utx)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &PartiallySignedTx{
+		Tx:           &txs.Tx{Unsigned: utx},
+		UTXOs:        recorder.utxos,
+		SubnetOwners: recorder.subnetOwners,
+		Signers:      signers,
+	}
+	// Signing without any keys populates the credentials with empty
+	// signatures.
+	return p, p.Sign(ctx, secp256k1fx.NewKeychain())
+}
+
+// ParsePartiallySignedTx parses and verifies a PartiallySignedTx previously
+// serialized with Bytes.
+func ParsePartiallySignedTx(b []byte) (*PartiallySignedTx, error) {
+	p := &PartiallySignedTx{}
+	if _, err := txs.Codec.Unmarshal(b, p); err != nil {
+		return nil, fmt.Errorf("couldn't parse partially signed tx: %w", err)
+	}
+	if p.Tx == nil {
+		return nil, ErrNilTx
+	}
+	if err := p.Tx.Initialize(txs.Codec); err != nil {
+		return nil, err
+	}
+	return p, p.Verify(stdcontext.Background())
+}
+
+// Bytes returns the serialized representation of the PartiallySignedTx.
+func (p *PartiallySignedTx) Bytes() ([]byte, error) {
+	return txs.Codec.Marshal(txs.CodecVersion, p)
+}
+
+// Verify that the signers match the signature indices of the tx and that every
+// collected signature was produced by the signer of its slot.
+func (p *PartiallySignedTx) Verify(ctx stdcontext.Context) error {
+	signers, err := getSigners(ctx, &partiallySignedTxBackend{p: p}, p.Tx.Unsigned)
+	if err != nil {
+		return err
+	}
+	if err := common.EqualSigners(signers, p.Signers); err != nil {
+		return err
+	}
+
+	creds, err := credentials(p.Tx)
+	if err != nil {
+		return err
+	}
+	return common.VerifySignatures(p.Tx.Unsigned.Bytes(), p.Signers, creds, false /*=complete*/)
+}
+
+// Sign adds the missing signatures that [kc] is able to provide.
+func (p *PartiallySignedTx) Sign(ctx stdcontext.Context, kc keychain.Keychain) error {
+	return New(kc, &partiallySignedTxBackend{p: p}).Sign(ctx, p.Tx)
+}
+
+// Merge adds the signatures collected by [other] to this PartiallySignedTx.
+//
+// [other] must be a PartiallySignedTx of the same tx. Every signature of
+// [other] must be produced by the signer of its slot, and must not conflict
+// with a signature that was already collected.
+func (p *PartiallySignedTx) Merge(other *PartiallySignedTx) error {
+	if !bytes.Equal(p.Tx.Unsigned.Bytes(), other.Tx.Unsigned.Bytes()) {
+		return fmt.Errorf("%w: %s", common.ErrMismatchedTx, other.Tx.ID())
+	}
+	if err := common.EqualSigners(p.Signers, other.Signers); err != nil {
+		return err
+	}
+
+	otherCreds, err := credentials(other.Tx)
+	if err != nil {
+		return err
+	}
+	unsignedBytes := p.Tx.Unsigned.Bytes()
+	if err := common.VerifySignatures(unsignedBytes, p.Signers, otherCreds, false /*=complete*/); err != nil {
+		return err
+	}
+
+	creds, err := credentials(p.Tx)
+	if err != nil {
+		return err
+	}
+	if err := common.MergeSignatures(creds, otherCreds); err != nil {
+		return err
+	}
+	return p.Tx.Initialize(txs.Codec)
+}
+
+// MissingSigners returns the addresses whose signatures are still missing.
+func (p *PartiallySignedTx) MissingSigners() (set.Set[ids.ShortID], error) {
+	creds, err := credentials(p.Tx)
+	if err != nil {
+		return nil, err
+	}
+	return common.MissingSigners(p.Signers, creds), nil
+}
+
+// Finalize returns the signed tx once every signature has been collected.
+func (p *PartiallySignedTx) Finalize() (*txs.Tx, error) {
+	creds, err := credentials(p.Tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := common.VerifySignatures(p.Tx.Unsigned.Bytes(), p.Signers, creds, true /*=complete*/); err != nil {
+		return nil, err
+	}
+	return p.Tx, nil
+}
+
+// partiallySignedTxBackend provides the UTXOs and subnet owners included in
+// [p].
+type partiallySignedTxBackend struct {
+	p *PartiallySignedTx
+}
+
+func (b *partiallySignedTxBackend) GetUTXO(_ stdcontext.Context, _, utxoID ids.ID) (*avax.UTXO, error) {
+	for _, utxo := range b.p.UTXOs {
+		if utxo.InputID() == utxoID {
+			return utxo, nil
+		}
+	}
+	return nil, database.ErrNotFound
+}
+
+func (b *partiallySignedTxBackend) GetSubnetOwner(_ stdcontext.Context, subnetID ids.ID) (fx.Owner, error) {
+	owner, ok := b.p.SubnetOwners[subnetID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return owner, nil
+}
+
+// getSigners returns the addresses that must sign each credential of [utx].
+func getSigners(
+	ctx stdcontext.Context,
+	backend Backend,
+	utx txs.UnsignedTx,
+) ([][]ids.ShortID, error) {
+	var signers [][]keychain.Signer
+	err := utx.Visit(&visitor{
+		kc:      common.SignersKeychain{},
+		backend: backend,
+		ctx:     ctx,
+		tx:      &txs.Tx{Unsigned: utx},
+		signers: &signers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := common.SignerAddresses(signers)
+	for credIndex, credAddrs := range addrs {
+		for sigIndex, addr := range credAddrs {
+			if addr == ids.ShortEmpty {
+				return nil, fmt.Errorf("%w: signature %d of credential %d",
+					ErrMissingUTXO,
+					sigIndex,
+					credIndex,
+				)
+			}
+		}
+	}
+	return addrs, nil
+}
+
+func credentials(tx *txs.Tx) ([]*secp256k1fx.Credential, error) {
+	creds := make([]*secp256k1fx.Credential, len(tx.Creds))
+	for i, credIntf := range tx.Creds {
+		cred, ok := credIntf.(*secp256k1fx.Credential)
+		if !ok {
+			return nil, ErrUnknownCredentialType
+		}
+		creds[i] = cred
+	}
+	return creds, nil
+}
+
+// recordingBackend records the UTXOs and subnet owners that are fetched from
+// [backend].
+type recordingBackend struct {
+	backend      Backend
+	utxos        []*avax.UTXO
+	subnetOwners map[ids.ID]fx.Owner
+}
+
+func (b *recordingBackend) GetUTXO(ctx stdcontext.Context, chainID, utxoID ids.ID) (*avax.UTXO, error) {
+	utxo, err := b.backend.GetUTXO(ctx, chainID, utxoID)
+	if err == database.ErrNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrMissingUTXO, utxoID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	b.utxos = append(b.utxos, utxo)
+	return utxo, nil
+}
+
+func (b *recordingBackend) GetSubnetOwner(ctx stdcontext.Context, subnetID ids.ID) (fx.Owner, error) {
+	owner, err := b.backend.GetSubnetOwner(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	b.subnetOwners[subnetID] = owner
+	return owner, nil
+}
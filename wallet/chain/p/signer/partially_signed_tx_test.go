@@ -0,0 +1,176 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+)
+
+var _ Backend = (*testBackend)(nil)
+
+type testBackend struct {
+	utxos map[ids.ID]*avax.UTXO
+}
+
+func (b *testBackend) GetUTXO(_ context.Context, _, utxoID ids.ID) (*avax.UTXO, error) {
+	utxo, ok := b.utxos[utxoID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return utxo, nil
+}
+
+func (*testBackend) GetSubnetOwner(context.Context, ids.ID) (fx.Owner, error) {
+	return nil, database.ErrNotFound
+}
+
+func TestPartiallySignedTx(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		ctx   = context.Background()
+		keys  = secp256k1.TestKeys()[:3]
+		addrs = []ids.ShortID{
+			keys[0].Address(),
+			keys[1].Address(),
+			keys[2].Address(),
+		}
+		owners = &secp256k1fx.OutputOwners{
+			Threshold: 2,
+			Addrs:     addrs,
+		}
+		assetID = ids.GenerateTestID()
+		utxo    = &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          units.Avax,
+				OutputOwners: *owners,
+			},
+		}
+		backend = &testBackend{
+			utxos: map[ids.ID]*avax.UTXO{
+				utxo.InputID(): utxo,
+			},
+		}
+	)
+
+	sigIndices, ok := common.MatchOwners(owners, set.Of(addrs...), 0)
+	require.True(ok)
+
+	newTx := func(memo string) txs.UnsignedTx {
+		return &txs.BaseTx{
+			BaseTx: avax.BaseTx{
+				NetworkID:    constants.UnitTestID,
+				BlockchainID: constants.PlatformChainID,
+				Ins: []*avax.TransferableInput{{
+					UTXOID: utxo.UTXOID,
+					Asset:  utxo.Asset,
+					In: &secp256k1fx.TransferInput{
+						Amt: units.Avax,
+						Input: secp256k1fx.Input{
+							SigIndices: sigIndices,
+						},
+					},
+				}},
+				Memo: []byte(memo),
+			},
+		}
+	}
+
+	pst, err := NewPartiallySignedTx(ctx, backend, newTx(""))
+	require.NoError(err)
+	require.Equal([][]ids.ShortID{{addrs[0], addrs[1]}}, pst.Signers)
+	require.Equal([]*avax.UTXO{utxo}, pst.UTXOs)
+
+	missing, err := pst.MissingSigners()
+	require.NoError(err)
+	require.Equal(set.Of(addrs[0], addrs[1]), missing)
+
+	_, err = pst.Finalize()
+	require.ErrorIs(err, common.ErrMissingSignature)
+
+	// Each signer signs their own copy of the tx.
+	pstBytes, err := pst.Bytes()
+	require.NoError(err)
+	signed := make([]*PartiallySignedTx, len(keys))
+	for i, key := range keys {
+		signed[i], err = ParsePartiallySignedTx(pstBytes)
+		require.NoError(err)
+		require.NoError(signed[i].Sign(ctx, secp256k1fx.NewKeychain(key)))
+
+		signedBytes, err := signed[i].Bytes()
+		require.NoError(err)
+		signed[i], err = ParsePartiallySignedTx(signedBytes)
+		require.NoError(err)
+	}
+
+	// The third key isn't required, so it doesn't sign anything.
+	missing, err = signed[2].MissingSigners()
+	require.NoError(err)
+	require.Equal(set.Of(addrs[0], addrs[1]), missing)
+
+	require.NoError(pst.Merge(signed[0]))
+	missing, err = pst.MissingSigners()
+	require.NoError(err)
+	require.Equal(set.Of(addrs[1]), missing)
+
+	// Merging the same signatures again has no effect.
+	require.NoError(pst.Merge(signed[0]))
+	require.NoError(pst.Merge(signed[2]))
+	require.NoError(pst.Merge(signed[1]))
+
+	tx, err := pst.Finalize()
+	require.NoError(err)
+
+	expectedTx, err := txs.NewSigned(newTx(""), txs.Codec, [][]*secp256k1.PrivateKey{{keys[0], keys[1]}})
+	require.NoError(err)
+	require.Equal(expectedTx.Bytes(), tx.Bytes())
+
+	// Signatures of a different tx are rejected.
+	otherPST, err := NewPartiallySignedTx(ctx, backend, newTx("other"))
+	require.NoError(err)
+	err = pst.Merge(otherPST)
+	require.ErrorIs(err, common.ErrMismatchedTx)
+
+	// Signatures in the wrong slot are rejected.
+	mismatched, err := ParsePartiallySignedTx(pstBytes)
+	require.NoError(err)
+	mismatched.Tx.Creds[0].(*secp256k1fx.Credential).Sigs[0] = tx.Creds[0].(*secp256k1fx.Credential).Sigs[1]
+	err = signed[1].Merge(mismatched)
+	require.ErrorIs(err, common.ErrMismatchedSignature)
+
+	mismatchedBytes, err := mismatched.Bytes()
+	require.NoError(err)
+	_, err = ParsePartiallySignedTx(mismatchedBytes)
+	require.ErrorIs(err, common.ErrMismatchedSignature)
+
+	// Signers that don't match the signature indices are rejected.
+	mismatched, err = ParsePartiallySignedTx(pstBytes)
+	require.NoError(err)
+	mismatched.Signers[0][0], mismatched.Signers[0][1] = mismatched.Signers[0][1], mismatched.Signers[0][0]
+	mismatchedBytes, err = mismatched.Bytes()
+	require.NoError(err)
+	_, err = ParsePartiallySignedTx(mismatchedBytes)
+	require.ErrorIs(err, common.ErrMismatchedSigners)
+
+	// The UTXOs must be available to create a partially signed tx.
+	_, err = NewPartiallySignedTx(ctx, &testBackend{}, newTx(""))
+	require.ErrorIs(err, ErrMissingUTXO)
+}
@@ -41,6 +41,10 @@ type visitor struct {
 	backend Backend
 	ctx     context.Context
 	tx      *txs.Tx
+
+	// If non-nil, [signers] is populated with the signers of each credential
+	// rather than signing [tx].
+	signers *[][]keychain.Signer
 }
 
 func (s *visitor) BaseTx(tx *txs.BaseTx) error {
@@ -48,7 +52,7 @@ func (s *visitor) BaseTx(tx *txs.BaseTx) error {
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, txCreds, txSigners)
+	return s.sign(txCreds, txSigners)
 }
 
 func (s *visitor) CreateAssetTx(tx *txs.CreateAssetTx) error {
@@ -56,7 +60,7 @@ func (s *visitor) CreateAssetTx(tx *txs.CreateAssetTx) error {
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, txCreds, txSigners)
+	return s.sign(txCreds, txSigners)
 }
 
 func (s *visitor) OperationTx(tx *txs.OperationTx) error {
@@ -70,7 +74,7 @@ func (s *visitor) OperationTx(tx *txs.OperationTx) error {
 	}
 	txCreds = append(txCreds, txOpsCreds...)
 	txSigners = append(txSigners, txOpsSigners...)
-	return sign(s.tx, txCreds, txSigners)
+	return s.sign(txCreds, txSigners)
 }
 
 func (s *visitor) ImportTx(tx *txs.ImportTx) error {
@@ -84,7 +88,7 @@ func (s *visitor) ImportTx(tx *txs.ImportTx) error {
 	}
 	txCreds = append(txCreds, txImportCreds...)
 	txSigners = append(txSigners, txImportSigners...)
-	return sign(s.tx, txCreds, txSigners)
+	return s.sign(txCreds, txSigners)
 }
 
 func (s *visitor) ExportTx(tx *txs.ExportTx) error {
@@ -92,7 +96,7 @@ func (s *visitor) ExportTx(tx *txs.ExportTx) error {
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, txCreds, txSigners)
+	return s.sign(txCreds, txSigners)
 }
 
 func (s *visitor) getSigners(ctx context.Context, sourceChainID ids.ID, ins []*avax.TransferableInput) ([]verify.Verifiable, [][]keychain.Signer, error) {
@@ -218,6 +222,14 @@ func (s *visitor) getOpsSigners(ctx context.Context, sourceChainID ids.ID, ops [
 	return txCreds, txSigners, nil
 }
 
+func (s *visitor) sign(creds []verify.Verifiable, txSigners [][]keychain.Signer) error {
+	if s.signers != nil {
+		*s.signers = txSigners
+		return nil
+	}
+	return sign(s.tx, creds, txSigners)
+}
+
 func sign(tx *txs.Tx, creds []verify.Verifiable, txSigners [][]keychain.Signer) error {
 	codec := builder.Parser.Codec()
 	unsignedBytes, err := codec.Marshal(txs.CodecVersion, &tx.Unsigned)
@@ -0,0 +1,201 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/x"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	psigner "github.com/ava-labs/avalanchego/wallet/chain/p/signer"
+	pwallet "github.com/ava-labs/avalanchego/wallet/chain/p/wallet"
+	xbuilder "github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+	xsigner "github.com/ava-labs/avalanchego/wallet/chain/x/signer"
+)
+
+const (
+	uriKey       = "uri"
+	chainKey     = "chain"
+	ownersKey    = "owners"
+	thresholdKey = "threshold"
+	signersKey   = "signers"
+	toKey        = "to"
+	amountKey    = "amount"
+)
+
+var (
+	errInvalidThreshold = errors.New("invalid threshold")
+	errInvalidSigners   = errors.New("invalid signers")
+)
+
+func createCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "create <out>",
+		Short: "Creates a partially signed tx that sends AVAX from a multisig owner",
+		Long:  "Creates a partially signed tx that sends AVAX from the UTXOs owned by the multisig owner. The change is sent back to the same owner.",
+		Args:  cobra.ExactArgs(1),
+	}
+	flags := c.Flags()
+	flags.String(uriKey, primary.LocalAPIURI, "API URI to fetch the UTXOs from")
+	flags.String(chainKey, pChain, "Chain to create the tx on, either P or X")
+	flags.StringSlice(ownersKey, nil, "Addresses of the multisig owner")
+	flags.Uint32(thresholdKey, 1, "Number of owners that must sign")
+	flags.StringSlice(signersKey, nil, "Addresses of the owners that will sign, exactly threshold of them (default the first threshold owners in sorted order)")
+	flags.String(toKey, "", "Address to send the AVAX to")
+	flags.Uint64(amountKey, 0, "Amount of nAVAX to send")
+
+	c.RunE = func(c *cobra.Command, args []string) error {
+		uri, err := flags.GetString(uriKey)
+		if err != nil {
+			return err
+		}
+		chain, err := flags.GetString(chainKey)
+		if err != nil {
+			return err
+		}
+		ownerStrs, err := flags.GetStringSlice(ownersKey)
+		if err != nil {
+			return err
+		}
+		threshold, err := flags.GetUint32(thresholdKey)
+		if err != nil {
+			return err
+		}
+		signerStrs, err := flags.GetStringSlice(signersKey)
+		if err != nil {
+			return err
+		}
+		toStr, err := flags.GetString(toKey)
+		if err != nil {
+			return err
+		}
+		amount, err := flags.GetUint64(amountKey)
+		if err != nil {
+			return err
+		}
+
+		ownerAddrs, err := address.ParseToIDs(ownerStrs)
+		if err != nil {
+			return err
+		}
+		if threshold == 0 || int(threshold) > len(ownerAddrs) {
+			return fmt.Errorf("%w: %d of %d owners", errInvalidThreshold, threshold, len(ownerAddrs))
+		}
+		owners := &secp256k1fx.OutputOwners{
+			Threshold: threshold,
+			Addrs:     ownerAddrs,
+		}
+		owners.Sort()
+
+		ownerSet := set.Of(ownerAddrs...)
+		signers := ownerSet
+		if len(signerStrs) > 0 {
+			signerAddrs, err := address.ParseToIDs(signerStrs)
+			if err != nil {
+				return err
+			}
+			signers = set.Of(signerAddrs...)
+			if signers.Len() != int(threshold) {
+				return fmt.Errorf("%w: %d signers but the threshold is %d", errInvalidSigners, signers.Len(), threshold)
+			}
+			for _, signer := range signerAddrs {
+				if !ownerSet.Contains(signer) {
+					return fmt.Errorf("%w: %s isn't an owner", errInvalidSigners, signer)
+				}
+			}
+		}
+
+		to, err := address.ParseToID(toStr)
+		if err != nil {
+			return err
+		}
+
+		ctx := c.Context()
+		state, err := primary.FetchState(ctx, uri, ownerSet)
+		if err != nil {
+			return err
+		}
+
+		// The builder selects the inputs' signers from the addresses it's given,
+		// so only the chosen signers are given to it.
+		chain = strings.ToUpper(chain)
+		var (
+			tx      partiallySignedTx
+			options = []common.Option{
+				common.WithChangeOwner(owners),
+			}
+		)
+		switch chain {
+		case pChain:
+			chainID := constants.PlatformChainID
+			backend := pwallet.NewBackend(
+				state.PCTX,
+				common.NewChainUTXOs(chainID, state.UTXOs),
+				nil,
+			)
+			builder := pbuilder.New(signers, state.PCTX, backend)
+			utx, err := builder.NewBaseTx(
+				transferOutputs(state.PCTX.AVAXAssetID, amount, to),
+				options...,
+			)
+			if err != nil {
+				return err
+			}
+			tx, err = psigner.NewPartiallySignedTx(ctx, backend, utx)
+			if err != nil {
+				return err
+			}
+		case xChain:
+			chainID := state.XCTX.BlockchainID
+			backend := x.NewBackend(
+				state.XCTX,
+				common.NewChainUTXOs(chainID, state.UTXOs),
+			)
+			builder := xbuilder.New(signers, state.XCTX, backend)
+			utx, err := builder.NewBaseTx(
+				transferOutputs(state.XCTX.AVAXAssetID, amount, to),
+				options...,
+			)
+			if err != nil {
+				return err
+			}
+			tx, err = xsigner.NewPartiallySignedTx(ctx, backend, utx)
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w %q", errUnknownChain, chain)
+		}
+		return writeTx(args[0], chain, tx)
+	}
+	return c
+}
+
+func transferOutputs(assetID ids.ID, amount uint64, to ids.ShortID) []*avax.TransferableOutput {
+	return []*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs: []ids.ShortID{
+					to,
+				},
+			},
+		},
+	}}
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	psigner "github.com/ava-labs/avalanchego/wallet/chain/p/signer"
+	xsigner "github.com/ava-labs/avalanchego/wallet/chain/x/signer"
+)
+
+const (
+	pChain = "P"
+	xChain = "X"
+)
+
+var (
+	_ partiallySignedTx = (*psigner.PartiallySignedTx)(nil)
+	_ partiallySignedTx = (*xsigner.PartiallySignedTx)(nil)
+
+	errUnknownChain = errors.New("unknown chain")
+)
+
+type partiallySignedTx interface {
+	Sign(ctx context.Context, kc keychain.Keychain) error
+	MissingSigners() (set.Set[ids.ShortID], error)
+	Bytes() ([]byte, error)
+}
+
+// txFile is the format partially signed txs are stored in.
+type txFile struct {
+	Chain             string `json:"chain"`
+	PartiallySignedTx string `json:"partiallySignedTx"`
+}
+
+func readTx(path string) (string, partiallySignedTx, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var f txFile
+	if err := json.Unmarshal(fileBytes, &f); err != nil {
+		return "", nil, fmt.Errorf("couldn't parse %s: %w", path, err)
+	}
+
+	txBytes, err := formatting.Decode(formatting.Hex, f.PartiallySignedTx)
+	if err != nil {
+		return "", nil, fmt.Errorf("couldn't decode %s: %w", path, err)
+	}
+
+	var tx partiallySignedTx
+	switch f.Chain {
+	case pChain:
+		tx, err = psigner.ParsePartiallySignedTx(txBytes)
+	case xChain:
+		tx, err = xsigner.ParsePartiallySignedTx(txBytes)
+	default:
+		return "", nil, fmt.Errorf("%w %q in %s", errUnknownChain, f.Chain, path)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid partially signed tx in %s: %w", path, err)
+	}
+	return f.Chain, tx, nil
+}
+
+func writeTx(path string, chain string, tx partiallySignedTx) error {
+	txBytes, err := tx.Bytes()
+	if err != nil {
+		return err
+	}
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return err
+	}
+	fileBytes, err := json.MarshalIndent(txFile{
+		Chain:             chain,
+		PartiallySignedTx: txStr,
+	}, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, fileBytes, 0o600); err != nil {
+		return err
+	}
+
+	missing, err := tx.MissingSigners()
+	if err != nil {
+		return err
+	}
+	if missing.Len() == 0 {
+		log.Printf("wrote %s, which has every required signature\n", path)
+		return nil
+	}
+	log.Printf("wrote %s, which is missing signatures from %s\n", path, missing)
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+
+	psigner "github.com/ava-labs/avalanchego/wallet/chain/p/signer"
+	xsigner "github.com/ava-labs/avalanchego/wallet/chain/x/signer"
+)
+
+func finalizeCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "finalize <in>",
+		Short: "Prints, and optionally issues, a tx that has every required signature",
+		Args:  cobra.ExactArgs(1),
+	}
+	flags := c.Flags()
+	flags.String(uriKey, "", "API URI to issue the tx to. If empty, the tx is only printed")
+
+	c.RunE = func(c *cobra.Command, args []string) error {
+		uri, err := flags.GetString(uriKey)
+		if err != nil {
+			return err
+		}
+
+		_, tx, err := readTx(args[0])
+		if err != nil {
+			return err
+		}
+
+		var (
+			txID    ids.ID
+			txBytes []byte
+			issue   func([]byte) (ids.ID, error)
+		)
+		switch tx := tx.(type) {
+		case *psigner.PartiallySignedTx:
+			signedTx, err := tx.Finalize()
+			if err != nil {
+				return err
+			}
+			txID, txBytes = signedTx.ID(), signedTx.Bytes()
+			issue = func(b []byte) (ids.ID, error) {
+				return platformvm.NewClient(uri).IssueTx(c.Context(), b)
+			}
+		case *xsigner.PartiallySignedTx:
+			signedTx, err := tx.Finalize()
+			if err != nil {
+				return err
+			}
+			txID, txBytes = signedTx.ID(), signedTx.Bytes()
+			issue = func(b []byte) (ids.ID, error) {
+				return avm.NewClient(uri, "X").IssueTx(c.Context(), b)
+			}
+		}
+
+		txStr, err := formatting.Encode(formatting.Hex, txBytes)
+		if err != nil {
+			return err
+		}
+		log.Printf("signed tx %s: %s\n", txID, txStr)
+		if uri == "" {
+			return nil
+		}
+
+		if _, err := issue(txBytes); err != nil {
+			return err
+		}
+		log.Printf("issued tx %s\n", txID)
+		return nil
+	}
+	return c
+}
@@ -0,0 +1,37 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// multisig collects the signatures of P-chain and X-chain txs spending UTXOs
+// that are owned by multiple addresses, whose keys may be held by different
+// people on different machines.
+//
+// A partially signed tx is created with the create command, passed to each
+// signer to be signed with the sign command, combined with the merge command,
+// and issued with the finalize command. Only the create and finalize commands
+// need access to a node.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	cmd := &cobra.Command{
+		Use:   "multisig",
+		Short: "Collects the signatures of multisig P-chain and X-chain txs",
+	}
+	cmd.AddCommand(
+		createCommand(),
+		signCommand(),
+		mergeCommand(),
+		finalizeCommand(),
+	)
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		fmt.Fprintf(os.Stderr, "command failed %v\n", err)
+		os.Exit(1)
+	}
+}
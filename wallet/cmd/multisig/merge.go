@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	psigner "github.com/ava-labs/avalanchego/wallet/chain/p/signer"
+	xsigner "github.com/ava-labs/avalanchego/wallet/chain/x/signer"
+)
+
+var errMismatchedChains = errors.New("mismatched chains")
+
+func mergeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "merge <out> <in>...",
+		Short: "Combines the signatures of partially signed copies of the same tx",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			chain, merged, err := readTx(args[1])
+			if err != nil {
+				return err
+			}
+
+			for _, path := range args[2:] {
+				otherChain, other, err := readTx(path)
+				if err != nil {
+					return err
+				}
+				if otherChain != chain {
+					return fmt.Errorf("%w: %s is for the %s-chain but expected the %s-chain",
+						errMismatchedChains,
+						path,
+						otherChain,
+						chain,
+					)
+				}
+
+				switch merged := merged.(type) {
+				case *psigner.PartiallySignedTx:
+					err = merged.Merge(other.(*psigner.PartiallySignedTx))
+				case *xsigner.PartiallySignedTx:
+					err = merged.Merge(other.(*xsigner.PartiallySignedTx))
+				}
+				if err != nil {
+					return fmt.Errorf("couldn't merge %s: %w", path, err)
+				}
+			}
+			return writeTx(args[0], chain, merged)
+		},
+	}
+}
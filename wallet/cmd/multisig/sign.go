@@ -0,0 +1,40 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const privateKeyEnvVar = "MULTISIG_PRIVATE_KEY"
+
+func signCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "sign <in> <out>",
+		Short: "Adds the signatures of a private key to a partially signed tx",
+		Long:  "Adds the signatures of the private key provided by the " + privateKeyEnvVar + " environment variable to a partially signed tx. Doesn't require access to the network.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(c *cobra.Command, args []string) error {
+			chain, tx, err := readTx(args[0])
+			if err != nil {
+				return err
+			}
+
+			key := &secp256k1.PrivateKey{}
+			if err := key.UnmarshalText([]byte(os.Getenv(privateKeyEnvVar))); err != nil {
+				return err
+			}
+			if err := tx.Sign(c.Context(), secp256k1fx.NewKeychain(key)); err != nil {
+				return err
+			}
+			return writeTx(args[1], chain, tx)
+		},
+	}
+	return c
+}
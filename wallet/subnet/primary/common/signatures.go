@@ -0,0 +1,236 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	_ keychain.Keychain = SignersKeychain{}
+	_ keychain.Signer   = addressSigner{}
+
+	ErrMismatchedTx            = errors.New("mismatched tx")
+	ErrMismatchedSigners       = errors.New("mismatched signers")
+	ErrWrongNumberOfSignatures = errors.New("wrong number of signatures")
+	ErrMismatchedSignature     = errors.New("signature doesn't match the required signer")
+	ErrDuplicateSignature      = errors.New("duplicate signature")
+	ErrMissingSignature        = errors.New("missing signature")
+
+	errCantSign = errors.New("can't sign")
+
+	emptySig [secp256k1.SignatureLen]byte
+)
+
+// VerifySignatures verifies that every signature in [creds] was produced over
+// [unsignedBytes] by the address that [signers] requires for its slot.
+//
+// If [complete] is false, missing signatures are allowed.
+func VerifySignatures(
+	unsignedBytes []byte,
+	signers [][]ids.ShortID,
+	creds []*secp256k1fx.Credential,
+	complete bool,
+) error {
+	if len(creds) != len(signers) {
+		return fmt.Errorf("%w: expected %d credentials but got %d",
+			ErrWrongNumberOfSignatures,
+			len(signers),
+			len(creds),
+		)
+	}
+
+	unsignedHash := hashing.ComputeHash256(unsignedBytes)
+	for credIndex, cred := range creds {
+		credSigners := signers[credIndex]
+		if len(cred.Sigs) != len(credSigners) {
+			return fmt.Errorf("%w: expected %d signatures in credential %d but got %d",
+				ErrWrongNumberOfSignatures,
+				len(credSigners),
+				credIndex,
+				len(cred.Sigs),
+			)
+		}
+
+		for sigIndex, sig := range cred.Sigs {
+			if sig == emptySig {
+				if complete {
+					return fmt.Errorf("%w: signature %d of credential %d from %s",
+						ErrMissingSignature,
+						sigIndex,
+						credIndex,
+						credSigners[sigIndex],
+					)
+				}
+				continue
+			}
+
+			pk, err := secp256k1.RecoverPublicKeyFromHash(unsignedHash, sig[:])
+			if err != nil {
+				return fmt.Errorf("%w: signature %d of credential %d: %w",
+					ErrMismatchedSignature,
+					sigIndex,
+					credIndex,
+					err,
+				)
+			}
+			if addr := pk.Address(); addr != credSigners[sigIndex] {
+				return fmt.Errorf("%w: signature %d of credential %d is from %s but should be from %s",
+					ErrMismatchedSignature,
+					sigIndex,
+					credIndex,
+					addr,
+					credSigners[sigIndex],
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// MergeSignatures copies the signatures of [src] into the empty slots of
+// [dst].
+//
+// Both [dst] and [src] are expected to have been verified against the same
+// signers. A slot populated with different signatures in [dst] and [src] is
+// reported as a duplicate signature.
+func MergeSignatures(dst, src []*secp256k1fx.Credential) error {
+	if len(dst) != len(src) {
+		return fmt.Errorf("%w: expected %d credentials but got %d",
+			ErrWrongNumberOfSignatures,
+			len(dst),
+			len(src),
+		)
+	}
+
+	for credIndex, srcCred := range src {
+		dstCred := dst[credIndex]
+		if len(dstCred.Sigs) != len(srcCred.Sigs) {
+			return fmt.Errorf("%w: expected %d signatures in credential %d but got %d",
+				ErrWrongNumberOfSignatures,
+				len(dstCred.Sigs),
+				credIndex,
+				len(srcCred.Sigs),
+			)
+		}
+
+		for sigIndex, sig := range srcCred.Sigs {
+			switch dstSig := dstCred.Sigs[sigIndex]; {
+			case sig == emptySig || sig == dstSig:
+			case dstSig == emptySig:
+				dstCred.Sigs[sigIndex] = sig
+			default:
+				return fmt.Errorf("%w: signature %d of credential %d",
+					ErrDuplicateSignature,
+					sigIndex,
+					credIndex,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// MissingSigners returns the addresses that still need to provide signatures
+// in [creds].
+func MissingSigners(signers [][]ids.ShortID, creds []*secp256k1fx.Credential) set.Set[ids.ShortID] {
+	var missing set.Set[ids.ShortID]
+	for credIndex, credSigners := range signers {
+		for sigIndex, addr := range credSigners {
+			if credIndex >= len(creds) ||
+				sigIndex >= len(creds[credIndex].Sigs) ||
+				creds[credIndex].Sigs[sigIndex] == emptySig {
+				missing.Add(addr)
+			}
+		}
+	}
+	return missing
+}
+
+// EqualSigners returns nil if [a] and [b] require the same addresses to sign
+// the same slots.
+func EqualSigners(a, b [][]ids.ShortID) error {
+	if len(a) != len(b) {
+		return fmt.Errorf("%w: expected %d credentials but got %d",
+			ErrMismatchedSigners,
+			len(a),
+			len(b),
+		)
+	}
+	for credIndex, aSigners := range a {
+		bSigners := b[credIndex]
+		if len(aSigners) != len(bSigners) {
+			return fmt.Errorf("%w: expected %d signers of credential %d but got %d",
+				ErrMismatchedSigners,
+				len(aSigners),
+				credIndex,
+				len(bSigners),
+			)
+		}
+		for sigIndex, addr := range aSigners {
+			if addr != bSigners[sigIndex] {
+				return fmt.Errorf("%w: expected signature %d of credential %d from %s but got %s",
+					ErrMismatchedSigners,
+					sigIndex,
+					credIndex,
+					addr,
+					bSigners[sigIndex],
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// SignersKeychain claims to hold the key of every address. Its signers can't
+// sign, but they can be used to find the addresses whose signatures a tx
+// requires.
+type SignersKeychain struct{}
+
+func (SignersKeychain) Get(addr ids.ShortID) (keychain.Signer, bool) {
+	return addressSigner{addr: addr}, true
+}
+
+func (SignersKeychain) Addresses() set.Set[ids.ShortID] {
+	return nil
+}
+
+type addressSigner struct {
+	addr ids.ShortID
+}
+
+func (addressSigner) SignHash([]byte) ([]byte, error) {
+	return nil, errCantSign
+}
+
+func (addressSigner) Sign([]byte) ([]byte, error) {
+	return nil, errCantSign
+}
+
+func (s addressSigner) Address() ids.ShortID {
+	return s.addr
+}
+
+// SignerAddresses returns the addresses of [signers]. Missing signers are
+// reported as [ids.ShortEmpty].
+func SignerAddresses(signers [][]keychain.Signer) [][]ids.ShortID {
+	addrs := make([][]ids.ShortID, len(signers))
+	for credIndex, credSigners := range signers {
+		addrs[credIndex] = make([]ids.ShortID, len(credSigners))
+		for sigIndex, signer := range credSigners {
+			if signer != nil {
+				addrs[credIndex][sigIndex] = signer.Address()
+			}
+		}
+	}
+	return addrs
+}
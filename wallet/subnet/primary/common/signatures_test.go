@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestMergeSignatures(t *testing.T) {
+	sig := func(b byte) [secp256k1.SignatureLen]byte {
+		return [secp256k1.SignatureLen]byte{b}
+	}
+
+	tests := []struct {
+		name        string
+		dst         []*secp256k1fx.Credential
+		src         []*secp256k1fx.Credential
+		expected    []*secp256k1fx.Credential
+		expectedErr error
+	}{
+		{
+			name: "fills missing signatures",
+			dst: []*secp256k1fx.Credential{
+				{Sigs: [][secp256k1.SignatureLen]byte{sig(1), emptySig}},
+			},
+			src: []*secp256k1fx.Credential{
+				{Sigs: [][secp256k1.SignatureLen]byte{emptySig, sig(2)}},
+			},
+			expected: []*secp256k1fx.Credential{
+				{Sigs: [][secp256k1.SignatureLen]byte{sig(1), sig(2)}},
+			},
+		},
+		{
+			name: "same signature",
+			dst: []*secp256k1fx.Credential{
+				{Sigs: [][secp256k1.SignatureLen]byte{sig(1)}},
+			},
+			src: []*secp256k1fx.Credential{
+				{Sigs: [][secp256k1.SignatureLen]byte{sig(1)}},
+			},
+			expected: []*secp256k1fx.Credential{
+				{Sigs: [][secp256k1.SignatureLen]byte{sig(1)}},
+			},
+		},
+		{
+			name: "duplicate signature",
+			dst: []*secp256k1fx.Credential{
+				{Sigs: [][secp256k1.SignatureLen]byte{sig(1)}},
+			},
+			src: []*secp256k1fx.Credential{
+				{Sigs: [][secp256k1.SignatureLen]byte{sig(2)}},
+			},
+			expectedErr: ErrDuplicateSignature,
+		},
+		{
+			name: "wrong number of credentials",
+			dst: []*secp256k1fx.Credential{
+				{Sigs: [][secp256k1.SignatureLen]byte{sig(1)}},
+			},
+			src:         nil,
+			expectedErr: ErrWrongNumberOfSignatures,
+		},
+		{
+			name: "wrong number of signatures",
+			dst: []*secp256k1fx.Credential{
+				{Sigs: [][secp256k1.SignatureLen]byte{sig(1)}},
+			},
+			src: []*secp256k1fx.Credential{
+				{Sigs: [][secp256k1.SignatureLen]byte{sig(1), sig(2)}},
+			},
+			expectedErr: ErrWrongNumberOfSignatures,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			err := MergeSignatures(test.dst, test.src)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				require.Equal(test.expected, test.dst)
+			}
+		})
+	}
+}
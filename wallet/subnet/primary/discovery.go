@@ -0,0 +1,258 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	xbuilder "github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+)
+
+// DefaultGapLimit is the number of consecutive unused addresses after which
+// address discovery stops, as recommended by BIP-44.
+const DefaultGapLimit = 20
+
+var (
+	errInvalidGapLimit = errors.New("gap limit must be at least 1")
+
+	// discoverySourceChains are the chains whose UTXOs, including the UTXOs
+	// they exported, mark an address as used.
+	discoverySourceChains = []string{"P", "X", "C"}
+)
+
+type DiscoveryConfig struct {
+	// Base URI to use for all node requests.
+	URI string // required
+	// Number of consecutive unused addresses after which discovery stops.
+	GapLimit int // required
+	// Index before which every address is treated as used. It should be set
+	// to the index that follows the last address known to have been used,
+	// e.g. by a previous discovery, as discovery may miss it otherwise.
+	MinIndex uint32 // optional
+	// If true, the addresses that were used by accepted P-chain txs or
+	// X-chain AVAX txs are found even if they no longer reference any UTXOs.
+	// The node must run with the P-chain tx index and the X-chain address tx
+	// index enabled.
+	TxHistory bool // optional
+}
+
+type utxoChain struct {
+	client UTXOClient
+	codec  codec.Manager
+}
+
+// addressTxsClient returns the IDs of the accepted txs that touched an
+// address.
+type addressTxsClient interface {
+	GetAddressTxs(
+		ctx context.Context,
+		addr ids.ShortID,
+		cursor uint64,
+		pageSize uint64,
+		options ...rpc.Option,
+	) ([]ids.ID, uint64, error)
+}
+
+// avaxTxsClient returns the X-chain AVAX txs of an address.
+type avaxTxsClient struct {
+	client avm.Client
+}
+
+func (c avaxTxsClient) GetAddressTxs(
+	ctx context.Context,
+	addr ids.ShortID,
+	cursor uint64,
+	pageSize uint64,
+	options ...rpc.Option,
+) ([]ids.ID, uint64, error) {
+	return c.client.GetAddressTxs(ctx, addr, "", cursor, pageSize, options...)
+}
+
+// DiscoverAddresses derives the keys of [kc], starting from index 0, until
+// [config.GapLimit] consecutive addresses after [config.MinIndex] are unused.
+//
+// An address is used if it references any UTXO on the P-chain or the X-chain,
+// including the atomic UTXOs exported to them from the P-chain, the X-chain or
+// the C-chain. Unless [config.TxHistory] is set, an address whose UTXOs were
+// all spent looks unused, so discovery may stop before the addresses that
+// follow it and miss their funds. [config.MinIndex] can be used to scan past
+// such addresses.
+//
+// Afterwards, [kc] holds every used address and the [config.GapLimit] unused
+// addresses that follow the last of them. It can then be provided to
+// MakeWallet as both the AVAX and Eth keychain.
+//
+// Returns the index that follows the last used address.
+func DiscoverAddresses(
+	ctx context.Context,
+	config *DiscoveryConfig,
+	kc *secp256k1fx.HDKeychain,
+) (uint32, error) {
+	var (
+		pClient = platformvm.NewClient(config.URI)
+		xClient = avm.NewClient(config.URI, "X")
+		history []addressTxsClient
+	)
+	if config.TxHistory {
+		history = []addressTxsClient{
+			pClient,
+			avaxTxsClient{client: xClient},
+		}
+	}
+	return discoverAddresses(
+		ctx,
+		kc,
+		config.GapLimit,
+		config.MinIndex,
+		[]utxoChain{
+			{
+				client: pClient,
+				codec:  txs.Codec,
+			},
+			{
+				client: xClient,
+				codec:  xbuilder.Parser.Codec(),
+			},
+		},
+		history,
+	)
+}
+
+func discoverAddresses(
+	ctx context.Context,
+	kc *secp256k1fx.HDKeychain,
+	gapLimit int,
+	minIndex uint32,
+	chains []utxoChain,
+	history []addressTxsClient,
+) (uint32, error) {
+	if gapLimit < 1 {
+		return 0, errInvalidGapLimit
+	}
+
+	var (
+		next    = minIndex // index that follows the last used address
+		derived uint32     // number of addresses checked so far
+	)
+	for derived < next+uint32(gapLimit) {
+		indices := make([]uint32, 0, next+uint32(gapLimit)-derived)
+		for index := derived; index < next+uint32(gapLimit); index++ {
+			indices = append(indices, index)
+		}
+		addrs, err := kc.Derive(indices...)
+		if err != nil {
+			return 0, err
+		}
+		derived += uint32(len(indices))
+
+		used, err := usedAddresses(ctx, chains, addrs)
+		if err != nil {
+			return 0, err
+		}
+		for i, addr := range addrs {
+			isUsed := used.Contains(addr)
+			if !isUsed {
+				// The address may have been used by UTXOs that were spent.
+				isUsed, err = hasTxs(ctx, history, addr)
+				if err != nil {
+					return 0, err
+				}
+			}
+			if isUsed {
+				next = max(next, indices[i]+1)
+			}
+		}
+	}
+	return next, nil
+}
+
+// hasTxs returns true if any client of [history] returns a tx that touched
+// [addr].
+func hasTxs(ctx context.Context, history []addressTxsClient, addr ids.ShortID) (bool, error) {
+	for _, client := range history {
+		txIDs, _, err := client.GetAddressTxs(ctx, addr, 0, 1)
+		if err != nil {
+			return false, err
+		}
+		if len(txIDs) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// usedAddresses returns the subset of [addrs] that is referenced by any UTXO
+// on [chains].
+func usedAddresses(
+	ctx context.Context,
+	chains []utxoChain,
+	addrs []ids.ShortID,
+) (set.Set[ids.ShortID], error) {
+	var (
+		candidates = set.Of(addrs...)
+		used       set.Set[ids.ShortID]
+	)
+	for _, chain := range chains {
+		for _, sourceChain := range discoverySourceChains {
+			var (
+				startAddr ids.ShortID
+				startUTXO ids.ID
+			)
+			for used.Len() < candidates.Len() {
+				utxosBytes, endAddr, endUTXO, err := chain.client.GetAtomicUTXOs(
+					ctx,
+					addrs,
+					sourceChain,
+					fetchLimit,
+					startAddr,
+					startUTXO,
+				)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, utxoBytes := range utxosBytes {
+					var utxo avax.UTXO
+					if _, err := chain.codec.Unmarshal(utxoBytes, &utxo); err != nil {
+						return nil, err
+					}
+
+					out, ok := utxo.Out.(avax.Addressable)
+					if !ok {
+						continue
+					}
+					for _, addrBytes := range out.Addresses() {
+						addr, err := ids.ToShortID(addrBytes)
+						if err != nil {
+							return nil, err
+						}
+						if candidates.Contains(addr) {
+							used.Add(addr)
+						}
+					}
+				}
+
+				if len(utxosBytes) < fetchLimit {
+					break
+				}
+
+				// Update the vars to query the next page of UTXOs.
+				startAddr = endAddr
+				startUTXO = endUTXO
+			}
+		}
+	}
+	return used, nil
+}
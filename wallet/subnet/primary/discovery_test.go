@@ -0,0 +1,225 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+var (
+	_ UTXOClient       = (*testUTXOClient)(nil)
+	_ addressTxsClient = (*testAddressTxsClient)(nil)
+)
+
+// testUTXOClient returns a UTXO for each owner that was exported from
+// [sourceChain].
+type testUTXOClient struct {
+	sourceChain string
+	owners      set.Set[ids.ShortID]
+}
+
+func (c *testUTXOClient) GetAtomicUTXOs(
+	_ context.Context,
+	addrs []ids.ShortID,
+	sourceChain string,
+	_ uint32,
+	_ ids.ShortID,
+	_ ids.ID,
+	_ ...rpc.Option,
+) ([][]byte, ids.ShortID, ids.ID, error) {
+	if sourceChain != c.sourceChain {
+		return nil, ids.ShortEmpty, ids.Empty, nil
+	}
+
+	var utxos [][]byte
+	for _, addr := range addrs {
+		if !c.owners.Contains(addr) {
+			continue
+		}
+		utxoBytes, err := txs.Codec.Marshal(txs.CodecVersion, &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: ids.GenerateTestID()},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+		})
+		if err != nil {
+			return nil, ids.ShortEmpty, ids.Empty, err
+		}
+		utxos = append(utxos, utxoBytes)
+	}
+	return utxos, ids.ShortEmpty, ids.Empty, nil
+}
+
+// testAddressTxsClient returns a tx for each address that was used.
+type testAddressTxsClient struct {
+	used set.Set[ids.ShortID]
+}
+
+func (c *testAddressTxsClient) GetAddressTxs(
+	_ context.Context,
+	addr ids.ShortID,
+	cursor uint64,
+	_ uint64,
+	_ ...rpc.Option,
+) ([]ids.ID, uint64, error) {
+	if !c.used.Contains(addr) {
+		return nil, cursor, nil
+	}
+	return []ids.ID{ids.GenerateTestID()}, cursor + 1, nil
+}
+
+func TestDiscoverAddresses(t *testing.T) {
+	require := require.New(t)
+
+	// Derive the addresses that are expected to be used.
+	used, err := secp256k1fx.NewHDKeychainFromIndices(testMnemonic, "", []uint32{0, 3, 22})
+	require.NoError(err)
+	addrs := make(map[uint32]ids.ShortID)
+	for addr := range used.Addresses() {
+		index, _ := used.Index(addr)
+		addrs[index] = addr
+	}
+
+	kc, err := secp256k1fx.NewHDKeychain(testMnemonic, "", 1)
+	require.NoError(err)
+
+	next, err := discoverAddresses(
+		context.Background(),
+		kc,
+		DefaultGapLimit,
+		0,
+		[]utxoChain{
+			{
+				client: &testUTXOClient{
+					sourceChain: "P",
+					owners:      set.Of(addrs[0], addrs[3]),
+				},
+				codec: txs.Codec,
+			},
+			{
+				// Address 22 is only used by an atomic UTXO exported from the
+				// C-chain.
+				client: &testUTXOClient{
+					sourceChain: "C",
+					owners:      set.Of(addrs[22]),
+				},
+				codec: txs.Codec,
+			},
+		},
+		nil,
+	)
+	require.NoError(err)
+	require.Equal(uint32(23), next)
+	kcAddrs := kc.Addresses()
+	require.Equal(23+DefaultGapLimit, kcAddrs.Len())
+	for _, addr := range addrs {
+		require.True(kcAddrs.Contains(addr))
+	}
+}
+
+func TestDiscoverAddressesUnused(t *testing.T) {
+	require := require.New(t)
+
+	kc, err := secp256k1fx.NewHDKeychain(testMnemonic, "", 1)
+	require.NoError(err)
+
+	next, err := discoverAddresses(
+		context.Background(),
+		kc,
+		5,
+		0,
+		[]utxoChain{
+			{
+				client: &testUTXOClient{sourceChain: "P"},
+				codec:  txs.Codec,
+			},
+		},
+		nil,
+	)
+	require.NoError(err)
+	require.Zero(next)
+	require.Equal(5, kc.Addresses().Len())
+
+	_, err = discoverAddresses(context.Background(), kc, 0, 0, nil, nil)
+	require.ErrorIs(err, errInvalidGapLimit)
+}
+
+func TestDiscoverAddressesSpent(t *testing.T) {
+	// Address 0 was used, but its UTXOs were all spent.
+	used, err := secp256k1fx.NewHDKeychainFromIndices(testMnemonic, "", []uint32{0, 10})
+	require.NoError(t, err)
+	addrs := make(map[uint32]ids.ShortID)
+	for addr := range used.Addresses() {
+		index, _ := used.Index(addr)
+		addrs[index] = addr
+	}
+	chains := []utxoChain{
+		{
+			client: &testUTXOClient{
+				sourceChain: "P",
+				owners:      set.Of(addrs[10]),
+			},
+			codec: txs.Codec,
+		},
+	}
+
+	tests := []struct {
+		name         string
+		minIndex     uint32
+		history      []addressTxsClient
+		expectedNext uint32
+	}{
+		{
+			name:         "unused by UTXOs",
+			expectedNext: 0,
+		},
+		{
+			name:         "used by txs",
+			history:      []addressTxsClient{&testAddressTxsClient{used: set.Of(addrs[0])}},
+			expectedNext: 11,
+		},
+		{
+			name:         "min index",
+			minIndex:     1,
+			expectedNext: 11,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			kc, err := secp256k1fx.NewHDKeychain(testMnemonic, "", 1)
+			require.NoError(err)
+
+			next, err := discoverAddresses(
+				context.Background(),
+				kc,
+				10,
+				test.minIndex,
+				chains,
+				test.history,
+			)
+			require.NoError(err)
+			require.Equal(test.expectedNext, next)
+			require.Equal(int(test.expectedNext)+10, kc.Addresses().Len())
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
+)
+
+func main() {
+	uri := primary.LocalAPIURI
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	kc, err := secp256k1fx.NewHDKeychain(mnemonic, "", 1)
+	if err != nil {
+		log.Fatalf("failed to derive keychain: %s\n", err)
+	}
+
+	ctx := context.Background()
+
+	discoverStartTime := time.Now()
+	next, err := primary.DiscoverAddresses(ctx, &primary.DiscoveryConfig{
+		URI:      uri,
+		GapLimit: primary.DefaultGapLimit,
+	}, kc)
+	if err != nil {
+		log.Fatalf("failed to discover addresses: %s\n", err)
+	}
+	log.Printf("discovered %d used addresses in %s\n", next, time.Since(discoverStartTime))
+
+	// MakeWallet fetches the available UTXOs owned by [kc] on the network that
+	// [uri] is hosting.
+	walletSyncStartTime := time.Now()
+	wallet, err := primary.MakeWallet(ctx, &primary.WalletConfig{
+		URI:          uri,
+		AVAXKeychain: kc,
+		EthKeychain:  kc,
+	})
+	if err != nil {
+		log.Fatalf("failed to initialize wallet: %s\n", err)
+	}
+	log.Printf("synced wallet in %s\n", time.Since(walletSyncStartTime))
+
+	pBuilder := wallet.P().Builder()
+	balances, err := pBuilder.GetBalance()
+	if err != nil {
+		log.Fatalf("failed to get the balance: %s\n", err)
+	}
+
+	avaxID := pBuilder.Context().AVAXAssetID
+	log.Printf("current P-chain AVAX balance is %d nAVAX\n", balances[avaxID])
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// fsck checks the consistency of a merkledb stored in a database that isn't
+// in use, and optionally repairs it.
+//
+// If the merkledb is stored under a prefix, for example in a chain's database,
+// each prefix is passed with --prefix in the order it was applied.
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/pebbledb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+)
+
+var errUnrepairedIssues = errors.New("found issues that weren't repaired")
+
+func main() {
+	var (
+		dbPath         string
+		dbType         string
+		prefixes       []string
+		branchFactor   int
+		ethereumHasher bool
+		cacheSize      uint
+		repair         bool
+	)
+	cmd := &cobra.Command{
+		Use:           "fsck",
+		Short:         "Checks the consistency of a merkledb, and optionally repairs it",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if len(dbPath) == 0 {
+				return errors.New("--db-path is required")
+			}
+
+			config := merkledb.Config{
+				BranchFactor:                merkledb.BranchFactor(branchFactor),
+				ValueNodeCacheSize:          cacheSize,
+				IntermediateNodeCacheSize:   cacheSize,
+				IntermediateWriteBufferSize: cacheSize,
+				IntermediateWriteBatchSize:  cacheSize / 16,
+				Reg:                         prometheus.NewRegistry(),
+				TraceLevel:                  merkledb.NoTrace,
+			}
+			if ethereumHasher {
+				config.Hasher = merkledb.EthereumHasher
+			}
+
+			prefixBytes := make([][]byte, len(prefixes))
+			for i, prefix := range prefixes {
+				var err error
+				prefixBytes[i], err = hex.DecodeString(prefix)
+				if err != nil {
+					return fmt.Errorf("failed to parse prefix %q: %w", prefix, err)
+				}
+			}
+
+			// Opening a database that doesn't exist would create it.
+			if _, err := os.Stat(dbPath); err != nil {
+				return err
+			}
+			db, err := openDB(dbType, dbPath)
+			if err != nil {
+				return err
+			}
+
+			var merkleDB database.Database = db
+			for _, prefix := range prefixBytes {
+				merkleDB = prefixdb.New(prefix, merkleDB)
+			}
+
+			report, err := merkledb.Fsck(cmd.Context(), merkleDB, config, repair)
+			if err := errors.Join(err, db.Close()); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(os.Stdout, "clean shutdown: %t\n", report.CleanShutdown)
+			fmt.Fprintf(os.Stdout, "nodes: %d\n", report.NumNodes)
+			fmt.Fprintf(os.Stdout, "values: %d\n", report.NumValues)
+			for _, issue := range report.Issues {
+				fmt.Fprintf(os.Stdout, "issue: %s\n", issue)
+			}
+			for _, key := range report.RebuiltSubtrees {
+				fmt.Fprintf(os.Stdout, "rebuilt subtree at key %x (%d bits)\n", key.Bytes(), key.Length())
+			}
+			if report.RebuiltTrie {
+				fmt.Fprintln(os.Stdout, "rebuilt trie")
+			}
+
+			if len(report.Issues) > 0 && !repair {
+				return errUnrepairedIssues
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&dbPath, "db-path", "", "The path of the database")
+	cmd.Flags().StringVar(&dbType, "db-type", leveldb.Name, fmt.Sprintf("The type of the database (%s or %s)", leveldb.Name, pebbledb.Name))
+	cmd.Flags().StringArrayVar(&prefixes, "prefix", nil, "A hex encoded prefix the merkledb is stored under. May be repeated for nested prefixes")
+	cmd.Flags().IntVar(&branchFactor, "branch-factor", int(merkledb.BranchFactor16), "The branch factor of the merkledb")
+	cmd.Flags().BoolVar(&ethereumHasher, "ethereum-hasher", false, "Whether the merkledb uses the ethereum hasher")
+	cmd.Flags().UintVar(&cacheSize, "cache-size", 64*units.MiB, "The number of bytes used to cache nodes")
+	cmd.Flags().BoolVar(&repair, "repair", false, "Whether to repair the issues that are found")
+
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		fmt.Fprintf(os.Stderr, "command failed %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func openDB(dbType string, dbPath string) (database.Database, error) {
+	switch dbType {
+	case leveldb.Name:
+		return leveldb.New(dbPath, nil, logging.NoLog{}, prometheus.NewRegistry())
+	case pebbledb.Name:
+		return pebbledb.New(dbPath, nil, logging.NoLog{}, prometheus.NewRegistry())
+	default:
+		return nil, fmt.Errorf("unknown database type %q", dbType)
+	}
+}
@@ -20,6 +20,11 @@ const (
 	boolLen   = 1
 	trueByte  = 1
 	falseByte = 0
+
+	// Flags encoded in the byte following each child's ID.
+	childHasValueFlag = 1 << 0
+	childEmbeddedFlag = 1 << 1
+	childFlagsMask    = childHasValueFlag | childEmbeddedFlag
 )
 
 var (
@@ -29,6 +34,7 @@ var (
 	errChildIndexTooLarge = errors.New("invalid child index. Must be less than branching factor")
 	errLeadingZeroes      = errors.New("varint has leading zeroes")
 	errInvalidBool        = errors.New("decoded bool is neither true nor false")
+	errInvalidChildFlags  = errors.New("decoded child flags are invalid")
 	errNonZeroKeyPadding  = errors.New("key partial byte should be padded with 0s")
 	errExtraSpace         = errors.New("trailing buffer space")
 	errIntOverflow        = errors.New("value overflows int")
@@ -39,7 +45,7 @@ func childSize(index byte, childEntry *child) int {
 	// * index
 	// * child ID
 	// * child key
-	// * bool indicating whether the child has a value
+	// * flags indicating whether the child has a value and is embedded
 	return uintSize(uint64(index)) + ids.IDLen + keySize(childEntry.compressedKey) + boolLen
 }
 
@@ -107,7 +113,7 @@ func encodeDBNode(n *dbNode) []byte {
 		w.Uvarint(uint64(index))
 		w.Key(entry.compressedKey)
 		w.ID(entry.id)
-		w.Bool(entry.hasValue)
+		w.ChildFlags(entry)
 	}
 
 	return w.b
@@ -134,6 +140,17 @@ func (w *codecWriter) Bool(v bool) {
 	}
 }
 
+func (w *codecWriter) ChildFlags(c *child) {
+	var flags byte
+	if c.hasValue {
+		flags |= childHasValueFlag
+	}
+	if c.embedded {
+		flags |= childEmbeddedFlag
+	}
+	w.b = append(w.b, flags)
+}
+
 func (w *codecWriter) Uvarint(v uint64) {
 	w.b = binary.AppendUvarint(w.b, v)
 }
@@ -201,7 +218,7 @@ func decodeDBNode(b []byte, n *dbNode) error {
 		if err != nil {
 			return err
 		}
-		hasValue, err := r.Bool()
+		hasValue, embedded, err := r.ChildFlags()
 		if err != nil {
 			return err
 		}
@@ -209,6 +226,7 @@ func decodeDBNode(b []byte, n *dbNode) error {
 			compressedKey: compressedKey,
 			id:            childID,
 			hasValue:      hasValue,
+			embedded:      embedded,
 		}
 	}
 	if len(r.b) != 0 {
@@ -252,6 +270,19 @@ func (r *codecReader) Bool() (bool, error) {
 	return boolByte == trueByte, nil
 }
 
+func (r *codecReader) ChildFlags() (bool, bool, error) {
+	if len(r.b) < boolLen {
+		return false, false, io.ErrUnexpectedEOF
+	}
+	flags := r.b[0]
+	if flags&^childFlagsMask != 0 {
+		return false, false, errInvalidChildFlags
+	}
+
+	r.b = r.b[boolLen:]
+	return flags&childHasValueFlag != 0, flags&childEmbeddedFlag != 0, nil
+}
+
 func (r *codecReader) Uvarint() (uint64, error) {
 	length, bytesRead := binary.Uvarint(r.b)
 	if bytesRead <= 0 {
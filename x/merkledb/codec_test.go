@@ -79,6 +79,34 @@ var (
 				0x01, // children[0].hasValue
 			},
 		},
+		{
+			name: "1 embedded child",
+			n: &dbNode{
+				children: map[byte]*child{
+					0: {
+						compressedKey: ToKey([]byte{0}),
+						id: ids.ID{
+							0xc3, 0x82, 0x20, 0x00, 0x00, 0x00, 0x00, 0x00,
+						},
+						hasValue: true,
+						embedded: true,
+					},
+				},
+			},
+			expectedBytes: []byte{
+				0x00, // value.HasValue()
+				0x01, // len(children)
+				0x00, // children[0].index
+				0x08, // len(children[0].compressedKey)
+				0x00, // children[0].compressedKey
+				// children[0].id
+				0xc3, 0x82, 0x20, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x03, // children[0].hasValue | children[0].embedded
+			},
+		},
 		{
 			name: "2 children",
 			n: &dbNode{
@@ -509,6 +537,8 @@ func FuzzCodecDBNodeDeterministic(f *testing.F) {
 					children[byte(i)] = &child{
 						compressedKey: ToKey(childKeyBytes),
 						id:            childID,
+						hasValue:      r.Intn(2) == 1, // #nosec G404
+						embedded:      r.Intn(2) == 1, // #nosec G404
 					}
 				}
 				node := dbNode{
@@ -545,6 +575,24 @@ func TestCodecDecodeDBNode_TooShort(t *testing.T) {
 	require.ErrorIs(err, io.ErrUnexpectedEOF)
 }
 
+func TestCodecDecodeDBNode_InvalidChildFlags(t *testing.T) {
+	require := require.New(t)
+
+	nodeBytes := encodeDBNode(&dbNode{
+		children: map[byte]*child{
+			0: {
+				hasValue: true,
+				embedded: true,
+			},
+		},
+	})
+	nodeBytes[len(nodeBytes)-1] |= 1 << 2
+
+	var parsedDBNode dbNode
+	err := decodeDBNode(nodeBytes, &parsedDBNode)
+	require.ErrorIs(err, errInvalidChildFlags)
+}
+
 func TestEncodeDBNode(t *testing.T) {
 	for _, test := range encodeDBNodeTests {
 		t.Run(test.name, func(t *testing.T) {
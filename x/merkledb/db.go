@@ -43,6 +43,7 @@ var (
 	metadataPrefix         = []byte{0}
 	valueNodePrefix        = []byte{1}
 	intermediateNodePrefix = []byte{2}
+	historyPrefix          = []byte{3}
 
 	// cleanShutdownKey is used to flag that the database did (or did not)
 	// previously shutdown correctly.
@@ -129,12 +130,27 @@ type RangeProofer interface {
 	CommitRangeProof(ctx context.Context, start, end maybe.Maybe[[]byte], proof *RangeProof) error
 }
 
+type HistoricalViewer interface {
+	// NewReadOnlyViewAtRoot returns a view of the trie as it was when its root
+	// was [rootID].
+	// The returned view can't be committed and is invalidated when a change
+	// is committed to the database.
+	// Returns [ErrInsufficientHistory] if [rootID] isn't in the history.
+	NewReadOnlyViewAtRoot(ctx context.Context, rootID ids.ID) (View, error)
+}
+
 type Clearer interface {
 	// Deletes all key/value pairs from the database
 	// and clears the change history.
 	Clear() error
 }
 
+type Syncer interface {
+	// Sync waits until every change committed to the database has been
+	// written to disk.
+	Sync() error
+}
+
 type Prefetcher interface {
 	// PrefetchPath attempts to load all trie nodes on the path of [key]
 	// into the cache.
@@ -156,7 +172,9 @@ type MerkleDB interface {
 	ProofGetter
 	ChangeProofer
 	RangeProofer
+	HistoricalViewer
 	Prefetcher
+	Syncer
 }
 
 type Config struct {
@@ -177,6 +195,11 @@ type Config struct {
 	// The number of changes to the database that we store in memory in order to
 	// serve change proofs.
 	HistoryLength uint
+	// The number of changes to the database that we store on disk in order to
+	// serve change proofs once they are no longer in memory, including after
+	// a restart.
+	// If 0, changes aren't persisted.
+	HistoryDiskLength uint
 	// The number of bytes used to cache nodes with values.
 	ValueNodeCacheSize uint
 	// The number of bytes used to cache nodes without values.
@@ -186,6 +209,11 @@ type Config struct {
 	// The number of bytes to write to disk when intermediate nodes are evicted
 	// from the write buffer and written to disk.
 	IntermediateWriteBatchSize uint
+	// The number of bytes of committed changes that may be held in memory
+	// while they're written to disk in the background. Once exceeded, commits
+	// wait for the changes to be written.
+	// If 0, changes are written to disk before the commit returns.
+	CommitBufferSize uint
 	// If [Reg] is nil, metrics are collected locally but not exported through
 	// Prometheus.
 	// This may be useful for testing.
@@ -209,6 +237,10 @@ type merkleDB struct {
 	// including metadata, intermediate nodes and value nodes.
 	baseDB database.Database
 
+	// If non-nil, [baseDB] is [pipeline], which writes the changes to the
+	// underlying database in the background.
+	pipeline *pipelineDB
+
 	valueNodeDB        *valueNodeDB
 	intermediateNodeDB *intermediateNodeDB
 
@@ -266,19 +298,31 @@ func newDatabase(
 	if hasher == nil {
 		hasher = DefaultHasher
 	}
+	if _, ok := hasher.(*ethereumHasher); ok && config.BranchFactor != BranchFactor16 {
+		return nil, ErrEthereumHasherBranchFactor
+	}
 
 	rootGenConcurrency := runtime.NumCPU()
 	if config.RootGenConcurrency != 0 {
 		rootGenConcurrency = int(config.RootGenConcurrency)
 	}
 
+	// Writes are made to the pipeline, rather than [db], so that they're
+	// written in the order they were made.
+	var pipeline *pipelineDB
+	if config.CommitBufferSize > 0 {
+		pipeline = newPipelineDB(db, int(config.CommitBufferSize))
+		db = pipeline
+	}
+
 	// Share a bytes pool between the intermediateNodeDB and valueNodeDB to
 	// reduce memory allocations.
 	bufferPool := utils.NewBytesPool()
 
 	trieDB := &merkleDB{
-		metrics: metrics,
-		baseDB:  db,
+		metrics:  metrics,
+		baseDB:   db,
+		pipeline: pipeline,
 		intermediateNodeDB: newIntermediateNodeDB(
 			db,
 			bufferPool,
@@ -335,6 +379,21 @@ func newDatabase(
 		nodes:  map[Key]*change[*node]{},
 	})
 
+	// The persisted history is loaded after the trie has been rebuilt so that
+	// the rebuild isn't recorded as a change.
+	if config.HistoryDiskLength > 0 {
+		trieDB.history.disk = newHistoryDB(db, hasher, uint64(config.HistoryDiskLength))
+		if err := trieDB.history.disk.initialize(trieDB.root, trieDB.rootID); err != nil {
+			return nil, err
+		}
+	} else {
+		// Remove any history persisted by a previous run, as it won't be kept
+		// up to date.
+		if err := database.ClearPrefix(db, historyPrefix, clearBatchSize); err != nil {
+			return nil, err
+		}
+	}
+
 	// mark that the db has not yet been cleanly closed
 	err = trieDB.baseDB.Put(cleanShutdownKey, didNotHaveCleanShutdown)
 	return trieDB, err
@@ -507,7 +566,31 @@ func (db *merkleDB) Close() error {
 	if err := batch.Put(cleanShutdownKey, hadCleanShutdown); err != nil {
 		return err
 	}
-	return batch.Write()
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	if db.pipeline == nil {
+		return nil
+	}
+
+	// The clean shutdown marker is written after every other change, so it's
+	// only on disk if every change is.
+	return db.pipeline.Close()
+}
+
+func (db *merkleDB) Sync() error {
+	if db.pipeline != nil {
+		// [db.lock] isn't held while waiting so that commits aren't blocked.
+		return db.pipeline.Sync()
+	}
+
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return nil
 }
 
 func (db *merkleDB) PrefetchPaths(keys [][]byte) error {
@@ -656,6 +739,37 @@ func (db *merkleDB) GetProof(ctx context.Context, key []byte) (*Proof, error) {
 	return getProof(db, key)
 }
 
+func (db *merkleDB) GetMultiProof(ctx context.Context, keys [][]byte) (*MultiProof, error) {
+	db.commitLock.RLock()
+	defer db.commitLock.RUnlock()
+
+	_, span := db.infoTracer.Start(ctx, "MerkleDB.GetMultiProof")
+	defer span.End()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+
+	return getMultiProof(db, keys)
+}
+
+func (db *merkleDB) GetEthereumProof(ctx context.Context, key []byte) ([][]byte, error) {
+	db.commitLock.RLock()
+	defer db.commitLock.RUnlock()
+
+	_, span := db.infoTracer.Start(ctx, "MerkleDB.GetEthereumProof")
+	defer span.End()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	if _, ok := db.hasher.(*ethereumHasher); !ok {
+		return nil, ErrNotEthereumHasher
+	}
+
+	return getEthereumProof(db, key)
+}
+
 func (db *merkleDB) GetRangeProof(
 	ctx context.Context,
 	start maybe.Maybe[[]byte],
@@ -675,6 +789,53 @@ func (db *merkleDB) GetRangeProof(
 	return getRangeProof(db, start, end, maxLength)
 }
 
+func (db *merkleDB) NewReadOnlyViewAtRoot(ctx context.Context, rootID ids.ID) (View, error) {
+	_, span := db.infoTracer.Start(ctx, "MerkleDB.NewReadOnlyViewAtRoot")
+	defer span.End()
+
+	// ensure the db doesn't change while creating the new view
+	db.commitLock.RLock()
+	defer db.commitLock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+
+	var changes *changeSummary
+	if rootID == db.getMerkleRoot() {
+		changes = &changeSummary{
+			rootID: rootID,
+			rootChange: change[maybe.Maybe[*node]]{
+				after: db.root,
+			},
+			values: map[Key]*change[maybe.Maybe[[]byte]]{},
+			nodes:  map[Key]*change[*node]{},
+		}
+	} else {
+		var err error
+		changes, err = db.history.getChangesToGetToRoot(rootID, maybe.Nothing[[]byte](), maybe.Nothing[[]byte]())
+		if err != nil {
+			return nil, err
+		}
+		changes.rootID = rootID
+	}
+
+	view, err := newViewWithChanges(db, changes)
+	if err != nil {
+		return nil, err
+	}
+	view.readOnly = true
+
+	// ensure access to childViews is protected
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	// Track the view so that it's invalidated by the next commit, as its
+	// changes are relative to the current state of the db.
+	db.childViews = append(db.childViews, view)
+	return view, nil
+}
+
 func (db *merkleDB) GetRangeProofAtRoot(
 	ctx context.Context,
 	rootID ids.ID,
@@ -950,9 +1111,7 @@ func (db *merkleDB) commitBatch(ops []database.BatchOp) error {
 // Assumes [trieToCommit]'s node IDs have been calculated.
 // Assumes [db.commitLock] is held.
 func (db *merkleDB) commitView(ctx context.Context, trieToCommit *view) error {
-	db.lock.Lock()
-	defer db.lock.Unlock()
-
+	// Nothing checked here can change while [db.commitLock] is held.
 	switch {
 	case db.closed:
 		return database.ErrClosed
@@ -973,6 +1132,28 @@ func (db *merkleDB) commitView(ctx context.Context, trieToCommit *view) error {
 	))
 	defer span.End()
 
+	// The value nodes and the history are encoded before [db.lock] is grabbed
+	// so that reads aren't blocked while they're encoded.
+	var valueNodeBatch database.Batch
+	if len(changes.nodes) != 0 {
+		if db.pipeline != nil {
+			db.pipeline.WaitForCapacity()
+		}
+
+		valueNodeBatch = db.baseDB.NewBatch()
+		if err := db.stageValueChanges(ctx, valueNodeBatch, changes); err != nil {
+			return err
+		}
+
+		// Persist the changes atomically with the value nodes.
+		if err := db.history.persist(valueNodeBatch, changes); err != nil {
+			return err
+		}
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
 	// invalidate all child views except for the view being committed
 	db.invalidateChildrenExcept(trieToCommit)
 
@@ -983,11 +1164,11 @@ func (db *merkleDB) commitView(ctx context.Context, trieToCommit *view) error {
 		return nil
 	}
 
-	valueNodeBatch := db.baseDB.NewBatch()
-	if err := db.applyChanges(ctx, valueNodeBatch, changes); err != nil {
+	if err := db.applyChanges(ctx, changes); err != nil {
 		return err
 	}
 
+	// If [db.pipeline] is non-nil, this only writes the value nodes to memory.
 	if err := db.commitValueChanges(ctx, valueNodeBatch); err != nil {
 		return err
 	}
@@ -1015,11 +1196,35 @@ func (db *merkleDB) moveChildViewsToDB(trieToCommit *view) {
 	trieToCommit.childViews = make([]*view, 0, defaultPreallocationSize)
 }
 
+// stageValueChanges writes the value nodes in [changes] to [valueNodeBatch].
+//
+// [db.lock] doesn't need to be held.
+func (db *merkleDB) stageValueChanges(ctx context.Context, valueNodeBatch database.KeyValueWriterDeleter, changes *changeSummary) error {
+	_, span := db.infoTracer.Start(ctx, "MerkleDB.stageValueChanges")
+	defer span.End()
+
+	for key, nodeChange := range changes.nodes {
+		shouldAddValue := nodeChange.after != nil && nodeChange.after.hasValue()
+		shouldDeleteValue := !shouldAddValue && nodeChange.before != nil && nodeChange.before.hasValue()
+
+		if shouldAddValue {
+			if err := db.valueNodeDB.Stage(valueNodeBatch, key, nodeChange.after); err != nil {
+				return err
+			}
+		} else if shouldDeleteValue {
+			if err := db.valueNodeDB.Stage(valueNodeBatch, key, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // applyChanges takes the [changes] and applies them to [db.intermediateNodeDB]
-// and [valueNodeBatch].
+// and the cache of [db.valueNodeDB].
 //
 // assumes [db.lock] is held
-func (db *merkleDB) applyChanges(ctx context.Context, valueNodeBatch database.KeyValueWriterDeleter, changes *changeSummary) error {
+func (db *merkleDB) applyChanges(ctx context.Context, changes *changeSummary) error {
 	_, span := db.infoTracer.Start(ctx, "MerkleDB.applyChanges")
 	defer span.End()
 
@@ -1041,13 +1246,9 @@ func (db *merkleDB) applyChanges(ctx context.Context, valueNodeBatch database.Ke
 		}
 
 		if shouldAddValue {
-			if err := db.valueNodeDB.Write(valueNodeBatch, key, nodeChange.after); err != nil {
-				return err
-			}
+			db.valueNodeDB.Cache(key, nodeChange.after)
 		} else if shouldDeleteValue {
-			if err := db.valueNodeDB.Write(valueNodeBatch, key, nil); err != nil {
-				return err
-			}
+			db.valueNodeDB.Cache(key, nil)
 		}
 	}
 	return nil
@@ -1255,7 +1456,7 @@ func (db *merkleDB) initializeRoot() error {
 		}
 	}
 
-	db.rootID = db.hasher.HashNode(root)
+	db.rootID = hashRoot(db.hasher, root)
 	db.metrics.HashCalculated()
 
 	db.root = maybe.Some(root)
@@ -1365,13 +1566,18 @@ func (db *merkleDB) Clear() error {
 	db.rootID = ids.Empty
 
 	// Clear history
+	disk := db.history.disk
 	db.history = newTrieHistory(db.history.maxHistoryLen)
 	db.history.record(&changeSummary{
 		rootID: db.rootID,
 		values: map[Key]*change[maybe.Maybe[[]byte]]{},
 		nodes:  map[Key]*change[*node]{},
 	})
-	return nil
+	if disk == nil {
+		return nil
+	}
+	db.history.disk = disk
+	return disk.reset(db.root, db.rootID)
 }
 
 func (db *merkleDB) getTokenSize() int {
@@ -1294,6 +1294,86 @@ func TestGetChangeProofEmptyRootID(t *testing.T) {
 	require.ErrorIs(err, ErrEmptyProof)
 }
 
+func TestNewReadOnlyViewAtRoot(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	expected := map[string][]byte{
+		"key0": []byte("value0"),
+		"key1": []byte("value1"),
+		"key2": []byte("value2"),
+	}
+	batch := db.NewBatch()
+	for k, v := range expected {
+		require.NoError(batch.Put([]byte(k), v))
+	}
+	require.NoError(batch.Write())
+	rootID := db.getMerkleRoot()
+
+	// Change the state after [rootID].
+	batch = db.NewBatch()
+	require.NoError(batch.Put([]byte("key0"), []byte("newValue0")))
+	require.NoError(batch.Delete([]byte("key1")))
+	require.NoError(batch.Put([]byte("key3"), []byte("value3")))
+	require.NoError(batch.Write())
+
+	_, err = db.NewReadOnlyViewAtRoot(context.Background(), ids.GenerateTestID())
+	require.ErrorIs(err, ErrInsufficientHistory)
+
+	view, err := db.NewReadOnlyViewAtRoot(context.Background(), rootID)
+	require.NoError(err)
+
+	viewRootID, err := view.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(rootID, viewRootID)
+
+	for k, v := range expected {
+		value, err := view.GetValue(context.Background(), []byte(k))
+		require.NoError(err)
+		require.Equal(v, value)
+
+		proof, err := view.GetProof(context.Background(), []byte(k))
+		require.NoError(err)
+		require.Equal(maybe.Some(v), proof.Value)
+		require.NoError(proof.Verify(context.Background(), rootID, db.tokenSize, db.hasher))
+	}
+	_, err = view.GetValue(context.Background(), []byte("key3"))
+	require.ErrorIs(err, database.ErrNotFound)
+
+	proof, err := view.GetProof(context.Background(), []byte("key3"))
+	require.NoError(err)
+	require.True(proof.Value.IsNothing())
+	require.NoError(proof.Verify(context.Background(), rootID, db.tokenSize, db.hasher))
+
+	it := view.NewIterator()
+	defer it.Release()
+
+	iterated := map[string][]byte{}
+	for it.Next() {
+		iterated[string(it.Key())] = it.Value()
+	}
+	require.NoError(it.Error())
+	require.Equal(expected, iterated)
+
+	require.ErrorIs(view.CommitToDB(context.Background()), ErrReadOnly)
+
+	// The view is relative to the current state of the db, so committing a
+	// change invalidates it.
+	require.NoError(db.Put([]byte("key4"), []byte("value4")))
+	_, err = view.GetValue(context.Background(), []byte("key0"))
+	require.ErrorIs(err, ErrInvalid)
+
+	// A view at the current root reflects the current state.
+	view, err = db.NewReadOnlyViewAtRoot(context.Background(), db.getMerkleRoot())
+	require.NoError(err)
+
+	value, err := view.GetValue(context.Background(), []byte("key4"))
+	require.NoError(err)
+	require.Equal([]byte("value4"), value)
+}
+
 func TestCrashRecovery(t *testing.T) {
 	require := require.New(t)
 
@@ -1364,7 +1444,8 @@ func BenchmarkCommitView(b *testing.B) {
 			db.baseDB = memdb.New() // Keep each iteration independent
 
 			valueNodeBatch := db.baseDB.NewBatch()
-			require.NoError(db.applyChanges(ctx, valueNodeBatch, view.changes))
+			require.NoError(db.stageValueChanges(ctx, valueNodeBatch, view.changes))
+			require.NoError(db.applyChanges(ctx, view.changes))
 			require.NoError(db.commitValueChanges(ctx, valueNodeBatch))
 		}
 	})
@@ -0,0 +1,239 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	// EthereumHasher hashes the trie the same way as Ethereum's Merkle
+	// Patricia Trie. Nodes are RLP encoded and hashed with keccak256. Nodes
+	// whose encodings are shorter than [HashLength] are inlined into their
+	// parent rather than hashed.
+	//
+	// The resulting merkle root of a non-empty trie is the root Ethereum
+	// would calculate for the same keys and values. An empty trie still has
+	// the root ids.Empty, rather than Ethereum's empty root.
+	//
+	// EthereumHasher requires BranchFactor16, so that each token of a key is
+	// a nibble. Ethereum treats empty values as deletions, so tries
+	// containing empty values don't have Ethereum equivalents.
+	EthereumHasher Hasher = &ethereumHasher{}
+
+	ErrEthereumHasherBranchFactor = errors.New("EthereumHasher requires BranchFactor16")
+	ErrNotEthereumHasher          = errors.New("trie isn't hashed with EthereumHasher")
+
+	_ pathHasher = (*ethereumHasher)(nil)
+)
+
+// Hex-prefix encoding flags of a node's path.
+const (
+	hexPrefixOddFlag  = 1
+	hexPrefixLeafFlag = 2
+)
+
+type ethereumHasher struct{}
+
+// HashNode returns the hash of [n] as if it were the root of the trie.
+func (h *ethereumHasher) HashNode(n *node) ids.ID {
+	return h.hashRoot(n)
+}
+
+func (*ethereumHasher) HashValue(value []byte) ids.ID {
+	return keccak256(value)
+}
+
+// The root node is always hashed, even if its encoding is short.
+func (*ethereumHasher) hashRoot(root *node) ids.ID {
+	encoding, _ := encodeEthereumNode(root, root.key)
+	return keccak256(encoding)
+}
+
+func (*ethereumHasher) hashChild(entry *child, childNode *node) {
+	encoding, _ := encodeEthereumNode(childNode, entry.compressedKey)
+	if len(encoding) >= HashLength {
+		entry.id = keccak256(encoding)
+		entry.embedded = false
+		return
+	}
+
+	// The ID is the encoding padded with zeroes. The length of the encoding
+	// is recovered from its RLP list header.
+	entry.id = ids.Empty
+	copy(entry.id[:], encoding)
+	entry.embedded = true
+}
+
+// Returns the RLP encoding of [n] as an Ethereum trie node. [path] is the
+// part of [n]'s key that isn't implied by its position in the trie.
+//
+// If [n] has children and a non-empty [path], [n] corresponds to an extension
+// node which references a branch node. In that case, the encoding of the
+// branch node is also returned.
+func encodeEthereumNode(n *node, path Key) ([]byte, []byte) {
+	if len(n.children) == 0 {
+		leaf := encodeRLPList(func(w rlp.EncoderBuffer) {
+			w.WriteBytes(hexPrefix(path, true /*isLeaf*/))
+			w.WriteBytes(n.value.Value())
+		})
+		return leaf, nil
+	}
+
+	branch := encodeRLPList(func(w rlp.EncoderBuffer) {
+		for i := 0; i < int(BranchFactor16); i++ {
+			entry, ok := n.children[byte(i)]
+			switch {
+			case !ok:
+				w.WriteBytes(nil)
+			case entry.embedded:
+				// The first byte of an embedded node is the header of an RLP
+				// list shorter than [HashLength], which is 0xc0 plus the
+				// length of the list's contents.
+				_, _ = w.Write(entry.id[:1+int(entry.id[0]&0x1f)])
+			default:
+				w.WriteBytes(entry.id[:])
+			}
+		}
+		w.WriteBytes(n.value.Value())
+	})
+	if path.length == 0 {
+		return branch, nil
+	}
+
+	extension := encodeRLPList(func(w rlp.EncoderBuffer) {
+		w.WriteBytes(hexPrefix(path, false /*isLeaf*/))
+		writeEthereumReference(w, branch)
+	})
+	return extension, branch
+}
+
+// Returns the RLP encoding of the list whose elements are written by
+// [writeElements].
+func encodeRLPList(writeElements func(w rlp.EncoderBuffer)) []byte {
+	w := rlp.NewEncoderBuffer(nil)
+	list := w.List()
+	writeElements(w)
+	w.ListEnd(list)
+	encoding := w.ToBytes()
+	// Flush only returns the buffer to the pool because there's no writer.
+	_ = w.Flush()
+	return encoding
+}
+
+// Writes the reference to the node with the given [encoding] to [w].
+func writeEthereumReference(w rlp.EncoderBuffer, encoding []byte) {
+	if len(encoding) < HashLength {
+		_, _ = w.Write(encoding)
+		return
+	}
+	hash := keccak256(encoding)
+	w.WriteBytes(hash[:])
+}
+
+// Returns the hex-prefix encoding of [path], which must be a whole number of
+// nibbles.
+func hexPrefix(path Key, isLeaf bool) []byte {
+	var (
+		numNibbles = path.length / 4
+		encoded    = make([]byte, numNibbles/2+1)
+		flags      byte
+		i          int
+	)
+	if isLeaf {
+		flags |= hexPrefixLeafFlag
+	}
+	if numNibbles%2 == 1 {
+		flags |= hexPrefixOddFlag
+		encoded[0] = path.Token(0, 4)
+		i = 1
+	}
+	encoded[0] |= flags << 4
+	for j := 1; i < numNibbles; j, i = j+1, i+2 {
+		encoded[j] = path.Token(i*4, 4)<<4 | path.Token((i+1)*4, 4)
+	}
+	return encoded
+}
+
+func keccak256(b []byte) ids.ID {
+	sha := sha3.NewLegacyKeccak256()
+	// sha.Write always returns nil, so we ignore its return values.
+	_, _ = sha.Write(b)
+
+	var hash ids.ID
+	sha.Sum(hash[:0])
+	return hash
+}
+
+// Returns the proof of [key] in [t] in the format of Ethereum's eth_getProof.
+// That is, the RLP encodings of the hashed Ethereum trie nodes on the path to
+// [key], starting with the root.
+// Assumes [t] is hashed with [EthereumHasher].
+// Assumes [t] doesn't change while this function is running.
+func getEthereumProof(t Trie, keyBytes []byte) ([][]byte, error) {
+	root := t.getRoot()
+	if root.IsNothing() {
+		return nil, ErrEmptyProof
+	}
+
+	var (
+		key       = ToKey(keyBytes)
+		tokenSize = t.getTokenSize()
+		proof     [][]byte
+		last      *node
+	)
+	if err := visitPathToKey(t, key, func(n *node) error {
+		path := n.key
+		if last != nil {
+			path = n.key.Skip(last.key.length + tokenSize)
+		}
+		proof = appendEthereumProofNodes(proof, n, path, last == nil)
+		last = n
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if last == nil {
+		// The root's key isn't a prefix of [key], which the root proves.
+		rootNode := root.Value()
+		return appendEthereumProofNodes(proof, rootNode, rootNode.key, true /*isRoot*/), nil
+	}
+	if last.key.length == key.length {
+		return proof, nil
+	}
+
+	// The child of [last] along [key] diverges from [key], which the child
+	// proves.
+	index := key.Token(last.key.length, tokenSize)
+	entry, ok := last.children[index]
+	if !ok || entry.embedded {
+		return proof, nil
+	}
+	childKey := last.key.Extend(ToToken(index, tokenSize), entry.compressedKey)
+	childNode, err := t.getNode(childKey, entry.hasValue)
+	if err != nil {
+		return nil, err
+	}
+	return appendEthereumProofNodes(proof, childNode, entry.compressedKey, false /*isRoot*/), nil
+}
+
+// Appends the encodings of the hashed Ethereum trie nodes that [n] corresponds
+// to, where [path] is the part of [n]'s key that isn't implied by its position
+// in the trie.
+func appendEthereumProofNodes(proof [][]byte, n *node, path Key, isRoot bool) [][]byte {
+	encoding, branch := encodeEthereumNode(n, path)
+	if isRoot || len(encoding) >= HashLength {
+		proof = append(proof, encoding)
+	}
+	if len(branch) >= HashLength {
+		proof = append(proof, branch)
+	}
+	return proof
+}
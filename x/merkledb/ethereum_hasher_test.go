@@ -0,0 +1,363 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"context"
+	"encoding/hex"
+	"math/rand"
+	"testing"
+
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/trie"
+	"github.com/ava-labs/coreth/triedb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+)
+
+// The code of the only account in the C-Chain's genesis state on Mainnet.
+const cChainGenesisCode = "7300000000000000000000000000000000000000003014608060405260043610" +
+	"603d5760003560e01c80631e010439146042578063b6510bb314606e575b6000" +
+	"80fd5b605c60048036036020811015605657600080fd5b503560b1565b604080" +
+	"51918252519081900360200190f35b818015607957600080fd5b5060af600480" +
+	"36036080811015608e57600080fd5b506001600160a01b038135169060208101" +
+	"35906040810135906060013560b6565b005b30cd90565b836001600160a01b03" +
+	"1681836108fc8690811502906040516000604051808303818888878c8acf9550" +
+	"505050505015801560f4573d6000803e3d6000fd5b505050505056fea2646970" +
+	"6673582212201eebce970fe3f5cb96bf8ac6ba5f5c133fc2908ae3dcd51082cf" +
+	"ee8f583429d064736f6c634300060a0033"
+
+func newEthereumConfig() Config {
+	config := newDefaultConfig()
+	config.Hasher = EthereumHasher
+	return config
+}
+
+func TestEthereumHasherKnownRoots(t *testing.T) {
+	cChainGenesisCode, err := hex.DecodeString(cChainGenesisCode)
+	require.NoError(t, err)
+	cChainGenesisAccount, err := rlp.EncodeToBytes(&types.StateAccount{
+		Balance:  uint256.NewInt(0),
+		Root:     types.EmptyRootHash,
+		CodeHash: crypto.Keccak256(cChainGenesisCode),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		ops          []database.BatchOp
+		expectedRoot string
+	}{
+		{
+			name: "shared prefixes",
+			ops: []database.BatchOp{
+				{Key: []byte("doe"), Value: []byte("reindeer")},
+				{Key: []byte("dog"), Value: []byte("puppy")},
+				{Key: []byte("dogglesworth"), Value: []byte("cat")},
+			},
+			expectedRoot: "8aad789dff2f538bca5d8ea56e8abe10f4c7ba3a5dea95fea4cd6e7c3a1168d3",
+		},
+		{
+			name: "single long value",
+			ops: []database.BatchOp{
+				{Key: []byte("A"), Value: []byte("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")},
+			},
+			expectedRoot: "d23786fb4a010da3ce639d66d5e904a11dbc02746d1ce25029e53290cabf28ab",
+		},
+		{
+			name: "deletions",
+			ops: []database.BatchOp{
+				{Key: []byte("do"), Value: []byte("verb")},
+				{Key: []byte("ether"), Value: []byte("wookiedoo")},
+				{Key: []byte("horse"), Value: []byte("stallion")},
+				{Key: []byte("shaman"), Value: []byte("horse")},
+				{Key: []byte("doge"), Value: []byte("coin")},
+				{Key: []byte("ether"), Delete: true},
+				{Key: []byte("dog"), Value: []byte("puppy")},
+				{Key: []byte("shaman"), Delete: true},
+			},
+			expectedRoot: "5991bb8c6514148a29db676a14ac506cd2cd5775ace63c30a4fe457715e9ac84",
+		},
+		{
+			// The state root of the C-Chain's genesis block on Mainnet.
+			name: "C-Chain genesis state",
+			ops: []database.BatchOp{
+				{
+					Key:   crypto.Keccak256(common.HexToAddress("0x0100000000000000000000000000000000000000").Bytes()),
+					Value: cChainGenesisAccount,
+				},
+			},
+			expectedRoot: "d65eb1b8604a7aa497d41cd6372663785a5f809a17bd192edb86658ef24e29cc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			db, err := newDB(context.Background(), memdb.New(), newEthereumConfig())
+			require.NoError(err)
+
+			// Apply the operations one at a time so that intermediate tries
+			// are hashed too.
+			for _, op := range tt.ops {
+				if op.Delete {
+					require.NoError(db.Delete(op.Key))
+				} else {
+					require.NoError(db.Put(op.Key, op.Value))
+				}
+			}
+
+			root, err := db.GetMerkleRoot(context.Background())
+			require.NoError(err)
+			require.Equal(tt.expectedRoot, hex.EncodeToString(root[:]))
+		})
+	}
+}
+
+func TestEthereumHasherInvalidBranchFactor(t *testing.T) {
+	config := newEthereumConfig()
+	config.BranchFactor = BranchFactor256
+	_, err := newDB(context.Background(), memdb.New(), config)
+	require.ErrorIs(t, err, ErrEthereumHasherBranchFactor)
+}
+
+// Returns the root Ethereum calculates for [kvs].
+func getEthereumRoot(t *testing.T, kvs map[string][]byte) ids.ID {
+	ethTrie := trie.NewEmpty(triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	for key, value := range kvs {
+		require.NoError(t, ethTrie.Update([]byte(key), value))
+	}
+	return ids.ID(ethTrie.Hash())
+}
+
+// Returns a random key/value pair. Keys and values are short so that the trie
+// contains nodes which are embedded in their parents.
+func newRandomEthereumKeyValue(r *rand.Rand) ([]byte, []byte) {
+	var key []byte
+	if r.Intn(2) == 0 { // #nosec G404
+		// Small alphabets result in long shared prefixes.
+		key = make([]byte, 1+r.Intn(6)) // #nosec G404
+		for i := range key {
+			key[i] = byte(r.Intn(4)) // #nosec G404
+		}
+	} else {
+		key = make([]byte, common.HashLength)
+		_, _ = r.Read(key) // #nosec G404
+	}
+
+	value := make([]byte, 1+r.Intn(40)) // #nosec G404
+	_, _ = r.Read(value)                // #nosec G404
+	return key, value
+}
+
+func TestEthereumHasherMatchesEthereum(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		require := require.New(t)
+		r := rand.New(rand.NewSource(seed)) // #nosec G404
+
+		baseDB := memdb.New()
+		config := newEthereumConfig()
+		db, err := newDB(context.Background(), baseDB, config)
+		require.NoError(err)
+
+		var (
+			expected = map[string][]byte{}
+			inserted [][]byte
+		)
+		for batch := 0; batch < 20; batch++ {
+			ops := make([]database.BatchOp, 0, 20)
+			for i := 0; i < 20; i++ {
+				if len(inserted) > 0 && r.Intn(3) == 0 { // #nosec G404
+					key := inserted[r.Intn(len(inserted))] // #nosec G404
+					ops = append(ops, database.BatchOp{Key: key, Delete: true})
+					delete(expected, string(key))
+					continue
+				}
+
+				key, value := newRandomEthereumKeyValue(r)
+				ops = append(ops, database.BatchOp{Key: key, Value: value})
+				expected[string(key)] = value
+				inserted = append(inserted, key)
+			}
+
+			view, err := db.NewView(context.Background(), ViewChanges{BatchOps: ops})
+			require.NoError(err)
+			require.NoError(view.CommitToDB(context.Background()))
+
+			root, err := db.GetMerkleRoot(context.Background())
+			require.NoError(err)
+			if len(expected) == 0 {
+				require.Equal(ids.Empty, root)
+			} else {
+				require.Equal(getEthereumRoot(t, expected), root)
+			}
+
+			// Make sure that embedded children are persisted correctly.
+			if batch%5 == 4 {
+				require.NoError(db.Close())
+				config.Reg = prometheus.NewRegistry()
+				db, err = newDB(context.Background(), baseDB, config)
+				require.NoError(err)
+
+				reopenedRoot, err := db.GetMerkleRoot(context.Background())
+				require.NoError(err)
+				require.Equal(root, reopenedRoot)
+			}
+		}
+	}
+}
+
+func TestEthereumProof(t *testing.T) {
+	require := require.New(t)
+	r := rand.New(rand.NewSource(0)) // #nosec G404
+
+	db, err := newDB(context.Background(), memdb.New(), newEthereumConfig())
+	require.NoError(err)
+
+	_, err = db.GetEthereumProof(context.Background(), []byte{0})
+	require.ErrorIs(err, ErrEmptyProof)
+
+	expected := map[string][]byte{}
+	ops := make([]database.BatchOp, 0, 500)
+	for i := 0; i < 500; i++ {
+		key, value := newRandomEthereumKeyValue(r)
+		ops = append(ops, database.BatchOp{Key: key, Value: value})
+		expected[string(key)] = value
+	}
+	view, err := db.NewView(context.Background(), ViewChanges{BatchOps: ops})
+	require.NoError(err)
+
+	root, err := view.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(getEthereumRoot(t, expected), root)
+
+	keys := make([][]byte, 0, 2*len(expected))
+	for key := range expected {
+		keys = append(keys, []byte(key))
+	}
+	for i := 0; i < len(expected); i++ {
+		key, _ := newRandomEthereumKeyValue(r)
+		keys = append(keys, key)
+	}
+
+	verifyProofs := func(tr Trie) {
+		for _, key := range keys {
+			proof, err := tr.GetEthereumProof(context.Background(), key)
+			require.NoError(err)
+
+			proofDB := memorydb.New()
+			for _, proofNode := range proof {
+				require.NoError(proofDB.Put(crypto.Keccak256(proofNode), proofNode))
+			}
+			value, err := trie.VerifyProof(common.Hash(root), key, proofDB)
+			require.NoError(err)
+			require.Equal(expected[string(key)], value)
+		}
+	}
+	verifyProofs(view)
+
+	require.NoError(view.CommitToDB(context.Background()))
+	verifyProofs(db)
+}
+
+func TestEthereumProofNotEthereumHasher(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+	writeBasicBatch(t, db)
+
+	_, err = db.GetEthereumProof(context.Background(), []byte{0})
+	require.ErrorIs(err, ErrNotEthereumHasher)
+
+	view, err := db.NewView(context.Background(), ViewChanges{})
+	require.NoError(err)
+	_, err = view.GetEthereumProof(context.Background(), []byte{0})
+	require.ErrorIs(err, ErrNotEthereumHasher)
+}
+
+func TestHexPrefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     Key
+		isLeaf   bool
+		expected []byte
+	}{
+		{
+			name:     "empty extension",
+			path:     Key{},
+			expected: []byte{0x00},
+		},
+		{
+			name:     "empty leaf",
+			path:     Key{},
+			isLeaf:   true,
+			expected: []byte{0x20},
+		},
+		{
+			name:     "odd extension",
+			path:     ToKey([]byte{0x12}).Skip(4),
+			expected: []byte{0x12},
+		},
+		{
+			name:     "odd leaf",
+			path:     ToKey([]byte{0x12, 0x34}).Skip(4),
+			isLeaf:   true,
+			expected: []byte{0x32, 0x34},
+		},
+		{
+			name:     "even extension",
+			path:     ToKey([]byte{0x12, 0x34}),
+			expected: []byte{0x00, 0x12, 0x34},
+		},
+		{
+			name:     "even leaf",
+			path:     ToKey([]byte{0x12, 0x34}).Take(8),
+			isLeaf:   true,
+			expected: []byte{0x20, 0x12},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, hexPrefix(tt.path, tt.isLeaf))
+		})
+	}
+}
+
+func TestEthereumHasherEmbeddedChild(t *testing.T) {
+	require := require.New(t)
+
+	// The leaf for each key is shorter than [HashLength], so it's embedded in
+	// the root branch node.
+	n := newNode(Key{})
+	for i := byte(0); i < 2; i++ {
+		childNode := newNode(ToKey([]byte{i << 4}))
+		childNode.setValue(EthereumHasher, maybe.Some([]byte{i}))
+		n.addChild(childNode, 4)
+		hashChild(EthereumHasher, n.children[i], childNode)
+
+		entry := n.children[i]
+		require.True(entry.embedded)
+		encoding, _ := encodeEthereumNode(childNode, entry.compressedKey)
+		require.Less(len(encoding), HashLength)
+		require.Equal(encoding, entry.id[:len(encoding)])
+	}
+
+	expected := getEthereumRoot(t, map[string][]byte{
+		string([]byte{0x00}): {0},
+		string([]byte{0x10}): {1},
+	})
+	require.Equal(expected, hashRoot(EthereumHasher, n))
+}
@@ -0,0 +1,588 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+const (
+	// FsckMissingNode means that a node is referenced by its parent, or is
+	// the root, but isn't in the database.
+	FsckMissingNode FsckIssueType = iota + 1
+	// FsckCorruptNode means that a node couldn't be parsed, or is stored in
+	// the wrong database.
+	FsckCorruptNode
+	// FsckHashMismatch means that the hash of a node doesn't match the ID its
+	// parent references.
+	FsckHashMismatch
+	// FsckUnreferencedValue means that a value node isn't part of the trie.
+	FsckUnreferencedValue
+)
+
+var errUnknownFsckIssueType = errors.New("unknown fsck issue type")
+
+type FsckIssueType int
+
+func (t FsckIssueType) String() string {
+	switch t {
+	case FsckMissingNode:
+		return "missing node"
+	case FsckCorruptNode:
+		return "corrupt node"
+	case FsckHashMismatch:
+		return "hash mismatch"
+	case FsckUnreferencedValue:
+		return "unreferenced value"
+	default:
+		return errUnknownFsckIssueType.Error()
+	}
+}
+
+type FsckIssue struct {
+	Type FsckIssueType
+	// Key of the node with the issue.
+	Key Key
+}
+
+func (i FsckIssue) String() string {
+	return fmt.Sprintf("%s at key %x (%d bits)", i.Type, i.Key.Bytes(), i.Key.Length())
+}
+
+type FsckReport struct {
+	// CleanShutdown is false if the database wasn't closed cleanly. In that
+	// case the intermediate nodes are expected to be incomplete, and they
+	// will be rebuilt the next time the database is opened.
+	CleanShutdown bool
+	// NumNodes is the number of valid nodes in the trie.
+	NumNodes uint64
+	// NumValues is the number of valid value nodes in the trie.
+	NumValues uint64
+	// Issues found while walking the trie, in key order.
+	Issues []FsckIssue
+	// RebuiltSubtrees are the keys of the subtrees that were rebuilt.
+	RebuiltSubtrees []Key
+	// RebuiltTrie is true if the entire trie was rebuilt. This happens if an
+	// issue couldn't be repaired by rebuilding a subtree, for example because
+	// the value nodes don't match the trie. The root ID may have changed.
+	RebuiltTrie bool
+}
+
+// Fsck walks the trie stored in [db] from its root, checks that every node can
+// be parsed and hashes to the ID referenced by its parent, and checks that
+// every value node is part of the trie.
+//
+// If [repair] is true, the subtrees containing the issues are rebuilt from the
+// value nodes. If the rebuilt subtree doesn't match the ID referenced by its
+// parent, the parent's subtree is rebuilt instead. If the issue reaches the
+// root, the entire trie is rebuilt.
+//
+// [db] must not be in use by a MerkleDB. [config] must be the config [db] is
+// opened with. Subtrees are rebuilt in memory.
+func Fsck(ctx context.Context, db database.Database, config Config, repair bool) (*FsckReport, error) {
+	if err := config.BranchFactor.Valid(); err != nil {
+		return nil, err
+	}
+
+	hasher := config.Hasher
+	if hasher == nil {
+		hasher = DefaultHasher
+	}
+	tokenSize := BranchFactorToTokenSize[config.BranchFactor]
+
+	shutdownType, err := db.Get(cleanShutdownKey)
+	switch err {
+	case nil:
+	case database.ErrNotFound:
+		shutdownType = hadCleanShutdown
+	default:
+		return nil, err
+	}
+
+	f := &fsck{
+		baseDB:    db,
+		config:    config,
+		hasher:    hasher,
+		tokenSize: tokenSize,
+		intermediateNodeDB: newIntermediateNodeDB(
+			db,
+			utils.NewBytesPool(),
+			&mockMetrics{},
+			0,
+			0,
+			0,
+			tokenSize,
+			hasher,
+		),
+		report: &FsckReport{
+			CleanShutdown: bytes.Equal(shutdownType, hadCleanShutdown),
+		},
+	}
+	if err := f.check(ctx); err != nil {
+		return nil, err
+	}
+	if repair {
+		if err := f.repair(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return f.report, nil
+}
+
+// fsckNode is a node of the trie that is expected to exist.
+type fsckNode struct {
+	key      Key
+	hasValue bool
+	// The ID referenced by [parent].
+	// Only set if [parent] is non-nil.
+	id     ids.ID
+	parent *fsckNode
+}
+
+type fsck struct {
+	baseDB             database.Database
+	config             Config
+	hasher             Hasher
+	tokenSize          int
+	intermediateNodeDB *intermediateNodeDB
+
+	// Iterates over the value nodes in [baseDB] in key order.
+	valueIt database.Iterator
+	// The key [valueIt] is at.
+	// Only set if [hasValueKey] is true.
+	valueKey    Key
+	hasValueKey bool
+
+	// The nodes whose subtrees must be rebuilt.
+	// A nil node means that the entire trie must be rebuilt.
+	toRebuild []*fsckNode
+
+	report *FsckReport
+}
+
+// check walks the trie in key order and compares the value nodes it
+// references to the value nodes in the database.
+func (f *fsck) check(ctx context.Context) error {
+	f.valueIt = f.baseDB.NewIteratorWithPrefix(valueNodePrefix)
+	defer f.valueIt.Release()
+
+	if err := f.nextValue(); err != nil {
+		return err
+	}
+
+	root, err := f.getRoot()
+	if err != nil {
+		return err
+	}
+
+	stack := make([]*fsckNode, 0, defaultPreallocationSize)
+	if root != nil {
+		stack = append(stack, root)
+	}
+	for len(stack) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		// Value nodes before [current] aren't in the trie.
+		for f.hasValueKey && f.valueKey.Less(current.key) {
+			f.addUnreferencedValue(current.parent)
+			if err := f.nextValue(); err != nil {
+				return err
+			}
+		}
+
+		n, issueType, err := f.getNode(current)
+		if err != nil {
+			return err
+		}
+		if issueType == 0 && current.parent != nil {
+			entry := child{
+				compressedKey: current.key.Skip(current.parent.key.length + f.tokenSize),
+			}
+			hashChild(f.hasher, &entry, n)
+			if entry.id != current.id {
+				issueType = FsckHashMismatch
+			}
+		}
+		if issueType != 0 {
+			f.report.Issues = append(f.report.Issues, FsckIssue{
+				Type: issueType,
+				Key:  current.key,
+			})
+			f.toRebuild = append(f.toRebuild, current)
+
+			// The subtree will be rebuilt, so the value nodes in it aren't
+			// checked.
+			for f.hasValueKey && f.valueKey.HasPrefix(current.key) {
+				if err := f.nextValue(); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		f.report.NumNodes++
+		if f.hasValueKey && f.valueKey == current.key {
+			if current.hasValue {
+				f.report.NumValues++
+			} else {
+				f.addUnreferencedValue(current)
+			}
+			if err := f.nextValue(); err != nil {
+				return err
+			}
+		}
+
+		// Push the children in reverse order so that they're visited in key
+		// order.
+		indices := make([]byte, 0, len(n.children))
+		for index := range n.children {
+			indices = append(indices, index)
+		}
+		slices.Sort(indices)
+		for i := len(indices) - 1; i >= 0; i-- {
+			index := indices[i]
+			entry := n.children[index]
+			stack = append(stack, &fsckNode{
+				key:      n.key.Extend(ToToken(index, f.tokenSize), entry.compressedKey),
+				hasValue: entry.hasValue,
+				id:       entry.id,
+				parent:   current,
+			})
+		}
+	}
+
+	// Value nodes after the last node aren't in the trie.
+	for f.hasValueKey {
+		f.addUnreferencedValue(root)
+		if err := f.nextValue(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getRoot returns the root of the trie, or nil if the trie is empty.
+func (f *fsck) getRoot() (*fsckNode, error) {
+	rootKeyBytes, err := f.baseDB.Get(rootDBKey)
+	if err == database.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rootKey, err := decodeKey(rootKeyBytes)
+	if err != nil {
+		f.report.Issues = append(f.report.Issues, FsckIssue{
+			Type: FsckCorruptNode,
+		})
+		f.toRebuild = append(f.toRebuild, nil)
+		return nil, nil
+	}
+
+	// The root may be an intermediate node or a value node. Only keys with a
+	// whole number of bytes can have values.
+	hasValue := false
+	if !rootKey.hasPartialByte() {
+		dbKey := f.intermediateNodeDB.constructDBKey(rootKey)
+		isIntermediate, err := f.baseDB.Has(*dbKey)
+		f.intermediateNodeDB.bufferPool.Put(dbKey)
+		if err != nil {
+			return nil, err
+		}
+		hasValue = !isIntermediate
+	}
+	return &fsckNode{
+		key:      rootKey,
+		hasValue: hasValue,
+	}, nil
+}
+
+// getNode returns the node [n] refers to. If the node can't be loaded, the
+// type of issue is returned.
+func (f *fsck) getNode(n *fsckNode) (*node, FsckIssueType, error) {
+	var (
+		nodeBytes []byte
+		err       error
+	)
+	if n.hasValue {
+		nodeBytes, err = f.baseDB.Get(f.valueNodeDBKey(n.key))
+	} else {
+		dbKey := f.intermediateNodeDB.constructDBKey(n.key)
+		nodeBytes, err = f.baseDB.Get(*dbKey)
+		f.intermediateNodeDB.bufferPool.Put(dbKey)
+	}
+	if err == database.ErrNotFound {
+		return nil, FsckMissingNode, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result, err := parseNode(f.hasher, n.key, nodeBytes)
+	if err != nil || result.hasValue() != n.hasValue {
+		return nil, FsckCorruptNode, nil
+	}
+	return result, 0, nil
+}
+
+func (f *fsck) valueNodeDBKey(key Key) []byte {
+	dbKey := make([]byte, len(valueNodePrefix)+len(key.Bytes()))
+	copy(dbKey, valueNodePrefix)
+	copy(dbKey[len(valueNodePrefix):], key.Bytes())
+	return dbKey
+}
+
+func (f *fsck) nextValue() error {
+	f.hasValueKey = f.valueIt.Next()
+	if !f.hasValueKey {
+		return f.valueIt.Error()
+	}
+	f.valueKey = ToKey(f.valueIt.Key()[valueNodePrefixLen:])
+	return nil
+}
+
+// addUnreferencedValue reports the value node at [f.valueKey]. The subtree of
+// the closest ancestor of the value node, starting from [n], is rebuilt.
+func (f *fsck) addUnreferencedValue(n *fsckNode) {
+	f.report.Issues = append(f.report.Issues, FsckIssue{
+		Type: FsckUnreferencedValue,
+		Key:  f.valueKey,
+	})
+	for n != nil && !f.valueKey.HasPrefix(n.key) {
+		n = n.parent
+	}
+	f.toRebuild = append(f.toRebuild, n)
+}
+
+// repair rebuilds the subtrees containing issues.
+func (f *fsck) repair(ctx context.Context) error {
+	rebuildTrie := false
+	for _, n := range f.toRebuild {
+		if n == nil {
+			rebuildTrie = true
+			break
+		}
+		if f.isRebuilt(n.key) {
+			continue
+		}
+
+		rebuilt, err := f.repairSubtree(ctx, n)
+		if err != nil {
+			return err
+		}
+		if !rebuilt {
+			rebuildTrie = true
+			break
+		}
+	}
+	if !rebuildTrie {
+		return nil
+	}
+
+	// Opening a database that wasn't shutdown cleanly rebuilds the
+	// intermediate nodes from the value nodes.
+	if err := f.baseDB.Put(cleanShutdownKey, didNotHaveCleanShutdown); err != nil {
+		return err
+	}
+	db, err := newDatabase(ctx, f.baseDB, f.config, &mockMetrics{})
+	if err != nil {
+		return err
+	}
+	f.report.RebuiltTrie = true
+	return db.Close()
+}
+
+// isRebuilt returns true if [key] is in a subtree that was rebuilt.
+func (f *fsck) isRebuilt(key Key) bool {
+	for _, rebuiltKey := range f.report.RebuiltSubtrees {
+		if key.HasPrefix(rebuiltKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// repairSubtree rebuilds the subtree of [n]. If the rebuilt subtree doesn't
+// match the ID referenced by its parent, the parent's subtree is rebuilt
+// instead. Returns false if the root was reached.
+func (f *fsck) repairSubtree(ctx context.Context, n *fsckNode) (bool, error) {
+	for ; n.parent != nil; n = n.parent {
+		rebuilt, err := f.rebuildSubtree(ctx, n)
+		if err != nil {
+			return false, err
+		}
+		if rebuilt {
+			f.report.RebuiltSubtrees = append(f.report.RebuiltSubtrees, n.key)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// rebuildSubtree builds the subtree of [n] from the value nodes with [n.key]
+// as a prefix. If the rebuilt subtree matches the ID referenced by the parent
+// of [n], it replaces the subtree in [f.baseDB] and true is returned.
+func (f *fsck) rebuildSubtree(ctx context.Context, n *fsckNode) (bool, error) {
+	config := f.config
+	config.HistoryLength = 0
+	config.HistoryDiskLength = 0
+	config.TraceLevel = NoTrace
+
+	scratchDB := memdb.New()
+	subtree, err := newDatabase(ctx, scratchDB, config, &mockMetrics{})
+	if err != nil {
+		return false, err
+	}
+
+	opsSizeLimit := max(
+		int(f.config.ValueNodeCacheSize)/rebuildViewSizeFractionOfCacheSize,
+		minRebuildViewSizePerCommit,
+	)
+	ops := make([]database.BatchOp, 0, opsSizeLimit)
+	commit := func() error {
+		view, err := newView(subtree, subtree, ViewChanges{BatchOps: ops, ConsumeBytes: true})
+		if err != nil {
+			return err
+		}
+		ops = make([]database.BatchOp, 0, opsSizeLimit)
+		return view.commitToDB(ctx)
+	}
+
+	it := f.baseDB.NewIteratorWithPrefix(f.valueNodeDBKey(n.key.Take(n.key.Length() / 8 * 8)))
+	defer it.Release()
+
+	for it.Next() {
+		key := ToKey(it.Key()[valueNodePrefixLen:])
+		if !key.HasPrefix(n.key) {
+			continue
+		}
+
+		var dbNode dbNode
+		if err := decodeDBNode(it.Value(), &dbNode); err != nil || dbNode.value.IsNothing() {
+			// An invalid value node can't be rebuilt.
+			return false, nil
+		}
+		ops = append(ops, database.BatchOp{
+			Key:   key.Bytes(),
+			Value: dbNode.value.Value(),
+		})
+		if len(ops) >= opsSizeLimit {
+			if err := commit(); err != nil {
+				return false, err
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return false, err
+	}
+	if err := commit(); err != nil {
+		return false, err
+	}
+
+	root := subtree.root
+	matches := root.HasValue() &&
+		root.Value().key == n.key &&
+		root.Value().hasValue() == n.hasValue &&
+		subtree.rootID == n.id
+	if err := subtree.Close(); err != nil {
+		return false, err
+	}
+	if !matches {
+		return false, nil
+	}
+	return true, f.replaceSubtree(n.key, scratchDB)
+}
+
+// replaceSubtree replaces the nodes with [key] as a prefix in [f.baseDB] with
+// the nodes in [subtreeDB].
+//
+// The nodes may be written in multiple batches, so the database is marked as
+// not having been shutdown cleanly until the last batch is written. If the
+// replacement is interrupted, the intermediate nodes are rebuilt the next time
+// the database is opened.
+func (f *fsck) replaceSubtree(key Key, subtreeDB database.Database) error {
+	if err := f.baseDB.Put(cleanShutdownKey, didNotHaveCleanShutdown); err != nil {
+		return err
+	}
+
+	batch := f.baseDB.NewBatch()
+
+	// The intermediate nodes of the subtree may not be the same after the
+	// rebuild, so they're all removed.
+	dbKey := f.intermediateNodeDB.constructDBKey(key.Take(key.Length() / 8 * 8))
+	prefix := slices.Clone((*dbKey)[:len(intermediateNodePrefix)+key.Length()/8])
+	f.intermediateNodeDB.bufferPool.Put(dbKey)
+
+	it := f.baseDB.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	for it.Next() {
+		nodeKey, err := f.intermediateNodeDB.parseDBKey(it.Key())
+		if err != nil || nodeKey.HasPrefix(key) {
+			if err := batch.Delete(it.Key()); err != nil {
+				return err
+			}
+		}
+		if err := writeBatchIfFull(batch); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	for _, nodePrefix := range [][]byte{intermediateNodePrefix, valueNodePrefix} {
+		subtreeIt := subtreeDB.NewIteratorWithPrefix(nodePrefix)
+		for subtreeIt.Next() {
+			if err := batch.Put(subtreeIt.Key(), subtreeIt.Value()); err != nil {
+				subtreeIt.Release()
+				return err
+			}
+			if err := writeBatchIfFull(batch); err != nil {
+				subtreeIt.Release()
+				return err
+			}
+		}
+		err := subtreeIt.Error()
+		subtreeIt.Release()
+		if err != nil {
+			return err
+		}
+	}
+
+	// If the database wasn't shutdown cleanly before the repair, its
+	// intermediate nodes must still be rebuilt.
+	if f.report.CleanShutdown {
+		if err := batch.Put(cleanShutdownKey, hadCleanShutdown); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+func writeBatchIfFull(batch database.Batch) error {
+	if batch.Size() < clearBatchSize {
+		return nil
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	batch.Reset()
+	return nil
+}
@@ -0,0 +1,257 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+)
+
+// newFsckTestDB returns a closed database containing [numKeys] keys and its
+// root.
+func newFsckTestDB(t *testing.T, config Config, numKeys int) (database.Database, ids.ID) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := newDatabase(context.Background(), baseDB, config, &mockMetrics{})
+	require.NoError(err)
+
+	batch := db.NewBatch()
+	for i := 0; i < numKeys; i++ {
+		key := hashing.ComputeHash256([]byte(strconv.Itoa(i)))
+		require.NoError(batch.Put(key, key))
+	}
+	require.NoError(batch.Write())
+
+	root := db.getMerkleRoot()
+	require.NoError(db.Close())
+	return baseDB, root
+}
+
+// getIntermediateNodeDBKey returns the database key of a non-root
+// intermediate node and its key.
+func getIntermediateNodeDBKey(t *testing.T, baseDB database.Database, config Config) ([]byte, Key) {
+	require := require.New(t)
+
+	rootKeyBytes, err := baseDB.Get(rootDBKey)
+	require.NoError(err)
+	rootKey, err := decodeKey(rootKeyBytes)
+	require.NoError(err)
+
+	intermediateNodeDB := newIntermediateNodeDB(
+		baseDB,
+		utils.NewBytesPool(),
+		&mockMetrics{},
+		0,
+		0,
+		0,
+		BranchFactorToTokenSize[config.BranchFactor],
+		DefaultHasher,
+	)
+
+	it := baseDB.NewIteratorWithPrefix(intermediateNodePrefix)
+	defer it.Release()
+
+	for it.Next() {
+		key, err := intermediateNodeDB.parseDBKey(it.Key())
+		require.NoError(err)
+		if key != rootKey {
+			return it.Key(), key
+		}
+	}
+	require.FailNow("no intermediate node found")
+	return nil, Key{}
+}
+
+// reopenRoot opens [baseDB] and returns its root.
+func reopenRoot(t *testing.T, baseDB database.Database, config Config) ids.ID {
+	require := require.New(t)
+
+	db, err := newDatabase(context.Background(), baseDB, config, &mockMetrics{})
+	require.NoError(err)
+	root := db.getMerkleRoot()
+	require.NoError(db.Close())
+	return root
+}
+
+func TestFsck(t *testing.T) {
+	tests := []struct {
+		name            string
+		corrupt         func(t *testing.T, baseDB database.Database, config Config) Key
+		expectedType    FsckIssueType
+		rebuildsSubtree bool
+	}{
+		{
+			name: "missing node",
+			corrupt: func(t *testing.T, baseDB database.Database, config Config) Key {
+				dbKey, key := getIntermediateNodeDBKey(t, baseDB, config)
+				require.NoError(t, baseDB.Delete(dbKey))
+				return key
+			},
+			expectedType:    FsckMissingNode,
+			rebuildsSubtree: true,
+		},
+		{
+			name: "corrupt node",
+			corrupt: func(t *testing.T, baseDB database.Database, config Config) Key {
+				dbKey, key := getIntermediateNodeDBKey(t, baseDB, config)
+				require.NoError(t, baseDB.Put(dbKey, []byte{0xFF}))
+				return key
+			},
+			expectedType:    FsckCorruptNode,
+			rebuildsSubtree: true,
+		},
+		{
+			name: "hash mismatch",
+			corrupt: func(t *testing.T, baseDB database.Database, config Config) Key {
+				dbKey, key := getIntermediateNodeDBKey(t, baseDB, config)
+				nodeBytes, err := baseDB.Get(dbKey)
+				require.NoError(t, err)
+
+				n, err := parseNode(DefaultHasher, key, nodeBytes)
+				require.NoError(t, err)
+				for _, entry := range n.children {
+					entry.id = ids.GenerateTestID()
+					break
+				}
+				require.NoError(t, baseDB.Put(dbKey, n.bytes()))
+				return key
+			},
+			expectedType:    FsckHashMismatch,
+			rebuildsSubtree: true,
+		},
+		{
+			name: "unreferenced value",
+			corrupt: func(t *testing.T, baseDB database.Database, _ Config) Key {
+				key := ToKey([]byte("unreferenced"))
+				n := newNode(key)
+				n.setValue(DefaultHasher, maybe.Some([]byte("value")))
+				require.NoError(t, baseDB.Put(append(valueNodePrefix, key.Bytes()...), n.bytes()))
+				return key
+			},
+			expectedType:    FsckUnreferencedValue,
+			rebuildsSubtree: false,
+		},
+	}
+	for _, bf := range validBranchFactors {
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("%s branch factor %d", test.name, bf), func(t *testing.T) {
+				require := require.New(t)
+
+				config := newDefaultConfig()
+				config.BranchFactor = bf
+				config.Reg = prometheus.NewRegistry()
+
+				baseDB, root := newFsckTestDB(t, config, 1_000)
+
+				report, err := Fsck(context.Background(), baseDB, config, false)
+				require.NoError(err)
+				require.True(report.CleanShutdown)
+				require.Empty(report.Issues)
+				require.Equal(uint64(1_000), report.NumValues)
+
+				badKey := test.corrupt(t, baseDB, config)
+
+				report, err = Fsck(context.Background(), baseDB, config, false)
+				require.NoError(err)
+				require.Equal([]FsckIssue{{Type: test.expectedType, Key: badKey}}, report.Issues)
+				require.Empty(report.RebuiltSubtrees)
+				require.False(report.RebuiltTrie)
+
+				report, err = Fsck(context.Background(), baseDB, config, true)
+				require.NoError(err)
+				require.Len(report.Issues, 1)
+				if test.rebuildsSubtree {
+					require.Equal([]Key{badKey}, report.RebuiltSubtrees)
+					require.False(report.RebuiltTrie)
+				} else {
+					require.Empty(report.RebuiltSubtrees)
+					require.True(report.RebuiltTrie)
+				}
+
+				report, err = Fsck(context.Background(), baseDB, config, false)
+				require.NoError(err)
+				require.True(report.CleanShutdown)
+				require.Empty(report.Issues)
+
+				newRoot := reopenRoot(t, baseDB, config)
+				if test.rebuildsSubtree {
+					require.Equal(root, newRoot)
+				} else {
+					require.NotEqual(root, newRoot)
+				}
+			})
+		}
+	}
+}
+
+func TestFsckMissingRoot(t *testing.T) {
+	require := require.New(t)
+
+	config := newDefaultConfig()
+	baseDB, root := newFsckTestDB(t, config, 100)
+
+	rootKeyBytes, err := baseDB.Get(rootDBKey)
+	require.NoError(err)
+	rootKey, err := decodeKey(rootKeyBytes)
+	require.NoError(err)
+
+	intermediateNodeDB := newIntermediateNodeDB(
+		baseDB,
+		utils.NewBytesPool(),
+		&mockMetrics{},
+		0,
+		0,
+		0,
+		BranchFactorToTokenSize[config.BranchFactor],
+		DefaultHasher,
+	)
+	dbKey := intermediateNodeDB.constructDBKey(rootKey)
+	require.NoError(baseDB.Delete(*dbKey))
+
+	report, err := Fsck(context.Background(), baseDB, config, true)
+	require.NoError(err)
+	require.Equal([]FsckIssue{{Type: FsckMissingNode, Key: rootKey}}, report.Issues)
+	require.True(report.RebuiltTrie)
+
+	require.Equal(root, reopenRoot(t, baseDB, config))
+}
+
+func TestFsckInterruptedRepair(t *testing.T) {
+	require := require.New(t)
+
+	config := newDefaultConfig()
+	baseDB, root := newFsckTestDB(t, config, 1_000)
+
+	dbKey, _ := getIntermediateNodeDBKey(t, baseDB, config)
+	require.NoError(baseDB.Delete(dbKey))
+
+	// The replaced subtree is never written.
+	_, err := Fsck(context.Background(), &failingDB{Database: baseDB}, config, true)
+	require.ErrorIs(err, errTestWriteFailed)
+
+	shutdownType, err := baseDB.Get(cleanShutdownKey)
+	require.NoError(err)
+	require.Equal(didNotHaveCleanShutdown, shutdownType)
+
+	// The intermediate nodes are rebuilt when the database is opened.
+	require.Equal(root, reopenRoot(t, baseDB, config))
+
+	report, err := Fsck(context.Background(), baseDB, config, false)
+	require.NoError(err)
+	require.True(report.CleanShutdown)
+	require.Empty(report.Issues)
+}
@@ -29,6 +29,33 @@ type Hasher interface {
 	HashValue(value []byte) ids.ID
 }
 
+// pathHasher is implemented by hashers whose node IDs depend on the node's
+// compressed key relative to its parent, rather than only on the node itself.
+type pathHasher interface {
+	Hasher
+	// Returns the ID of the root node [root].
+	hashRoot(root *node) ids.ID
+	// Sets the ID of [entry], which is the child entry of [childNode].
+	hashChild(entry *child, childNode *node)
+}
+
+// Returns the ID of the root node [root].
+func hashRoot(hasher Hasher, root *node) ids.ID {
+	if h, ok := hasher.(pathHasher); ok {
+		return h.hashRoot(root)
+	}
+	return hasher.HashNode(root)
+}
+
+// Sets the ID of [entry], which is the child entry of [childNode].
+func hashChild(hasher Hasher, entry *child, childNode *node) {
+	if h, ok := hasher.(pathHasher); ok {
+		h.hashChild(entry, childNode)
+		return
+	}
+	entry.id = hasher.HashNode(childNode)
+}
+
 type sha256Hasher struct{}
 
 // This method is performance critical. It is not expected to perform any memory
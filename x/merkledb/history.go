@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/buffer"
@@ -35,6 +36,10 @@ type trieHistory struct {
 
 	// Each change is tagged with this monotonic increasing number.
 	nextInsertNumber uint64
+
+	// Persists changes beyond the in-memory history.
+	// Nil if changes aren't persisted.
+	disk *historyDB
 }
 
 // Tracks the beginning and ending state of a value.
@@ -87,6 +92,9 @@ func newTrieHistory(maxHistoryLookback int) *trieHistory {
 // to generate the proof.
 // Returns [ErrNoEndRoot], which wraps [ErrInsufficientHistory], if
 // the [endRoot] isn't in the history.
+//
+// The in-memory history is consulted first. If it is insufficient, the
+// changes are read from the persisted history, if any.
 func (th *trieHistory) getValueChanges(
 	startRoot ids.ID,
 	endRoot ids.ID,
@@ -102,6 +110,22 @@ func (th *trieHistory) getValueChanges(
 		return newChangeSummary(maxLength), nil
 	}
 
+	changes, err := th.getValueChangesFromMemory(startRoot, endRoot, start, end, maxLength)
+	if th.disk == nil || !errors.Is(err, ErrInsufficientHistory) {
+		return changes, err
+	}
+	return th.disk.getValueChanges(startRoot, endRoot, start, end, maxLength)
+}
+
+// Returns the value changes that occurred between [startRoot] and [endRoot]
+// using only the in-memory history.
+func (th *trieHistory) getValueChangesFromMemory(
+	startRoot ids.ID,
+	endRoot ids.ID,
+	start maybe.Maybe[[]byte],
+	end maybe.Maybe[[]byte],
+	maxLength int,
+) (*changeSummary, error) {
 	// [endRootChanges] is the last change in the history resulting in [endRoot].
 	endRootChanges, ok := th.lastChanges[endRoot]
 	if !ok {
@@ -182,38 +206,56 @@ func (th *trieHistory) getValueChanges(
 	// [endRootChanges], record the change in [combinedChanges].
 	for i := startRootIndex + 1; i <= endRootIndex; i++ {
 		changes, _ := th.history.Index(i)
+		addValueChanges(combinedChanges, changedKeys, changes.changeSummary, startKey, endKey)
+	}
+	trimValueChanges(combinedChanges, changedKeys, maxLength)
+	return combinedChanges, nil
+}
 
-		// Add the changes from this commit to [combinedChanges].
-		for key, valueChange := range changes.values {
-			// The key is outside the range [start, end].
-			if (startKey.HasValue() && key.Less(startKey.Value())) ||
-				(end.HasValue() && key.Greater(endKey.Value())) {
-				continue
-			}
+// Adds the changes to keys in [startKey, endKey] from [changes] to
+// [combinedChanges], which must contain only earlier changes.
+// If [startKey] is Nothing, there's no lower bound on the range.
+// If [endKey] is Nothing, there's no upper bound on the range.
+func addValueChanges(
+	combinedChanges *changeSummary,
+	changedKeys set.Set[Key],
+	changes *changeSummary,
+	startKey maybe.Maybe[Key],
+	endKey maybe.Maybe[Key],
+) {
+	for key, valueChange := range changes.values {
+		// The key is outside the range [start, end].
+		if (startKey.HasValue() && key.Less(startKey.Value())) ||
+			(endKey.HasValue() && key.Greater(endKey.Value())) {
+			continue
+		}
 
-			// A change to this key already exists in [combinedChanges]
-			// so update its before value with the earlier before value
-			if existing, ok := combinedChanges.values[key]; ok {
-				existing.after = valueChange.after
-				if existing.before.HasValue() == existing.after.HasValue() &&
-					bytes.Equal(existing.before.Value(), existing.after.Value()) {
-					// The change to this key is a no-op, so remove it from [combinedChanges].
-					delete(combinedChanges.values, key)
-					changedKeys.Remove(key)
-				}
-			} else {
-				combinedChanges.values[key] = &change[maybe.Maybe[[]byte]]{
-					before: valueChange.before,
-					after:  valueChange.after,
-				}
-				changedKeys.Add(key)
+		// A change to this key already exists in [combinedChanges]
+		// so update its before value with the earlier before value
+		if existing, ok := combinedChanges.values[key]; ok {
+			existing.after = valueChange.after
+			if existing.before.HasValue() == existing.after.HasValue() &&
+				bytes.Equal(existing.before.Value(), existing.after.Value()) {
+				// The change to this key is a no-op, so remove it from [combinedChanges].
+				delete(combinedChanges.values, key)
+				changedKeys.Remove(key)
 			}
+		} else {
+			combinedChanges.values[key] = &change[maybe.Maybe[[]byte]]{
+				before: valueChange.before,
+				after:  valueChange.after,
+			}
+			changedKeys.Add(key)
 		}
 	}
+}
 
+// Keeps only the changes to the smallest [maxLength] keys in
+// [combinedChanges].
+func trimValueChanges(combinedChanges *changeSummary, changedKeys set.Set[Key], maxLength int) {
 	// If we have <= [maxLength] elements, we're done.
 	if changedKeys.Len() <= maxLength {
-		return combinedChanges, nil
+		return
 	}
 
 	// Keep only the smallest [maxLength] items in [combinedChanges.values].
@@ -224,18 +266,22 @@ func (th *trieHistory) getValueChanges(
 		sortedChangedKeys = sortedChangedKeys[:len(sortedChangedKeys)-1]
 		delete(combinedChanges.values, greatestKey)
 	}
-
-	return combinedChanges, nil
 }
 
 // Returns the changes to go from the current trie state back to the requested [rootID]
 // for the keys in [start, end].
 // If [start] is Nothing, all keys are considered > [start].
 // If [end] is Nothing, all keys are considered < [end].
+//
+// The in-memory history is consulted first. If it doesn't contain [rootID],
+// the changes are read from the persisted history, if any.
 func (th *trieHistory) getChangesToGetToRoot(rootID ids.ID, start maybe.Maybe[[]byte], end maybe.Maybe[[]byte]) (*changeSummary, error) {
 	// [lastRootChange] is the last change in the history resulting in [rootID].
 	lastRootChange, ok := th.lastChanges[rootID]
 	if !ok {
+		if th.disk != nil {
+			return th.disk.getChangesToGetToRoot(rootID, start, end)
+		}
 		return nil, ErrInsufficientHistory
 	}
 
@@ -262,28 +308,50 @@ func (th *trieHistory) getChangesToGetToRoot(rootID ids.ID, start maybe.Maybe[[]
 			combinedChanges.rootChange.after = changes.rootChange.before
 		}
 
-		for key, changedNode := range changes.nodes {
-			combinedChanges.nodes[key] = &change[*node]{
-				after: changedNode.before,
-			}
+		addReversedChanges(combinedChanges, changes.changeSummary, startKey, endKey)
+	}
+
+	return combinedChanges, nil
+}
+
+// Adds the reverse of [changes] to [combinedChanges], which must contain only
+// the reverse of later changes. Only value changes to keys in
+// [startKey, endKey] are added.
+// If [startKey] is Nothing, all keys are considered > [startKey].
+// If [endKey] is Nothing, all keys are considered < [endKey].
+func addReversedChanges(
+	combinedChanges *changeSummary,
+	changes *changeSummary,
+	startKey maybe.Maybe[Key],
+	endKey maybe.Maybe[Key],
+) {
+	for key, changedNode := range changes.nodes {
+		combinedChanges.nodes[key] = &change[*node]{
+			after: changedNode.before,
 		}
+	}
 
-		for key, valueChange := range changes.values {
-			if (startKey.IsNothing() || !key.Less(startKey.Value())) &&
-				(endKey.IsNothing() || !key.Greater(endKey.Value())) {
-				if existing, ok := combinedChanges.values[key]; ok {
-					existing.after = valueChange.before
-				} else {
-					combinedChanges.values[key] = &change[maybe.Maybe[[]byte]]{
-						before: valueChange.after,
-						after:  valueChange.before,
-					}
+	for key, valueChange := range changes.values {
+		if (startKey.IsNothing() || !key.Less(startKey.Value())) &&
+			(endKey.IsNothing() || !key.Greater(endKey.Value())) {
+			if existing, ok := combinedChanges.values[key]; ok {
+				existing.after = valueChange.before
+			} else {
+				combinedChanges.values[key] = &change[maybe.Maybe[[]byte]]{
+					before: valueChange.after,
+					after:  valueChange.before,
 				}
 			}
 		}
 	}
+}
 
-	return combinedChanges, nil
+// persist writes [changes] to [batch] if changes are persisted.
+func (th *trieHistory) persist(batch database.KeyValueWriterDeleter, changes *changeSummary) error {
+	if th.disk == nil {
+		return nil
+	}
+	return th.disk.put(batch, changes)
 }
 
 // record the provided set of changes in the history
@@ -0,0 +1,452 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+const insertNumberLen = 8
+
+var (
+	errTooManyChanges = errors.New("too many changes")
+
+	// historyChangesPrefix + insert number --> encoded change summary
+	historyChangesPrefix = []byte(string(historyPrefix) + "changes")
+	// historyRootsPrefix + root ID + insert number --> nil
+	historyRootsPrefix = []byte(string(historyPrefix) + "roots")
+	// historyNextKey --> the insert number of the next change
+	historyNextKey = []byte(string(historyPrefix) + "next")
+)
+
+// historyDB persists the most recent changes to the trie so that change
+// proofs and historical views can be served after the in-memory history has
+// been exhausted or the database has been restarted.
+//
+// Changes are numbered independently of the in-memory history.
+type historyDB struct {
+	baseDB database.Database
+	hasher Hasher
+
+	// Maximum number of changes to store on disk.
+	maxHistoryLen uint64
+
+	// The insert number of the oldest change on disk.
+	oldestInsertNumber uint64
+
+	// The insert number that will be assigned to the next change.
+	nextInsertNumber uint64
+}
+
+func newHistoryDB(db database.Database, hasher Hasher, maxHistoryLen uint64) *historyDB {
+	return &historyDB{
+		baseDB:        db,
+		hasher:        hasher,
+		maxHistoryLen: maxHistoryLen,
+	}
+}
+
+// initialize loads the persisted history. If the persisted history doesn't
+// end in [rootID], for example because it was written while the history
+// wasn't being persisted, it's discarded.
+func (h *historyDB) initialize(root maybe.Maybe[*node], rootID ids.ID) error {
+	nextInsertNumber, err := database.GetUInt64(h.baseDB, historyNextKey)
+	if err == database.ErrNotFound {
+		return h.reset(root, rootID)
+	}
+	if err != nil {
+		return err
+	}
+
+	latestRootID, err := h.getRootID(nextInsertNumber - 1)
+	if err == database.ErrNotFound || (err == nil && latestRootID != rootID) {
+		return h.reset(root, rootID)
+	}
+	if err != nil {
+		return err
+	}
+
+	it := h.baseDB.NewIteratorWithPrefix(historyChangesPrefix)
+	defer it.Release()
+
+	if !it.Next() {
+		if err := it.Error(); err != nil {
+			return err
+		}
+		return h.reset(root, rootID)
+	}
+	h.oldestInsertNumber = binary.BigEndian.Uint64(it.Key()[len(historyChangesPrefix):])
+	h.nextInsertNumber = nextInsertNumber
+
+	// The retention may have been lowered since the history was written.
+	batch := h.baseDB.NewBatch()
+	if err := h.prune(batch); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// reset deletes the persisted history and records [rootID] as the only root
+// in the history.
+func (h *historyDB) reset(root maybe.Maybe[*node], rootID ids.ID) error {
+	if err := database.ClearPrefix(h.baseDB, historyPrefix, clearBatchSize); err != nil {
+		return err
+	}
+
+	h.oldestInsertNumber = 0
+	h.nextInsertNumber = 0
+
+	batch := h.baseDB.NewBatch()
+	err := h.put(batch, &changeSummary{
+		rootID: rootID,
+		rootChange: change[maybe.Maybe[*node]]{
+			before: root,
+			after:  root,
+		},
+		values: map[Key]*change[maybe.Maybe[[]byte]]{},
+		nodes:  map[Key]*change[*node]{},
+	})
+	if err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// put writes [changes] to [batch] as the most recent change and removes the
+// changes that fall outside of the retention.
+func (h *historyDB) put(batch database.KeyValueWriterDeleter, changes *changeSummary) error {
+	insertNumber := h.nextInsertNumber
+	if err := batch.Put(historyChangesKey(insertNumber), encodeChangeSummary(changes)); err != nil {
+		return err
+	}
+	if err := batch.Put(historyRootKey(changes.rootID, insertNumber), nil); err != nil {
+		return err
+	}
+
+	h.nextInsertNumber++
+	if err := database.PutUInt64(batch, historyNextKey, h.nextInsertNumber); err != nil {
+		return err
+	}
+	return h.prune(batch)
+}
+
+// prune removes the oldest changes until at most [h.maxHistoryLen] changes
+// remain.
+func (h *historyDB) prune(batch database.KeyValueWriterDeleter) error {
+	for h.nextInsertNumber-h.oldestInsertNumber > h.maxHistoryLen {
+		rootID, err := h.getRootID(h.oldestInsertNumber)
+		if err != nil {
+			return err
+		}
+		if err := batch.Delete(historyChangesKey(h.oldestInsertNumber)); err != nil {
+			return err
+		}
+		if err := batch.Delete(historyRootKey(rootID, h.oldestInsertNumber)); err != nil {
+			return err
+		}
+		h.oldestInsertNumber++
+	}
+	return nil
+}
+
+// getRootID returns the root ID resulting from the change with
+// [insertNumber].
+func (h *historyDB) getRootID(insertNumber uint64) (ids.ID, error) {
+	changesBytes, err := h.baseDB.Get(historyChangesKey(insertNumber))
+	if err != nil {
+		return ids.Empty, err
+	}
+	r := codecReader{b: changesBytes}
+	return r.ID()
+}
+
+// get returns the change with [insertNumber]. If [withNodes] is false, only
+// the root ID and the value changes are populated.
+func (h *historyDB) get(insertNumber uint64, withNodes bool) (*changeSummary, error) {
+	changesBytes, err := h.baseDB.Get(historyChangesKey(insertNumber))
+	if err != nil {
+		return nil, err
+	}
+	return decodeChangeSummary(h.hasher, changesBytes, withNodes)
+}
+
+// lastInsertNumber returns the greatest insert number, less than [before], of
+// a change resulting in [rootID].
+func (h *historyDB) lastInsertNumber(rootID ids.ID, before uint64) (uint64, bool, error) {
+	prefix := make([]byte, len(historyRootsPrefix)+ids.IDLen)
+	copy(prefix, historyRootsPrefix)
+	copy(prefix[len(historyRootsPrefix):], rootID[:])
+
+	it := h.baseDB.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	var (
+		lastInsertNumber uint64
+		found            bool
+	)
+	for it.Next() {
+		insertNumber := binary.BigEndian.Uint64(it.Key()[len(prefix):])
+		if insertNumber >= before {
+			break
+		}
+		lastInsertNumber = insertNumber
+		found = true
+	}
+	return lastInsertNumber, found, it.Error()
+}
+
+// See [trieHistory.getValueChanges].
+func (h *historyDB) getValueChanges(
+	startRoot ids.ID,
+	endRoot ids.ID,
+	start maybe.Maybe[[]byte],
+	end maybe.Maybe[[]byte],
+	maxLength int,
+) (*changeSummary, error) {
+	endInsertNumber, ok, err := h.lastInsertNumber(endRoot, h.nextInsertNumber)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoEndRoot, endRoot)
+	}
+
+	startInsertNumber, ok, err := h.lastInsertNumber(startRoot, endInsertNumber)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf(
+			"%w: start root %s not found before end root %s",
+			ErrInsufficientHistory, startRoot, endRoot,
+		)
+	}
+
+	var (
+		changedKeys     = set.Set[Key]{}
+		startKey        = maybe.Bind(start, ToKey)
+		endKey          = maybe.Bind(end, ToKey)
+		combinedChanges = newChangeSummary(maxLength)
+	)
+	for insertNumber := startInsertNumber + 1; insertNumber <= endInsertNumber; insertNumber++ {
+		changes, err := h.get(insertNumber, false)
+		if err != nil {
+			return nil, err
+		}
+		addValueChanges(combinedChanges, changedKeys, changes, startKey, endKey)
+	}
+	trimValueChanges(combinedChanges, changedKeys, maxLength)
+	return combinedChanges, nil
+}
+
+// See [trieHistory.getChangesToGetToRoot].
+func (h *historyDB) getChangesToGetToRoot(rootID ids.ID, start maybe.Maybe[[]byte], end maybe.Maybe[[]byte]) (*changeSummary, error) {
+	lastRootChangeInsertNumber, ok, err := h.lastInsertNumber(rootID, h.nextInsertNumber)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInsufficientHistory
+	}
+
+	var (
+		startKey                     = maybe.Bind(start, ToKey)
+		endKey                       = maybe.Bind(end, ToKey)
+		combinedChanges              = newChangeSummary(defaultPreallocationSize)
+		mostRecentChangeInsertNumber = h.nextInsertNumber - 1
+	)
+	for insertNumber := mostRecentChangeInsertNumber; insertNumber > lastRootChangeInsertNumber; insertNumber-- {
+		changes, err := h.get(insertNumber, true)
+		if err != nil {
+			return nil, err
+		}
+
+		if insertNumber == mostRecentChangeInsertNumber {
+			combinedChanges.rootChange.before = changes.rootChange.after
+		}
+		if insertNumber == lastRootChangeInsertNumber+1 {
+			combinedChanges.rootChange.after = changes.rootChange.before
+		}
+		addReversedChanges(combinedChanges, changes, startKey, endKey)
+	}
+	return combinedChanges, nil
+}
+
+func historyChangesKey(insertNumber uint64) []byte {
+	key := make([]byte, len(historyChangesPrefix)+insertNumberLen)
+	copy(key, historyChangesPrefix)
+	binary.BigEndian.PutUint64(key[len(historyChangesPrefix):], insertNumber)
+	return key
+}
+
+func historyRootKey(rootID ids.ID, insertNumber uint64) []byte {
+	key := make([]byte, len(historyRootsPrefix)+ids.IDLen+insertNumberLen)
+	copy(key, historyRootsPrefix)
+	copy(key[len(historyRootsPrefix):], rootID[:])
+	binary.BigEndian.PutUint64(key[len(historyRootsPrefix)+ids.IDLen:], insertNumber)
+	return key
+}
+
+// The root ID and value changes are encoded before the node changes so that
+// the value changes can be read without decoding the nodes.
+func encodeChangeSummary(changes *changeSummary) []byte {
+	w := codecWriter{}
+	w.ID(changes.rootID)
+
+	w.Uvarint(uint64(len(changes.values)))
+	for key, valueChange := range changes.values {
+		w.Key(key)
+		w.MaybeBytes(valueChange.before)
+		w.MaybeBytes(valueChange.after)
+	}
+
+	encodeMaybeNode(&w, changes.rootChange.before)
+	encodeMaybeNode(&w, changes.rootChange.after)
+
+	w.Uvarint(uint64(len(changes.nodes)))
+	for key, nodeChange := range changes.nodes {
+		w.Key(key)
+		encodeNullableNode(&w, nodeChange.before)
+		encodeNullableNode(&w, nodeChange.after)
+	}
+	return w.b
+}
+
+func encodeMaybeNode(w *codecWriter, n maybe.Maybe[*node]) {
+	hasNode := n.HasValue()
+	w.Bool(hasNode)
+	if hasNode {
+		w.Key(n.Value().key)
+		w.Bytes(n.Value().bytes())
+	}
+}
+
+// The key of [n] isn't encoded as it's the key of the node change.
+func encodeNullableNode(w *codecWriter, n *node) {
+	hasNode := n != nil
+	w.Bool(hasNode)
+	if hasNode {
+		w.Bytes(n.bytes())
+	}
+}
+
+func decodeChangeSummary(hasher Hasher, b []byte, withNodes bool) (*changeSummary, error) {
+	r := codecReader{
+		b:    b,
+		copy: true,
+	}
+
+	rootID, err := r.ID()
+	if err != nil {
+		return nil, err
+	}
+
+	numValues, err := r.Uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if numValues > uint64(len(r.b)) {
+		return nil, errTooManyChanges
+	}
+	changes := &changeSummary{
+		rootID: rootID,
+		values: make(map[Key]*change[maybe.Maybe[[]byte]], numValues),
+	}
+	for i := uint64(0); i < numValues; i++ {
+		key, err := r.Key()
+		if err != nil {
+			return nil, err
+		}
+		before, err := r.MaybeBytes()
+		if err != nil {
+			return nil, err
+		}
+		after, err := r.MaybeBytes()
+		if err != nil {
+			return nil, err
+		}
+		changes.values[key] = &change[maybe.Maybe[[]byte]]{
+			before: before,
+			after:  after,
+		}
+	}
+	if !withNodes {
+		return changes, nil
+	}
+
+	if changes.rootChange.before, err = decodeMaybeNode(hasher, &r); err != nil {
+		return nil, err
+	}
+	if changes.rootChange.after, err = decodeMaybeNode(hasher, &r); err != nil {
+		return nil, err
+	}
+
+	numNodes, err := r.Uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if numNodes > uint64(len(r.b)) {
+		return nil, errTooManyChanges
+	}
+	changes.nodes = make(map[Key]*change[*node], numNodes)
+	for i := uint64(0); i < numNodes; i++ {
+		key, err := r.Key()
+		if err != nil {
+			return nil, err
+		}
+		before, err := decodeNullableNode(hasher, &r, key)
+		if err != nil {
+			return nil, err
+		}
+		after, err := decodeNullableNode(hasher, &r, key)
+		if err != nil {
+			return nil, err
+		}
+		changes.nodes[key] = &change[*node]{
+			before: before,
+			after:  after,
+		}
+	}
+	if len(r.b) != 0 {
+		return nil, errExtraSpace
+	}
+	return changes, nil
+}
+
+func decodeMaybeNode(hasher Hasher, r *codecReader) (maybe.Maybe[*node], error) {
+	if hasNode, err := r.Bool(); err != nil || !hasNode {
+		return maybe.Nothing[*node](), err
+	}
+	key, err := r.Key()
+	if err != nil {
+		return maybe.Nothing[*node](), err
+	}
+	nodeBytes, err := r.Bytes()
+	if err != nil {
+		return maybe.Nothing[*node](), err
+	}
+	n, err := parseNode(hasher, key, nodeBytes)
+	if err != nil {
+		return maybe.Nothing[*node](), err
+	}
+	return maybe.Some(n), nil
+}
+
+func decodeNullableNode(hasher Hasher, r *codecReader, key Key) (*node, error) {
+	if hasNode, err := r.Bool(); err != nil || !hasNode {
+		return nil, err
+	}
+	nodeBytes, err := r.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return parseNode(hasher, key, nodeBytes)
+}
@@ -0,0 +1,273 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+)
+
+// commitKeys puts [numKeys] keys prefixed by [prefix] into [db] in a single
+// commit and returns the resulting root.
+func commitKeys(t *testing.T, db MerkleDB, prefix string, numKeys int) ids.ID {
+	require := require.New(t)
+
+	batch := db.NewBatch()
+	for i := 0; i < numKeys; i++ {
+		key := []byte(prefix + strconv.Itoa(i))
+		require.NoError(batch.Put(key, key))
+	}
+	require.NoError(batch.Write())
+
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	return root
+}
+
+func TestHistoryDBServesProofsAfterRestart(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	config := newDefaultConfig()
+	config.HistoryLength = 1
+	config.HistoryDiskLength = 10
+
+	db, err := newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	roots := make([]ids.ID, 0, 5)
+	for i := 0; i < 5; i++ {
+		roots = append(roots, commitKeys(t, db, strconv.Itoa(i), 10))
+	}
+
+	// The first root is only available from disk.
+	_, err = db.history.getValueChangesFromMemory(roots[0], roots[4], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 100)
+	require.ErrorIs(err, ErrInsufficientHistory)
+
+	require.NoError(db.Close())
+
+	config.Reg = prometheus.NewRegistry()
+	db, err = newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+	require.Equal(roots[4], db.getMerkleRoot())
+
+	changeProof, err := db.GetChangeProof(
+		context.Background(),
+		roots[0],
+		roots[4],
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		100,
+	)
+	require.NoError(err)
+	require.Len(changeProof.KeyChanges, 40)
+
+	// Verify the proof against a database at the start root.
+	startDB, err := newDB(context.Background(), memdb.New(), newDefaultConfig())
+	require.NoError(err)
+	commitKeys(t, startDB, "0", 10)
+	require.NoError(startDB.VerifyChangeProof(
+		context.Background(),
+		changeProof,
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		roots[4],
+	))
+
+	rangeProof, err := db.GetRangeProofAtRoot(
+		context.Background(),
+		roots[1],
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		100,
+	)
+	require.NoError(err)
+	require.Len(rangeProof.KeyValues, 20)
+	require.NoError(rangeProof.Verify(
+		context.Background(),
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		roots[1],
+		db.tokenSize,
+		db.hasher,
+	))
+}
+
+func TestHistoryDBUncleanShutdown(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	config := newDefaultConfig()
+	config.HistoryLength = 1
+	config.HistoryDiskLength = 10
+
+	db, err := newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	startRoot := commitKeys(t, db, "a", 10)
+	endRoot := commitKeys(t, db, "b", 10)
+
+	// Reopening without closing rebuilds the trie, which must not be recorded
+	// in the history.
+	config.Reg = prometheus.NewRegistry()
+	db, err = newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+	require.Equal(endRoot, db.getMerkleRoot())
+
+	changeProof, err := db.GetChangeProof(
+		context.Background(),
+		startRoot,
+		endRoot,
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		100,
+	)
+	require.NoError(err)
+	require.Len(changeProof.KeyChanges, 10)
+}
+
+func TestHistoryDBPrune(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	config := newDefaultConfig()
+	config.HistoryLength = 1
+	config.HistoryDiskLength = 3
+
+	db, err := newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	emptyRoot := db.getMerkleRoot()
+	roots := make([]ids.ID, 0, 3)
+	for i := 0; i < 3; i++ {
+		roots = append(roots, commitKeys(t, db, strconv.Itoa(i), 1))
+	}
+
+	// The empty root was pruned to make room for the latest change.
+	_, err = db.GetChangeProof(context.Background(), emptyRoot, roots[2], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.ErrorIs(err, ErrInsufficientHistory)
+
+	_, err = db.GetRangeProofAtRoot(context.Background(), roots[0], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.NoError(err)
+
+	// Lowering the retention prunes the persisted history on startup.
+	require.NoError(db.Close())
+	config.HistoryDiskLength = 2
+	config.Reg = prometheus.NewRegistry()
+	db, err = newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	_, err = db.GetRangeProofAtRoot(context.Background(), roots[0], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.ErrorIs(err, ErrInsufficientHistory)
+
+	_, err = db.GetRangeProofAtRoot(context.Background(), roots[1], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.NoError(err)
+
+	_, err = baseDB.Get(historyChangesKey(1))
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestHistoryDBDiscardedWhenDisabled(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	config := newDefaultConfig()
+	config.HistoryLength = 1
+	config.HistoryDiskLength = 10
+
+	db, err := newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	root := commitKeys(t, db, "a", 1)
+	commitKeys(t, db, "b", 1)
+	require.NoError(db.Close())
+
+	// Changes made while the history isn't persisted must not be served from
+	// the previously persisted history.
+	config.HistoryDiskLength = 0
+	config.Reg = prometheus.NewRegistry()
+	db, err = newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+	commitKeys(t, db, "c", 1)
+	require.NoError(db.Close())
+
+	config.HistoryDiskLength = 10
+	config.Reg = prometheus.NewRegistry()
+	db, err = newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	_, err = db.GetRangeProofAtRoot(context.Background(), root, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.ErrorIs(err, ErrInsufficientHistory)
+}
+
+func TestHistoryDBClear(t *testing.T) {
+	require := require.New(t)
+
+	config := newDefaultConfig()
+	config.HistoryLength = 1
+	config.HistoryDiskLength = 10
+
+	db, err := newDB(context.Background(), memdb.New(), config)
+	require.NoError(err)
+
+	root := commitKeys(t, db, "a", 1)
+	commitKeys(t, db, "b", 1)
+	require.NoError(db.Clear())
+
+	_, err = db.GetRangeProofAtRoot(context.Background(), root, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.ErrorIs(err, ErrInsufficientHistory)
+
+	newRoot := commitKeys(t, db, "c", 1)
+	changeProof, err := db.GetChangeProof(
+		context.Background(),
+		ids.Empty,
+		newRoot,
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		10,
+	)
+	require.NoError(err)
+	require.Len(changeProof.KeyChanges, 1)
+}
+
+func TestChangeSummaryEncoding(t *testing.T) {
+	require := require.New(t)
+
+	db, err := newDB(context.Background(), memdb.New(), newDefaultConfig())
+	require.NoError(err)
+
+	v, err := db.NewView(context.Background(), ViewChanges{
+		BatchOps: []database.BatchOp{
+			{Key: []byte("key1"), Value: []byte("value1")},
+			{Key: []byte("key2"), Value: []byte("value2")},
+		},
+	})
+	require.NoError(err)
+	require.NoError(v.(*view).applyValueChanges(context.Background()))
+
+	changes := v.(*view).changes
+	changesBytes := encodeChangeSummary(changes)
+
+	decoded, err := decodeChangeSummary(db.hasher, changesBytes, true)
+	require.NoError(err)
+	require.Equal(changes.rootID, decoded.rootID)
+	require.Equal(changes.values, decoded.values)
+	require.Equal(changes.rootChange, decoded.rootChange)
+	require.Equal(changes.nodes, decoded.nodes)
+
+	decoded, err = decodeChangeSummary(db.hasher, changesBytes, false)
+	require.NoError(err)
+	require.Equal(changes.rootID, decoded.rootID)
+	require.Equal(changes.values, decoded.values)
+	require.Empty(decoded.nodes)
+}
@@ -4,11 +4,18 @@
 package merkledb
 
 import (
+	"bytes"
+	"errors"
+	"math/bits"
+	"slices"
+
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/utils"
 )
 
+var errInvalidDBKey = errors.New("invalid intermediate node database key")
+
 // Holds intermediate nodes. That is, those without values.
 // Changes to this database aren't written to [baseDB] until
 // they're evicted from the [nodeCache] or Flush is called.
@@ -167,6 +174,31 @@ func (db *intermediateNodeDB) constructDBKey(key Key) *[]byte {
 	return bufferPtr
 }
 
+// parseDBKey returns the key that was used to construct [dbKey] in
+// [constructDBKey].
+func (db *intermediateNodeDB) parseDBKey(dbKey []byte) (Key, error) {
+	if !bytes.HasPrefix(dbKey, intermediateNodePrefix) {
+		return Key{}, errInvalidDBKey
+	}
+	keyBytes := slices.Clone(dbKey[len(intermediateNodePrefix):])
+	if db.tokenSize == 8 {
+		return toKey(keyBytes), nil
+	}
+
+	// The key is followed by a padding token whose last bit is the last set
+	// bit of [keyBytes].
+	lastByteIndex := len(keyBytes) - 1
+	if lastByteIndex < 0 || keyBytes[lastByteIndex] == 0 {
+		return Key{}, errInvalidDBKey
+	}
+	paddedLength := 8*len(keyBytes) - bits.TrailingZeros8(keyBytes[lastByteIndex])
+	length := paddedLength - db.tokenSize
+	if length < 0 || length%db.tokenSize != 0 {
+		return Key{}, errInvalidDBKey
+	}
+	return toKey(keyBytes).Take(length), nil
+}
+
 func (db *intermediateNodeDB) Put(key Key, n *node) error {
 	db.nodeCache.Put(key, n)
 	return db.writeBuffer.Put(key, n)
@@ -315,3 +315,37 @@ func Benchmark_IntermediateNodeDB_ConstructDBKey(b *testing.B) {
 		}
 	}
 }
+
+func TestIntermediateNodeDBParseDBKey(t *testing.T) {
+	for _, bf := range validBranchFactors {
+		t.Run(fmt.Sprintf("branch factor %d", bf), func(t *testing.T) {
+			require := require.New(t)
+
+			tokenSize := BranchFactorToTokenSize[bf]
+			db := newIntermediateNodeDB(
+				memdb.New(),
+				utils.NewBytesPool(),
+				&mockMetrics{},
+				0,
+				0,
+				0,
+				tokenSize,
+				DefaultHasher,
+			)
+
+			fullKey := ToKey([]byte{0xF1, 0x00, 0x7A})
+			for length := 0; length <= fullKey.Length(); length += tokenSize {
+				key := fullKey.Take(length)
+
+				dbKey := db.constructDBKey(key)
+				parsedKey, err := db.parseDBKey(*dbKey)
+				require.NoError(err)
+				require.Equal(key, parsedKey)
+				db.bufferPool.Put(dbKey)
+			}
+
+			_, err := db.parseDBKey(valueNodePrefix)
+			require.ErrorIs(err, errInvalidDBKey)
+		})
+	}
+}
@@ -20,6 +20,10 @@ type child struct {
 	compressedKey Key
 	id            ids.ID
 	hasValue      bool
+	// If true, [id] is the child's encoding rather than its hash.
+	// Only hashers which inline small nodes, such as [EthereumHasher], set
+	// this.
+	embedded bool
 }
 
 // node holds additional information on top of the dbNode that makes calculations easier to do
@@ -125,6 +129,7 @@ func (n *node) clone() *node {
 			compressedKey: existing.compressedKey,
 			id:            existing.id,
 			hasValue:      existing.hasValue,
+			embedded:      existing.embedded,
 		}
 	}
 	return result
@@ -0,0 +1,362 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+var (
+	_ database.Database = (*pipelineDB)(nil)
+	_ database.Batch    = (*pipelineBatch)(nil)
+	_ database.Iterator = (*pipelineIterator)(nil)
+)
+
+// pipelineDB is a database whose batches are applied in memory when they're
+// written, and written to [baseDB] in the background. Batches are written to
+// [baseDB] one at a time, atomically and in the order they were written, so
+// [baseDB] always contains a prefix of the written batches.
+//
+// Reads observe every written batch, whether or not it has been written to
+// [baseDB] yet. Iterators are created once every written batch has been
+// written to [baseDB].
+//
+// Closing a pipelineDB waits for the written batches to be written to
+// [baseDB], but doesn't close [baseDB].
+type pipelineDB struct {
+	baseDB database.Database
+
+	// Once the batches waiting to be written to [baseDB] total more than
+	// [maxPendingBytes], writing another batch blocks until some are written.
+	maxPendingBytes int
+
+	lock sync.Mutex
+	// Broadcast when a batch is written to [baseDB], when a batch is queued,
+	// or when the background writer stops.
+	cond *sync.Cond
+
+	// The batches waiting to be written to [baseDB], oldest first.
+	queue []*pendingBatch
+	// The sum of the sizes of the batches in [queue].
+	pendingBytes int
+	// The last write to each key by a batch in [queue].
+	pending map[string]pendingWrite
+	// The number of batches that have been written to the pipelineDB.
+	numBatches uint64
+	// True iff a goroutine is writing the batches in [queue] to [baseDB].
+	writing bool
+
+	// The error, if any, that occurred while writing to [baseDB]. Once set,
+	// every operation fails with it.
+	err    error
+	closed utils.Atomic[bool]
+}
+
+type pendingBatch struct {
+	// The value of [pipelineDB.numBatches] when this batch was written.
+	number uint64
+	ops    []database.BatchOp
+	size   int
+}
+
+type pendingWrite struct {
+	value  []byte
+	delete bool
+	// The number of the batch that made this write.
+	batchNumber uint64
+}
+
+func newPipelineDB(db database.Database, maxPendingBytes int) *pipelineDB {
+	p := &pipelineDB{
+		baseDB:          db,
+		maxPendingBytes: maxPendingBytes,
+		pending:         make(map[string]pendingWrite),
+	}
+	p.cond = sync.NewCond(&p.lock)
+	return p
+}
+
+func (p *pipelineDB) Has(key []byte) (bool, error) {
+	p.lock.Lock()
+	if err := p.checkErr(); err != nil {
+		p.lock.Unlock()
+		return false, err
+	}
+	write, ok := p.pending[string(key)]
+	p.lock.Unlock()
+
+	if ok {
+		return !write.delete, nil
+	}
+	return p.baseDB.Has(key)
+}
+
+func (p *pipelineDB) Get(key []byte) ([]byte, error) {
+	p.lock.Lock()
+	if err := p.checkErr(); err != nil {
+		p.lock.Unlock()
+		return nil, err
+	}
+	write, ok := p.pending[string(key)]
+	p.lock.Unlock()
+
+	if !ok {
+		return p.baseDB.Get(key)
+	}
+	if write.delete {
+		return nil, database.ErrNotFound
+	}
+	return slices.Clone(write.value), nil
+}
+
+func (p *pipelineDB) Put(key, value []byte) error {
+	b := p.NewBatch()
+	if err := b.Put(key, value); err != nil {
+		return err
+	}
+	return b.Write()
+}
+
+func (p *pipelineDB) Delete(key []byte) error {
+	b := p.NewBatch()
+	if err := b.Delete(key); err != nil {
+		return err
+	}
+	return b.Write()
+}
+
+func (p *pipelineDB) NewBatch() database.Batch {
+	return &pipelineBatch{db: p}
+}
+
+func (p *pipelineDB) NewIterator() database.Iterator {
+	return p.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (p *pipelineDB) NewIteratorWithStart(start []byte) database.Iterator {
+	return p.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (p *pipelineDB) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return p.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (p *pipelineDB) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if err := p.Sync(); err != nil {
+		return &database.IteratorError{
+			Err: err,
+		}
+	}
+	return &pipelineIterator{
+		db:       p,
+		Iterator: p.baseDB.NewIteratorWithStartAndPrefix(start, prefix),
+	}
+}
+
+func (p *pipelineDB) Compact(start []byte, limit []byte) error {
+	p.lock.Lock()
+	err := p.checkErr()
+	p.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	return p.baseDB.Compact(start, limit)
+}
+
+// Close waits for the written batches to be written to [p.baseDB].
+func (p *pipelineDB) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.closed.Get() {
+		return database.ErrClosed
+	}
+	p.closed.Set(true)
+	for p.writing {
+		p.cond.Wait()
+	}
+	return p.err
+}
+
+func (p *pipelineDB) HealthCheck(ctx context.Context) (interface{}, error) {
+	p.lock.Lock()
+	err := p.checkErr()
+	p.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return p.baseDB.HealthCheck(ctx)
+}
+
+// Sync waits for the written batches to be written to [p.baseDB].
+func (p *pipelineDB) Sync() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for p.writing && p.err == nil {
+		p.cond.Wait()
+	}
+	return p.checkErr()
+}
+
+// WaitForCapacity waits until a batch can be written without blocking.
+func (p *pipelineDB) WaitForCapacity() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.waitForCapacity()
+}
+
+// Assumes [p.lock] is held.
+func (p *pipelineDB) waitForCapacity() {
+	for p.pendingBytes > p.maxPendingBytes && p.writing && p.err == nil {
+		p.cond.Wait()
+	}
+}
+
+// Assumes [p.lock] is held.
+func (p *pipelineDB) checkErr() error {
+	switch {
+	case p.closed.Get():
+		return database.ErrClosed
+	default:
+		return p.err
+	}
+}
+
+// Queues [ops] to be written to [p.baseDB].
+func (p *pipelineDB) write(ops []database.BatchOp, size int) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.waitForCapacity()
+	if err := p.checkErr(); err != nil {
+		return err
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	p.numBatches++
+	batch := &pendingBatch{
+		number: p.numBatches,
+		ops:    ops,
+		size:   size,
+	}
+	for _, op := range ops {
+		p.pending[string(op.Key)] = pendingWrite{
+			value:       op.Value,
+			delete:      op.Delete,
+			batchNumber: batch.number,
+		}
+	}
+	p.queue = append(p.queue, batch)
+	p.pendingBytes += size
+
+	if !p.writing {
+		p.writing = true
+		go p.writeToBaseDB()
+	}
+	return nil
+}
+
+// writeToBaseDB writes the batches in [p.queue] to [p.baseDB] until [p.queue]
+// is empty or an error occurs.
+func (p *pipelineDB) writeToBaseDB() {
+	baseBatch := p.baseDB.NewBatch()
+	for {
+		p.lock.Lock()
+		if len(p.queue) == 0 || p.err != nil {
+			p.writing = false
+			p.cond.Broadcast()
+			p.lock.Unlock()
+			return
+		}
+		batch := p.queue[0]
+		p.lock.Unlock()
+
+		baseBatch.Reset()
+		err := (&database.BatchOps{Ops: batch.ops}).Replay(baseBatch)
+		if err == nil {
+			err = baseBatch.Write()
+		}
+
+		p.lock.Lock()
+		if err != nil {
+			p.err = err
+			p.lock.Unlock()
+			continue
+		}
+
+		p.queue[0] = nil
+		p.queue = p.queue[1:]
+		p.pendingBytes -= batch.size
+		for _, op := range batch.ops {
+			key := string(op.Key)
+			// A later batch may have written to the same key.
+			if write := p.pending[key]; write.batchNumber == batch.number {
+				delete(p.pending, key)
+			}
+		}
+		p.cond.Broadcast()
+		p.lock.Unlock()
+	}
+}
+
+type pipelineBatch struct {
+	database.BatchOps
+
+	db *pipelineDB
+}
+
+func (b *pipelineBatch) Write() error {
+	return b.db.write(slices.Clone(b.Ops), b.Size())
+}
+
+func (b *pipelineBatch) Inner() database.Batch {
+	return b
+}
+
+// pipelineIterator reports [database.ErrClosed] once its database is closed.
+type pipelineIterator struct {
+	database.Iterator
+
+	db *pipelineDB
+	// True iff Next was called after [db] was closed.
+	closed bool
+}
+
+func (i *pipelineIterator) Next() bool {
+	if i.db.closed.Get() {
+		i.closed = true
+		return false
+	}
+	return i.Iterator.Next()
+}
+
+func (i *pipelineIterator) Error() error {
+	if i.closed || i.db.closed.Get() {
+		return database.ErrClosed
+	}
+	return i.Iterator.Error()
+}
+
+func (i *pipelineIterator) Key() []byte {
+	if i.closed {
+		return nil
+	}
+	return i.Iterator.Key()
+}
+
+func (i *pipelineIterator) Value() []byte {
+	if i.closed {
+		return nil
+	}
+	return i.Iterator.Value()
+}
@@ -0,0 +1,414 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/dbtest"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+func TestPipelineDBInterface(t *testing.T) {
+	for name, test := range dbtest.Tests {
+		t.Run(name, func(t *testing.T) {
+			test(t, newPipelineDB(memdb.New(), units.KiB))
+		})
+	}
+}
+
+// blockingDB blocks writes to the underlying database until [unblock] is
+// closed.
+type blockingDB struct {
+	database.Database
+
+	unblock chan struct{}
+}
+
+func (b *blockingDB) NewBatch() database.Batch {
+	return &blockingBatch{
+		Batch:   b.Database.NewBatch(),
+		unblock: b.unblock,
+	}
+}
+
+type blockingBatch struct {
+	database.Batch
+
+	unblock chan struct{}
+}
+
+func (b *blockingBatch) Write() error {
+	<-b.unblock
+	return b.Batch.Write()
+}
+
+func TestPipelineDBReadsPendingWrites(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := &blockingDB{
+		Database: memdb.New(),
+		unblock:  make(chan struct{}),
+	}
+	db := newPipelineDB(baseDB, units.MiB)
+
+	require.NoError(db.Put([]byte("a"), []byte("1")))
+	require.NoError(db.Put([]byte("b"), []byte("2")))
+	require.NoError(db.Delete([]byte("a")))
+
+	// The writes haven't been written to the base database.
+	has, err := baseDB.Database.Has([]byte("b"))
+	require.NoError(err)
+	require.False(has)
+
+	// But they're visible through the pipelineDB.
+	_, err = db.Get([]byte("a"))
+	require.ErrorIs(err, database.ErrNotFound)
+	value, err := db.Get([]byte("b"))
+	require.NoError(err)
+	require.Equal([]byte("2"), value)
+
+	close(baseDB.unblock)
+	require.NoError(db.Sync())
+
+	_, err = baseDB.Database.Get([]byte("a"))
+	require.ErrorIs(err, database.ErrNotFound)
+	value, err = baseDB.Database.Get([]byte("b"))
+	require.NoError(err)
+	require.Equal([]byte("2"), value)
+	require.Empty(db.pending)
+	require.Zero(db.pendingBytes)
+}
+
+func TestPipelineDBBackpressure(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := &blockingDB{
+		Database: memdb.New(),
+		unblock:  make(chan struct{}),
+	}
+	db := newPipelineDB(baseDB, 1)
+
+	// The first write can always be queued.
+	require.NoError(db.Put([]byte("a"), []byte("1")))
+
+	written := make(chan error)
+	go func() {
+		written <- db.Put([]byte("b"), []byte("2"))
+	}()
+
+	select {
+	case <-written:
+		require.FailNow("write should be blocked until the pending writes are written")
+	default:
+	}
+
+	close(baseDB.unblock)
+	require.NoError(<-written)
+	require.NoError(db.Close())
+
+	value, err := baseDB.Database.Get([]byte("b"))
+	require.NoError(err)
+	require.Equal([]byte("2"), value)
+}
+
+var errTestWriteFailed = errors.New("write failed")
+
+type failingDB struct {
+	database.Database
+}
+
+func (f *failingDB) NewBatch() database.Batch {
+	return &failingBatch{
+		Batch: f.Database.NewBatch(),
+	}
+}
+
+type failingBatch struct {
+	database.Batch
+}
+
+func (*failingBatch) Write() error {
+	return errTestWriteFailed
+}
+
+func TestPipelineDBWriteError(t *testing.T) {
+	require := require.New(t)
+
+	db := newPipelineDB(&failingDB{Database: memdb.New()}, units.MiB)
+	require.NoError(db.Put([]byte("a"), []byte("1")))
+
+	require.ErrorIs(db.Sync(), errTestWriteFailed)
+
+	// Every operation fails once a write has failed.
+	_, err := db.Get([]byte("a"))
+	require.ErrorIs(err, errTestWriteFailed)
+	require.ErrorIs(db.Put([]byte("b"), []byte("2")), errTestWriteFailed)
+	require.ErrorIs(db.Close(), errTestWriteFailed)
+}
+
+// Returns a copy of the key/value pairs in [db].
+func copyDB(t *testing.T, db database.Database) *memdb.Database {
+	require := require.New(t)
+
+	copied := memdb.New()
+	it := db.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		require.NoError(copied.Put(it.Key(), it.Value()))
+	}
+	require.NoError(it.Error())
+	return copied
+}
+
+// Returns a batch of random changes to the keys in [0, numKeys).
+func newRandomBatchOps(r *rand.Rand, numOps int, numKeys int) []database.BatchOp {
+	ops := make([]database.BatchOp, numOps)
+	for i := range ops {
+		ops[i] = database.BatchOp{
+			Key:    []byte(fmt.Sprintf("key%d", r.Intn(numKeys))), // #nosec G404
+			Delete: r.Intn(4) == 0,                                // #nosec G404
+		}
+		if !ops[i].Delete {
+			ops[i].Value = make([]byte, 1+r.Intn(32)) // #nosec G404
+			_, _ = r.Read(ops[i].Value)               // #nosec G404
+		}
+	}
+	return ops
+}
+
+func TestMerkleDBPipelinedCommits(t *testing.T) {
+	require := require.New(t)
+	r := rand.New(rand.NewSource(0)) // #nosec G404
+
+	syncDB, err := getBasicDB()
+	require.NoError(err)
+
+	baseDB := &blockingDB{
+		Database: memdb.New(),
+		unblock:  make(chan struct{}),
+	}
+	config := newDefaultConfig()
+	config.ValueNodeCacheSize = 0
+	config.IntermediateNodeCacheSize = 0
+	config.CommitBufferSize = units.GiB
+	db, err := newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	var (
+		roots = []ids.ID{ids.Empty}
+		ctx   = context.Background()
+	)
+	for i := 0; i < 10; i++ {
+		ops := newRandomBatchOps(r, 100, 200)
+
+		view, err := db.NewView(ctx, ViewChanges{BatchOps: ops})
+		require.NoError(err)
+		require.NoError(view.CommitToDB(ctx))
+
+		syncView, err := syncDB.NewView(ctx, ViewChanges{BatchOps: ops})
+		require.NoError(err)
+		require.NoError(syncView.CommitToDB(ctx))
+
+		// None of the changes have been written to [baseDB], but they're
+		// visible through [db].
+		rootID, err := db.GetMerkleRoot(ctx)
+		require.NoError(err)
+		expectedRootID, err := syncDB.GetMerkleRoot(ctx)
+		require.NoError(err)
+		require.Equal(expectedRootID, rootID)
+		roots = append(roots, rootID)
+
+		for _, op := range ops {
+			value, err := db.Get(op.Key)
+			expectedValue, expectedErr := syncDB.Get(op.Key)
+			require.ErrorIs(err, expectedErr)
+			require.Equal(expectedValue, value)
+		}
+	}
+	it := baseDB.Database.NewIterator()
+	require.False(it.Next())
+	it.Release()
+
+	// Simulate crashes while the changes are written to disk. Each crash
+	// must recover one of the committed roots, in order.
+	lastRootIndex := 0
+	checkRecovery := func() {
+		recoveredDB, err := newDB(ctx, copyDB(t, baseDB.Database), newDefaultConfig())
+		require.NoError(err)
+		rootID, err := recoveredDB.GetMerkleRoot(ctx)
+		require.NoError(err)
+
+		rootIndex := slices.Index(roots, rootID)
+		require.GreaterOrEqual(rootIndex, lastRootIndex)
+		lastRootIndex = rootIndex
+	}
+
+	synced := make(chan error)
+	go func() {
+		synced <- db.Sync()
+	}()
+	for {
+		select {
+		case err := <-synced:
+			require.NoError(err)
+			checkRecovery()
+			require.Equal(len(roots)-1, lastRootIndex)
+		case baseDB.unblock <- struct{}{}:
+			checkRecovery()
+			continue
+		}
+		break
+	}
+
+	close(baseDB.unblock)
+	require.NoError(db.Close())
+
+	// Changes were written before the clean shutdown marker, so the root
+	// doesn't need to be rebuilt.
+	config.Reg = prometheus.NewRegistry()
+	db, err = newDB(ctx, baseDB.Database, config)
+	require.NoError(err)
+	rootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.Equal(roots[len(roots)-1], rootID)
+}
+
+func TestMerkleDBPipelinedViewOnUnwrittenParent(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := &blockingDB{
+		Database: memdb.New(),
+		unblock:  make(chan struct{}),
+	}
+	config := newDefaultConfig()
+	config.CommitBufferSize = units.GiB
+	db, err := newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	ctx := context.Background()
+	parent, err := db.NewView(ctx, ViewChanges{
+		BatchOps: []database.BatchOp{
+			{Key: []byte("key1"), Value: []byte("value1")},
+		},
+	})
+	require.NoError(err)
+	child, err := parent.NewView(ctx, ViewChanges{
+		BatchOps: []database.BatchOp{
+			{Key: []byte("key2"), Value: []byte("value2")},
+		},
+	})
+	require.NoError(err)
+
+	// [child] is moved onto [db] before [parent]'s changes are written to
+	// disk.
+	require.NoError(parent.CommitToDB(ctx))
+	require.NoError(child.CommitToDB(ctx))
+
+	grandchild, err := db.NewView(ctx, ViewChanges{
+		BatchOps: []database.BatchOp{
+			{Key: []byte("key1"), Delete: true},
+		},
+	})
+	require.NoError(err)
+	require.NoError(grandchild.CommitToDB(ctx))
+
+	_, err = db.Get([]byte("key1"))
+	require.ErrorIs(err, database.ErrNotFound)
+	value, err := db.Get([]byte("key2"))
+	require.NoError(err)
+	require.Equal([]byte("value2"), value)
+
+	close(baseDB.unblock)
+	require.NoError(db.Sync())
+
+	it := db.NewIterator()
+	defer it.Release()
+	keys := set.Set[string]{}
+	for it.Next() {
+		keys.Add(string(it.Key()))
+	}
+	require.NoError(it.Error())
+	require.Equal(set.Of("key2"), keys)
+}
+
+// BenchmarkCommitLargeBlock reports the throughput of committing blocks with
+// many changed keys, and the longest a concurrent reader was blocked.
+func BenchmarkCommitLargeBlock(b *testing.B) {
+	const numKeys = 100_000
+
+	for _, commitBufferSize := range []uint{0, units.GiB} {
+		b.Run(fmt.Sprintf("commit_buffer_size_%d", commitBufferSize), func(b *testing.B) {
+			require := require.New(b)
+			r := rand.New(rand.NewSource(0)) // #nosec G404
+
+			baseDB, err := leveldb.New(b.TempDir(), nil, logging.NoLog{}, prometheus.NewRegistry())
+			require.NoError(err)
+			defer baseDB.Close()
+
+			config := newDefaultConfig()
+			config.IntermediateWriteBufferSize = 64 * units.MiB
+			config.CommitBufferSize = commitBufferSize
+			db, err := newDB(context.Background(), baseDB, config)
+			require.NoError(err)
+
+			blocks := make([][]database.BatchOp, b.N)
+			for i := range blocks {
+				blocks[i] = newRandomBatchOps(r, numKeys, 10*numKeys)
+			}
+
+			var (
+				stop           = make(chan struct{})
+				maxReadLatency time.Duration
+				readerStopped  = make(chan struct{})
+				ctx            = context.Background()
+			)
+			go func() {
+				defer close(readerStopped)
+				for {
+					select {
+					case <-stop:
+						return
+					default:
+					}
+
+					start := time.Now()
+					_, _ = db.GetMerkleRoot(ctx)
+					maxReadLatency = max(maxReadLatency, time.Since(start))
+				}
+			}()
+
+			b.ResetTimer()
+			for _, ops := range blocks {
+				view, err := db.NewView(ctx, ViewChanges{BatchOps: ops, ConsumeBytes: true})
+				require.NoError(err)
+				require.NoError(view.CommitToDB(ctx))
+			}
+			require.NoError(db.Sync())
+			b.StopTimer()
+
+			close(stop)
+			<-readerStopped
+
+			b.ReportMetric(float64(numKeys*b.N)/b.Elapsed().Seconds(), "keys/s")
+			b.ReportMetric(float64(maxReadLatency.Microseconds()), "max-read-µs")
+			require.NoError(db.Close())
+		})
+	}
+}
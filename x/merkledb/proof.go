@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
@@ -48,6 +49,10 @@ var (
 	ErrNilProof                    = errors.New("proof is nil")
 	ErrNilValue                    = errors.New("value is nil")
 	ErrUnexpectedEndProof          = errors.New("end proof should be empty")
+	ErrNilMultiProof               = errors.New("multiproof is nil")
+	ErrNilProvenKey                = errors.New("proven key is nil")
+	ErrUnreferencedProofNode       = errors.New("proof node isn't referenced by its parent")
+	ErrMissingProofNode            = errors.New("proof is missing a node on the path to a proven key")
 )
 
 type ProofNode struct {
@@ -115,6 +120,20 @@ func (node *ProofNode) UnmarshalProto(pbNode *pb.ProofNode) error {
 	return nil
 }
 
+// proofNodeToNode returns a node with the key, value digest and child IDs of
+// [proofNode]. The compressed keys of the children aren't known, which doesn't
+// affect the hash of the node.
+func proofNodeToNode(proofNode *ProofNode) *node {
+	n := newNode(proofNode.Key)
+	n.valueDigest = proofNode.ValueOrHash
+	for index, childID := range proofNode.Children {
+		n.setChildEntry(index, &child{
+			id: childID,
+		})
+	}
+	return n
+}
+
 // Proof represents an inclusion/exclusion proof of a key.
 type Proof struct {
 	// Nodes in the proof path from root --> target key
@@ -239,6 +258,209 @@ func (proof *Proof) UnmarshalProto(pbProof *pb.Proof) error {
 	return nil
 }
 
+// ProvenKey is a key proven to exist/not exist by a [MultiProof].
+type ProvenKey struct {
+	Key Key
+
+	// Nothing if [Key] isn't in the trie.
+	// Otherwise, the value corresponding to [Key].
+	Value maybe.Maybe[[]byte]
+}
+
+func (k *ProvenKey) ToProto() *pb.ProvenKey {
+	return &pb.ProvenKey{
+		Key: k.Key.Bytes(),
+		Value: &pb.MaybeBytes{
+			Value:     k.Value.Value(),
+			IsNothing: k.Value.IsNothing(),
+		},
+	}
+}
+
+func (k *ProvenKey) UnmarshalProto(pbKey *pb.ProvenKey) error {
+	switch {
+	case pbKey == nil:
+		return ErrNilProvenKey
+	case pbKey.Value == nil:
+		return ErrNilValue
+	case pbKey.Value.IsNothing && len(pbKey.Value.Value) != 0:
+		return ErrInvalidMaybe
+	}
+
+	k.Key = ToKey(pbKey.Key)
+	if !pbKey.Value.IsNothing {
+		k.Value = maybe.Some(pbKey.Value.Value)
+	}
+	return nil
+}
+
+// MultiProof represents an inclusion/exclusion proof of multiple keys.
+// It's equivalent to a [Proof] of each key, but nodes on the paths of more
+// than one key are only included once.
+type MultiProof struct {
+	// The union of the proof paths of [Keys], in increasing key order.
+	// Always contains at least the root.
+	Nodes []ProofNode
+
+	// The keys this is a proof of, in increasing order.
+	Keys []ProvenKey
+}
+
+// Verify returns nil if the trie given in [proof] has root [expectedRootID].
+// That is, this is a valid proof that each key in [proof.Keys] has the given
+// value, or doesn't exist, in the trie with root [expectedRootID].
+func (proof *MultiProof) Verify(
+	_ context.Context,
+	expectedRootID ids.ID,
+	tokenSize int,
+	hasher Hasher,
+) error {
+	// Make sure the proof is well-formed.
+	if len(proof.Nodes) == 0 {
+		return ErrEmptyProof
+	}
+	for i := 1; i < len(proof.Keys); i++ {
+		if !proof.Keys[i-1].Key.Less(proof.Keys[i].Key) {
+			return ErrNonIncreasingValues
+		}
+	}
+
+	// Each node must be referenced by the closest node in the proof whose key
+	// is a prefix of its key. By induction from the root, every node in the
+	// proof is in the trie, and for every key in the proof, the closest node
+	// whose key is a prefix is its parent.
+	var (
+		rootKey   = proof.Nodes[0].Key
+		nodeIDs   = make([]ids.ID, len(proof.Nodes))
+		ancestors = make([]int, 0, len(proof.Nodes))
+	)
+	for i := range proof.Nodes {
+		proofNode := &proof.Nodes[i]
+		if proofNode.Key.hasPartialByte() && proofNode.ValueOrHash.HasValue() {
+			return ErrPartialByteLengthWithValue
+		}
+		nodeIDs[i] = hasher.HashNode(proofNodeToNode(proofNode))
+
+		if i == 0 {
+			ancestors = append(ancestors, i)
+			continue
+		}
+		if !proof.Nodes[i-1].Key.Less(proofNode.Key) {
+			return ErrNonIncreasingProofNodes
+		}
+		if !proofNode.Key.HasStrictPrefix(rootKey) {
+			return ErrProofNodeNotForKey
+		}
+
+		for !proofNode.Key.HasPrefix(proof.Nodes[ancestors[len(ancestors)-1]].Key) {
+			ancestors = ancestors[:len(ancestors)-1]
+		}
+		parent := &proof.Nodes[ancestors[len(ancestors)-1]]
+		childID, ok := parent.Children[proofNode.Key.Token(parent.Key.length, tokenSize)]
+		if !ok || childID != nodeIDs[i] {
+			return ErrUnreferencedProofNode
+		}
+		ancestors = append(ancestors, i)
+	}
+
+	if nodeIDs[0] != expectedRootID {
+		return fmt.Errorf("%w:[%s], expected:[%s]", ErrInvalidProof, nodeIDs[0], expectedRootID)
+	}
+
+	for _, provenKey := range proof.Keys {
+		if err := proof.verifyKey(provenKey, tokenSize, hasher); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyKey returns nil if [proof.Nodes] proves that [provenKey.Key] has
+// [provenKey.Value].
+// Assumes the nodes in [proof] have been verified.
+func (proof *MultiProof) verifyKey(provenKey ProvenKey, tokenSize int, hasher Hasher) error {
+	var (
+		key     = provenKey.Key
+		current = &proof.Nodes[0]
+	)
+	if !key.HasPrefix(current.Key) {
+		// No key in the trie has [key] as a prefix.
+		if provenKey.Value.HasValue() {
+			return ErrProofValueDoesntMatch
+		}
+		return nil
+	}
+
+	for current.Key != key {
+		index := key.Token(current.Key.length, tokenSize)
+		if _, ok := current.Children[index]; !ok {
+			// There is no child along the path to [key].
+			if provenKey.Value.HasValue() {
+				return ErrProofValueDoesntMatch
+			}
+			return nil
+		}
+
+		// The child is the first node in the proof under [childPrefix].
+		childPrefix := current.Key.Extend(ToToken(index, tokenSize))
+		i, _ := slices.BinarySearchFunc(proof.Nodes, childPrefix, func(n ProofNode, k Key) int {
+			return n.Key.Compare(k)
+		})
+		if i == len(proof.Nodes) || !proof.Nodes[i].Key.HasPrefix(childPrefix) {
+			return ErrMissingProofNode
+		}
+		current = &proof.Nodes[i]
+
+		if !key.HasPrefix(current.Key) {
+			// The child that is there doesn't match the remaining path.
+			if provenKey.Value.HasValue() {
+				return ErrProofValueDoesntMatch
+			}
+			return nil
+		}
+	}
+
+	if !valueOrHashMatches(hasher, provenKey.Value, current.ValueOrHash) {
+		return ErrProofValueDoesntMatch
+	}
+	return nil
+}
+
+func (proof *MultiProof) ToProto() *pb.MultiProof {
+	pbProof := &pb.MultiProof{
+		Nodes: make([]*pb.ProofNode, len(proof.Nodes)),
+		Keys:  make([]*pb.ProvenKey, len(proof.Keys)),
+	}
+	for i, node := range proof.Nodes {
+		pbProof.Nodes[i] = node.ToProto()
+	}
+	for i, key := range proof.Keys {
+		pbProof.Keys[i] = key.ToProto()
+	}
+	return pbProof
+}
+
+func (proof *MultiProof) UnmarshalProto(pbProof *pb.MultiProof) error {
+	if pbProof == nil {
+		return ErrNilMultiProof
+	}
+
+	proof.Nodes = make([]ProofNode, len(pbProof.Nodes))
+	for i, pbNode := range pbProof.Nodes {
+		if err := proof.Nodes[i].UnmarshalProto(pbNode); err != nil {
+			return err
+		}
+	}
+
+	proof.Keys = make([]ProvenKey, len(pbProof.Keys))
+	for i, pbKey := range pbProof.Keys {
+		if err := proof.Keys[i].UnmarshalProto(pbKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type KeyValue struct {
 	Key   []byte
 	Value []byte
@@ -6,13 +6,16 @@ package merkledb
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"math/rand"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/maybe"
@@ -138,6 +141,230 @@ func Test_Proof_ValueOrHashMatches(t *testing.T) {
 	require.False(valueOrHashMatches(SHA256Hasher, maybe.Some(hashing.ComputeHash256([]byte{0})), maybe.Nothing[[]byte]()))
 }
 
+func Test_MultiProof_Empty(t *testing.T) {
+	proof := &MultiProof{}
+	err := proof.Verify(context.Background(), ids.Empty, 4, DefaultHasher)
+	require.ErrorIs(t, err, ErrEmptyProof)
+}
+
+func Test_MultiProof(t *testing.T) {
+	for _, bf := range validBranchFactors {
+		t.Run(fmt.Sprintf("branch factor %d", bf), func(t *testing.T) {
+			require := require.New(t)
+
+			config := newDefaultConfig()
+			config.BranchFactor = bf
+			db, err := newDB(context.Background(), memdb.New(), config)
+			require.NoError(err)
+
+			keys := make([][]byte, 0, 200)
+			batch := db.NewBatch()
+			for i := 0; i < 100; i++ {
+				key := hashing.ComputeHash256([]byte{byte(i)})
+				require.NoError(batch.Put(key, key[:i%(len(key)+1)]))
+				keys = append(keys, key)
+
+				// Keys that aren't in the trie.
+				keys = append(keys, hashing.ComputeHash256([]byte{byte(i), 1}), key[:i%len(key)])
+			}
+			require.NoError(batch.Write())
+
+			ctx := context.Background()
+			proof, err := db.GetMultiProof(ctx, keys)
+			require.NoError(err)
+
+			rootID, err := db.GetMerkleRoot(ctx)
+			require.NoError(err)
+			require.NoError(proof.Verify(ctx, rootID, db.tokenSize, db.hasher))
+
+			// The proof contains each of the keys, with the same value as an
+			// individual proof, and each node only once.
+			var (
+				expectedKeys  = make([]ProvenKey, 0, len(keys))
+				expectedNodes = map[Key]ProofNode{}
+			)
+			for _, key := range keys {
+				keyProof, err := db.GetProof(ctx, key)
+				require.NoError(err)
+				expectedKeys = append(expectedKeys, ProvenKey{
+					Key:   keyProof.Key,
+					Value: keyProof.Value,
+				})
+				for _, proofNode := range keyProof.Path {
+					expectedNodes[proofNode.Key] = proofNode
+				}
+			}
+			slices.SortFunc(expectedKeys, func(a, b ProvenKey) int {
+				return a.Key.Compare(b.Key)
+			})
+			expectedKeys = slices.CompactFunc(expectedKeys, func(a, b ProvenKey) bool {
+				return a.Key == b.Key
+			})
+			require.Equal(expectedKeys, proof.Keys)
+			require.Len(proof.Nodes, len(expectedNodes))
+			for _, proofNode := range proof.Nodes {
+				require.Equal(expectedNodes[proofNode.Key], proofNode)
+			}
+
+			// The proof still verifies after serialization.
+			var unmarshaledProof MultiProof
+			require.NoError(unmarshaledProof.UnmarshalProto(proof.ToProto()))
+			require.NoError(unmarshaledProof.Verify(ctx, rootID, db.tokenSize, db.hasher))
+		})
+	}
+}
+
+func Test_MultiProof_Verify_Bad_Data(t *testing.T) {
+	type test struct {
+		name        string
+		malform     func(proof *MultiProof)
+		expectedErr error
+	}
+
+	// The proven keys are [1], [2], [5] and [16].
+	tests := []test{
+		{
+			name:        "happyPath",
+			malform:     func(*MultiProof) {},
+			expectedErr: nil,
+		},
+		{
+			name: "empty",
+			malform: func(proof *MultiProof) {
+				proof.Nodes = nil
+			},
+			expectedErr: ErrEmptyProof,
+		},
+		{
+			name: "non-increasing keys",
+			malform: func(proof *MultiProof) {
+				proof.Keys[0], proof.Keys[1] = proof.Keys[1], proof.Keys[0]
+			},
+			expectedErr: ErrNonIncreasingValues,
+		},
+		{
+			name: "non-increasing nodes",
+			malform: func(proof *MultiProof) {
+				proof.Nodes[1], proof.Nodes[2] = proof.Nodes[2], proof.Nodes[1]
+			},
+			expectedErr: ErrNonIncreasingProofNodes,
+		},
+		{
+			name: "odd length key path with value",
+			malform: func(proof *MultiProof) {
+				proof.Nodes[0].ValueOrHash = maybe.Some([]byte{1, 2})
+			},
+			expectedErr: ErrPartialByteLengthWithValue,
+		},
+		{
+			name: "node not under root",
+			malform: func(proof *MultiProof) {
+				proof.Nodes = append(proof.Nodes, ProofNode{
+					Key: ToKey([]byte{32}),
+				})
+			},
+			expectedErr: ErrProofNodeNotForKey,
+		},
+		{
+			name: "modified node",
+			malform: func(proof *MultiProof) {
+				proof.Nodes[1].ValueOrHash = maybe.Some([]byte{10})
+			},
+			expectedErr: ErrUnreferencedProofNode,
+		},
+		{
+			name: "modified child ID",
+			malform: func(proof *MultiProof) {
+				for index := range proof.Nodes[0].Children {
+					proof.Nodes[0].Children[index] = ids.GenerateTestID()
+				}
+			},
+			expectedErr: ErrUnreferencedProofNode,
+		},
+		{
+			name: "missing node",
+			malform: func(proof *MultiProof) {
+				proof.Nodes = slices.Delete(proof.Nodes, 2, 3)
+			},
+			expectedErr: ErrMissingProofNode,
+		},
+		{
+			name: "mismatched value",
+			malform: func(proof *MultiProof) {
+				proof.Keys[0].Value = maybe.Some([]byte{10})
+			},
+			expectedErr: ErrProofValueDoesntMatch,
+		},
+		{
+			name: "missing value",
+			malform: func(proof *MultiProof) {
+				proof.Keys[1].Value = maybe.Nothing[[]byte]()
+			},
+			expectedErr: ErrProofValueDoesntMatch,
+		},
+		{
+			name: "value of key without child",
+			malform: func(proof *MultiProof) {
+				proof.Keys[2].Value = maybe.Some([]byte{5})
+			},
+			expectedErr: ErrProofValueDoesntMatch,
+		},
+		{
+			name: "value of key not under root",
+			malform: func(proof *MultiProof) {
+				proof.Keys[3].Value = maybe.Some([]byte{16})
+			},
+			expectedErr: ErrProofValueDoesntMatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			db, err := getBasicDB()
+			require.NoError(err)
+
+			writeBasicBatch(t, db)
+
+			proof, err := db.GetMultiProof(context.Background(), [][]byte{{16}, {5}, {2}, {1}, {2}})
+			require.NoError(err)
+			require.Len(proof.Nodes, 3)
+			require.Len(proof.Keys, 4)
+
+			tt.malform(proof)
+
+			err = proof.Verify(context.Background(), db.getMerkleRoot(), db.tokenSize, db.hasher)
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
+func Test_MultiProof_InvalidRoot(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	writeBasicBatch(t, db)
+
+	proof, err := db.GetMultiProof(context.Background(), [][]byte{{1}, {2}})
+	require.NoError(err)
+
+	err = proof.Verify(context.Background(), ids.GenerateTestID(), db.tokenSize, db.hasher)
+	require.ErrorIs(err, ErrInvalidProof)
+}
+
+func Test_MultiProof_EmptyTrie(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	_, err = db.GetMultiProof(context.Background(), [][]byte{{1}})
+	require.ErrorIs(err, ErrEmptyProof)
+}
+
 func Test_RangeProof_Extra_Value(t *testing.T) {
 	require := require.New(t)
 
@@ -1648,6 +1875,110 @@ func FuzzProofProtoMarshalUnmarshal(f *testing.F) {
 	})
 }
 
+func FuzzMultiProofProtoMarshalUnmarshal(f *testing.F) {
+	f.Fuzz(func(
+		t *testing.T,
+		randSeed int64,
+	) {
+		require := require.New(t)
+		rand := rand.New(rand.NewSource(randSeed)) // #nosec G404
+
+		// Make a random proof.
+		numNodes := rand.Intn(32)
+		proof := MultiProof{
+			Nodes: make([]ProofNode, numNodes),
+		}
+		for i := 0; i < numNodes; i++ {
+			proof.Nodes[i] = newRandomProofNode(rand)
+		}
+
+		numKeys := rand.Intn(32)
+		proof.Keys = make([]ProvenKey, numKeys)
+		for i := 0; i < numKeys; i++ {
+			key := make([]byte, rand.Intn(32))
+			_, _ = rand.Read(key)
+
+			value := maybe.Nothing[[]byte]()
+			if rand.Intn(2) == 1 {
+				valueBytes := make([]byte, rand.Intn(32))
+				_, _ = rand.Read(valueBytes)
+				value = maybe.Some(valueBytes)
+			}
+
+			proof.Keys[i] = ProvenKey{
+				Key:   ToKey(key),
+				Value: value,
+			}
+		}
+
+		// Marshal and unmarshal it.
+		// Assert the unmarshaled one is the same as the original.
+		var unmarshaledProof MultiProof
+		protoProof := proof.ToProto()
+		require.NoError(unmarshaledProof.UnmarshalProto(protoProof))
+		require.Equal(proof, unmarshaledProof)
+
+		// Marshaling again should yield same result.
+		protoUnmarshaledProof := unmarshaledProof.ToProto()
+		require.Equal(protoProof, protoUnmarshaledProof)
+	})
+}
+
+func TestMultiProofProtoUnmarshal(t *testing.T) {
+	tests := []struct {
+		name        string
+		proof       *pb.MultiProof
+		expectedErr error
+	}{
+		{
+			name:        "nil",
+			proof:       nil,
+			expectedErr: ErrNilMultiProof,
+		},
+		{
+			name: "nil node",
+			proof: &pb.MultiProof{
+				Nodes: []*pb.ProofNode{nil},
+			},
+			expectedErr: ErrNilProofNode,
+		},
+		{
+			name: "nil key",
+			proof: &pb.MultiProof{
+				Keys: []*pb.ProvenKey{nil},
+			},
+			expectedErr: ErrNilProvenKey,
+		},
+		{
+			name: "nil value",
+			proof: &pb.MultiProof{
+				Keys: []*pb.ProvenKey{{}},
+			},
+			expectedErr: ErrNilValue,
+		},
+		{
+			name: "invalid maybe",
+			proof: &pb.MultiProof{
+				Keys: []*pb.ProvenKey{{
+					Value: &pb.MaybeBytes{
+						Value:     []byte{1},
+						IsNothing: true,
+					},
+				}},
+			},
+			expectedErr: ErrInvalidMaybe,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var proof MultiProof
+			err := proof.UnmarshalProto(tt.proof)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
 func TestProofProtoUnmarshal(t *testing.T) {
 	type test struct {
 		name        string
@@ -1877,6 +2208,56 @@ func FuzzProofVerification(f *testing.F) {
 	})
 }
 
+func FuzzMultiProofVerification(f *testing.F) {
+	deletePortion := 0.25
+	f.Fuzz(func(
+		t *testing.T,
+		randSeed int64,
+		numKeyValues uint,
+		numKeys uint,
+	) {
+		rand := rand.New(rand.NewSource(randSeed)) // #nosec G404
+		require := require.New(t)
+		db, err := getBasicDB()
+		require.NoError(err)
+
+		// Insert a bunch of random key values.
+		insertRandomKeyValues(
+			require,
+			rand,
+			[]database.Database{db},
+			numKeyValues,
+			deletePortion,
+		)
+
+		if db.getMerkleRoot() == ids.Empty {
+			return
+		}
+
+		// Prove a mix of keys that are and aren't in the trie.
+		keys := make([][]byte, 0, numKeys%64)
+		iter := db.NewIterator()
+		for uint(len(keys)) < numKeys%64 {
+			if rand.Intn(2) == 0 && iter.Next() {
+				keys = append(keys, iter.Key())
+				continue
+			}
+			key := make([]byte, rand.Intn(8))
+			_, _ = rand.Read(key)
+			keys = append(keys, key)
+		}
+		iter.Release()
+
+		proof, err := db.GetMultiProof(context.Background(), keys)
+		require.NoError(err)
+
+		rootID, err := db.GetMerkleRoot(context.Background())
+		require.NoError(err)
+
+		require.NoError(proof.Verify(context.Background(), rootID, db.tokenSize, db.hasher))
+	})
+}
+
 // Generate change proofs and verify that they are valid.
 func FuzzChangeProofVerification(f *testing.F) {
 	const (
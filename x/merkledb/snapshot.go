@@ -0,0 +1,297 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/sync"
+)
+
+const (
+	snapshotVersion uint16 = 0
+
+	// The maximum size of an encoded snapshot chunk. This bounds the memory
+	// used to import a snapshot from an untrusted source.
+	maxSnapshotChunkSize = 256 * units.MiB
+
+	snapshotChunkLenSize      = 4
+	snapshotChunkChecksumSize = sha256.Size
+)
+
+var (
+	snapshotMagic = []byte("merkledb")
+
+	ErrInvalidSnapshot      = errors.New("invalid snapshot")
+	ErrSnapshotRootMismatch = errors.New("snapshot root doesn't match the expected root")
+	ErrSnapshotNonEmptyDB   = errors.New("snapshots can only be imported into an empty database")
+
+	errSnapshotChunkTooLarge = errors.New("snapshot chunk is too large")
+)
+
+// ExportSnapshot writes every key/value pair in [db] when its root was
+// [rootID] to [w].
+//
+// The snapshot consists of a header followed by chunks of at most
+// [chunkSize] key/value pairs, in key order. Each chunk is a range proof of
+// its key/value pairs, so that it can be verified against [rootID] on its
+// own, followed by the checksum of the chunk. The snapshot ends with an empty
+// chunk.
+//
+// The snapshot format is:
+//
+//	+--------------------------------------------+
+//	| magic ("merkledb")                         |
+//	| version (uint16)                           |
+//	| branch factor (uint16)                     |
+//	| root ID (32 bytes)                         |
+//	+--------------------------------------------+
+//	| chunk length (uint32)                      |
+//	| chunk (protobuf encoded RangeProof)        |
+//	| chunk checksum (sha256 of the chunk)       |
+//	+--------------------------------------------+
+//	| ...                                        |
+//	+--------------------------------------------+
+//	| 0 (uint32)                                 |
+//	+--------------------------------------------+
+//
+// If [rootID] isn't the current root of [db], [db] must have sufficient
+// history to generate range proofs at [rootID].
+func ExportSnapshot(
+	ctx context.Context,
+	db MerkleDB,
+	rootID ids.ID,
+	chunkSize int,
+	w io.Writer,
+) error {
+	if chunkSize <= 0 {
+		return fmt.Errorf("%w but was %d", ErrInvalidMaxLength, chunkSize)
+	}
+
+	branchFactor := BranchFactor(1 << db.getTokenSize())
+	header := make([]byte, 0, len(snapshotMagic)+2*wrappers.ShortLen+ids.IDLen)
+	header = append(header, snapshotMagic...)
+	header = binary.BigEndian.AppendUint16(header, snapshotVersion)
+	header = binary.BigEndian.AppendUint16(header, uint16(branchFactor))
+	header = append(header, rootID[:]...)
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	start := maybe.Nothing[[]byte]()
+	for rootID != ids.Empty {
+		proof, err := db.GetRangeProofAtRoot(ctx, rootID, start, maybe.Nothing[[]byte](), chunkSize)
+		if err != nil {
+			return err
+		}
+		if len(proof.KeyValues) == 0 {
+			break
+		}
+
+		chunk, err := proto.Marshal(proof.ToProto())
+		if err != nil {
+			return err
+		}
+		if err := writeSnapshotChunk(w, chunk); err != nil {
+			return err
+		}
+
+		if len(proof.KeyValues) < chunkSize {
+			break
+		}
+		start = maybe.Some(nextKey(proof.KeyValues[len(proof.KeyValues)-1].Key))
+	}
+
+	// The empty chunk marks the end of the snapshot.
+	_, err := w.Write(make([]byte, snapshotChunkLenSize))
+	return err
+}
+
+// ImportSnapshot writes the key/value pairs in the snapshot read from [r],
+// which was written by ExportSnapshot, into the empty database [db].
+//
+// Each chunk is verified to prove the key/value pairs which follow the
+// previous chunk's, at [expectedRootID], before it's committed. Once every
+// chunk has been committed, the root of [db] is verified to be
+// [expectedRootID]. This ensures that the snapshot doesn't need to be
+// trusted. If an error is returned, [db] may contain part of the snapshot
+// and should be cleared before it's used.
+//
+// [hasher] must be the Hasher used by [db]. If nil, [DefaultHasher] is
+// used.
+func ImportSnapshot(
+	ctx context.Context,
+	db MerkleDB,
+	r io.Reader,
+	expectedRootID ids.ID,
+	hasher Hasher,
+) error {
+	if hasher == nil {
+		hasher = DefaultHasher
+	}
+
+	currentRootID, err := db.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if currentRootID != ids.Empty {
+		return ErrSnapshotNonEmptyDB
+	}
+
+	tokenSize := db.getTokenSize()
+	if err := readSnapshotHeader(r, tokenSize, expectedRootID); err != nil {
+		return err
+	}
+
+	start := maybe.Nothing[[]byte]()
+	for {
+		chunk, err := readSnapshotChunk(r)
+		if err != nil {
+			return err
+		}
+		if len(chunk) == 0 {
+			break
+		}
+
+		var protoProof pb.RangeProof
+		if err := proto.Unmarshal(chunk, &protoProof); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+		}
+		var proof RangeProof
+		if err := proof.UnmarshalProto(&protoProof); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+		}
+		if len(proof.KeyValues) == 0 {
+			return fmt.Errorf("%w: chunk has no key/value pairs", ErrInvalidSnapshot)
+		}
+
+		// The start proof of each chunk proves that no keys were skipped
+		// since the previous chunk.
+		if err := proof.Verify(
+			ctx,
+			start,
+			maybe.Nothing[[]byte](),
+			expectedRootID,
+			tokenSize,
+			hasher,
+		); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+		}
+		if err := db.CommitRangeProof(ctx, start, maybe.Nothing[[]byte](), &proof); err != nil {
+			return err
+		}
+
+		start = maybe.Some(nextKey(proof.KeyValues[len(proof.KeyValues)-1].Key))
+	}
+
+	// The chunks don't prove that there are no keys after the last chunk, so
+	// the resulting root must be checked.
+	rootID, err := db.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if rootID != expectedRootID {
+		return fmt.Errorf("%w: got %s, expected %s", ErrSnapshotRootMismatch, rootID, expectedRootID)
+	}
+	return nil
+}
+
+// Returns the smallest key that is larger than [key].
+func nextKey(key []byte) []byte {
+	next := make([]byte, len(key)+1)
+	copy(next, key)
+	return next
+}
+
+func writeSnapshotChunk(w io.Writer, chunk []byte) error {
+	if len(chunk) > maxSnapshotChunkSize {
+		return fmt.Errorf("%w: chunk size %d exceeds maximum %d", errSnapshotChunkTooLarge, len(chunk), maxSnapshotChunkSize)
+	}
+
+	checksum := sha256.Sum256(chunk)
+	if _, err := w.Write(binary.BigEndian.AppendUint32(nil, uint32(len(chunk)))); err != nil {
+		return err
+	}
+	if _, err := w.Write(chunk); err != nil {
+		return err
+	}
+	_, err := w.Write(checksum[:])
+	return err
+}
+
+func readSnapshotHeader(r io.Reader, tokenSize int, expectedRootID ids.ID) error {
+	header := make([]byte, len(snapshotMagic)+2*wrappers.ShortLen+ids.IDLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	if !bytes.Equal(header[:len(snapshotMagic)], snapshotMagic) {
+		return fmt.Errorf("%w: unexpected magic %x", ErrInvalidSnapshot, header[:len(snapshotMagic)])
+	}
+	header = header[len(snapshotMagic):]
+
+	if version := binary.BigEndian.Uint16(header); version != snapshotVersion {
+		return fmt.Errorf("%w: unknown version %d", ErrInvalidSnapshot, version)
+	}
+	header = header[wrappers.ShortLen:]
+
+	expectedBranchFactor := BranchFactor(1 << tokenSize)
+	if branchFactor := BranchFactor(binary.BigEndian.Uint16(header)); branchFactor != expectedBranchFactor {
+		return fmt.Errorf("%w: branch factor %d, expected %d", ErrInvalidSnapshot, branchFactor, expectedBranchFactor)
+	}
+	header = header[wrappers.ShortLen:]
+
+	if rootID := ids.ID(header); rootID != expectedRootID {
+		return fmt.Errorf("%w: got %s, expected %s", ErrSnapshotRootMismatch, rootID, expectedRootID)
+	}
+	return nil
+}
+
+// Returns an empty chunk at the end of the snapshot.
+func readSnapshotChunk(r io.Reader) ([]byte, error) {
+	var chunkLenBytes [snapshotChunkLenSize]byte
+	if _, err := io.ReadFull(r, chunkLenBytes[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	chunkLen := binary.BigEndian.Uint32(chunkLenBytes[:])
+	if chunkLen == 0 {
+		return nil, nil
+	}
+	if chunkLen > maxSnapshotChunkSize {
+		return nil, fmt.Errorf("%w: %w: %d > %d", ErrInvalidSnapshot, errSnapshotChunkTooLarge, chunkLen, maxSnapshotChunkSize)
+	}
+
+	chunk := make([]byte, int(chunkLen)+snapshotChunkChecksumSize)
+	if _, err := io.ReadFull(r, chunk); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	chunk, checksum := chunk[:chunkLen], chunk[chunkLen:]
+	if expectedChecksum := sha256.Sum256(chunk); !bytes.Equal(checksum, expectedChecksum[:]) {
+		return nil, fmt.Errorf("%w: chunk checksum mismatch", ErrInvalidSnapshot)
+	}
+	return chunk, nil
+}
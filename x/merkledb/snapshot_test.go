@@ -0,0 +1,291 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/sync"
+)
+
+// Returns a database with [numKeys] random key/value pairs.
+func newSnapshotTestDB(t *testing.T, r *rand.Rand, config Config, numKeys int) *merkleDB {
+	require := require.New(t)
+
+	db, err := newDB(context.Background(), memdb.New(), config)
+	require.NoError(err)
+
+	batch := db.NewBatch()
+	for i := 0; i < numKeys; i++ {
+		key := make([]byte, r.Intn(32))     // #nosec G404
+		_, _ = r.Read(key)                  // #nosec G404
+		value := make([]byte, r.Intn(64)+1) // #nosec G404
+		_, _ = r.Read(value)                // #nosec G404
+		require.NoError(batch.Put(key, value))
+	}
+	require.NoError(batch.Write())
+	return db
+}
+
+// Returns the header and chunks of [snapshot].
+func splitSnapshot(t *testing.T, snapshot []byte) ([]byte, [][]byte) {
+	require := require.New(t)
+
+	headerLen := len(snapshotMagic) + 2*wrappers.ShortLen + ids.IDLen
+	header := snapshot[:headerLen]
+
+	r := bytes.NewReader(snapshot[headerLen:])
+	var chunks [][]byte
+	for {
+		chunk, err := readSnapshotChunk(r)
+		require.NoError(err)
+		if len(chunk) == 0 {
+			break
+		}
+		chunks = append(chunks, chunk)
+	}
+	require.Zero(r.Len())
+	return header, chunks
+}
+
+// Returns the snapshot with the given [header] and [chunks].
+func joinSnapshot(t *testing.T, header []byte, chunks [][]byte) []byte {
+	require := require.New(t)
+
+	snapshot := bytes.NewBuffer(bytes.Clone(header))
+	for _, chunk := range chunks {
+		require.NoError(writeSnapshotChunk(snapshot, chunk))
+	}
+	_, err := snapshot.Write(make([]byte, snapshotChunkLenSize))
+	require.NoError(err)
+	return snapshot.Bytes()
+}
+
+func TestSnapshotExportImport(t *testing.T) {
+	for _, bf := range validBranchFactors {
+		for _, chunkSize := range []int{1, 7, 1000} {
+			require := require.New(t)
+			r := rand.New(rand.NewSource(int64(chunkSize))) // #nosec G404
+
+			config := newDefaultConfig()
+			config.BranchFactor = bf
+			db := newSnapshotTestDB(t, r, config, 250)
+
+			rootID, err := db.GetMerkleRoot(context.Background())
+			require.NoError(err)
+
+			snapshot := &bytes.Buffer{}
+			require.NoError(ExportSnapshot(context.Background(), db, rootID, chunkSize, snapshot))
+
+			config = newDefaultConfig()
+			config.BranchFactor = bf
+			importedDB, err := newDB(context.Background(), memdb.New(), config)
+			require.NoError(err)
+
+			_, chunks := splitSnapshot(t, snapshot.Bytes())
+			require.NoError(ImportSnapshot(context.Background(), importedDB, snapshot, rootID, nil))
+
+			importedRootID, err := importedDB.GetMerkleRoot(context.Background())
+			require.NoError(err)
+			require.Equal(rootID, importedRootID)
+
+			numKeys := 0
+			it := db.NewIterator()
+			for it.Next() {
+				value, err := importedDB.Get(it.Key())
+				require.NoError(err)
+				require.Equal(it.Value(), value)
+				numKeys++
+			}
+			require.NoError(it.Error())
+			it.Release()
+
+			require.Len(chunks, (numKeys+chunkSize-1)/chunkSize)
+		}
+	}
+}
+
+func TestSnapshotExportHistoricalRoot(t *testing.T) {
+	require := require.New(t)
+	r := rand.New(rand.NewSource(0)) // #nosec G404
+
+	db := newSnapshotTestDB(t, r, newDefaultConfig(), 100)
+	rootID, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	// Change the database after [rootID].
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+	newRootID, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.NotEqual(rootID, newRootID)
+
+	snapshot := &bytes.Buffer{}
+	require.NoError(ExportSnapshot(context.Background(), db, rootID, 10, snapshot))
+
+	importedDB, err := newDB(context.Background(), memdb.New(), newDefaultConfig())
+	require.NoError(err)
+	require.NoError(ImportSnapshot(context.Background(), importedDB, snapshot, rootID, nil))
+}
+
+func TestSnapshotEmpty(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	snapshot := &bytes.Buffer{}
+	require.NoError(ExportSnapshot(context.Background(), db, ids.Empty, 10, snapshot))
+
+	_, chunks := splitSnapshot(t, snapshot.Bytes())
+	require.Empty(chunks)
+
+	importedDB, err := getBasicDB()
+	require.NoError(err)
+	require.NoError(ImportSnapshot(context.Background(), importedDB, snapshot, ids.Empty, nil))
+}
+
+func TestImportSnapshotInvalid(t *testing.T) {
+	r := rand.New(rand.NewSource(0)) // #nosec G404
+	db := newSnapshotTestDB(t, r, newDefaultConfig(), 100)
+
+	rootID, err := db.GetMerkleRoot(context.Background())
+	require.NoError(t, err)
+
+	snapshotBuffer := &bytes.Buffer{}
+	require.NoError(t, ExportSnapshot(context.Background(), db, rootID, 10, snapshotBuffer))
+	snapshot := snapshotBuffer.Bytes()
+	header, chunks := splitSnapshot(t, snapshot)
+	require.Len(t, chunks, 10)
+
+	tests := []struct {
+		name           string
+		snapshot       func() []byte
+		expectedRootID ids.ID
+		expectedErr    error
+	}{
+		{
+			name: "unexpected root",
+			snapshot: func() []byte {
+				return snapshot
+			},
+			expectedRootID: ids.GenerateTestID(),
+			expectedErr:    ErrSnapshotRootMismatch,
+		},
+		{
+			name: "invalid magic",
+			snapshot: func() []byte {
+				modified := bytes.Clone(snapshot)
+				modified[0]++
+				return modified
+			},
+			expectedRootID: rootID,
+			expectedErr:    ErrInvalidSnapshot,
+		},
+		{
+			name: "unknown version",
+			snapshot: func() []byte {
+				modified := bytes.Clone(snapshot)
+				modified[len(snapshotMagic)]++
+				return modified
+			},
+			expectedRootID: rootID,
+			expectedErr:    ErrInvalidSnapshot,
+		},
+		{
+			name: "invalid checksum",
+			snapshot: func() []byte {
+				modified := bytes.Clone(snapshot)
+				modified[len(header)+snapshotChunkLenSize]++
+				return modified
+			},
+			expectedRootID: rootID,
+			expectedErr:    ErrInvalidSnapshot,
+		},
+		{
+			name: "truncated",
+			snapshot: func() []byte {
+				return snapshot[:len(snapshot)-1]
+			},
+			expectedRootID: rootID,
+			expectedErr:    io.ErrUnexpectedEOF,
+		},
+		{
+			name: "modified value",
+			snapshot: func() []byte {
+				var protoProof pb.RangeProof
+				require.NoError(t, proto.Unmarshal(chunks[0], &protoProof))
+				protoProof.KeyValues[0].Value = append(protoProof.KeyValues[0].Value, 0)
+				chunk, err := proto.Marshal(&protoProof)
+				require.NoError(t, err)
+
+				modifiedChunks := append([][]byte{chunk}, chunks[1:]...)
+				return joinSnapshot(t, header, modifiedChunks)
+			},
+			expectedRootID: rootID,
+			expectedErr:    ErrInvalidSnapshot,
+		},
+		{
+			name: "skipped chunk",
+			snapshot: func() []byte {
+				modifiedChunks := append([][]byte{chunks[0]}, chunks[2:]...)
+				return joinSnapshot(t, header, modifiedChunks)
+			},
+			expectedRootID: rootID,
+			expectedErr:    ErrInvalidSnapshot,
+		},
+		{
+			name: "missing last chunk",
+			snapshot: func() []byte {
+				return joinSnapshot(t, header, chunks[:len(chunks)-1])
+			},
+			expectedRootID: rootID,
+			expectedErr:    ErrSnapshotRootMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			importedDB, err := getBasicDB()
+			require.NoError(err)
+
+			err = ImportSnapshot(
+				context.Background(),
+				importedDB,
+				bytes.NewReader(tt.snapshot()),
+				tt.expectedRootID,
+				nil,
+			)
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
+func TestImportSnapshotNonEmptyDB(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+	writeBasicBatch(t, db)
+
+	rootID, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	snapshot := &bytes.Buffer{}
+	require.NoError(ExportSnapshot(context.Background(), db, rootID, 10, snapshot))
+
+	err = ImportSnapshot(context.Background(), db, snapshot, rootID, nil)
+	require.ErrorIs(err, ErrSnapshotNonEmptyDB)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 type ViewChanges struct {
@@ -76,6 +77,19 @@ type Trie interface {
 	// Returns ErrEmptyProof if the trie is empty.
 	GetRangeProof(ctx context.Context, start maybe.Maybe[[]byte], end maybe.Maybe[[]byte], maxLength int) (*RangeProof, error)
 
+	// GetMultiProof generates a proof of the values associated with [keys],
+	// or proofs of their absence from the trie.
+	// Returns ErrEmptyProof if the trie is empty.
+	GetMultiProof(ctx context.Context, keys [][]byte) (*MultiProof, error)
+
+	// GetEthereumProof generates a proof of the value associated with [key],
+	// or a proof of its absence from the trie, in the format of Ethereum's
+	// eth_getProof. That is, the RLP encoded trie nodes on the path to [key].
+	// Returns ErrNotEthereumHasher if the trie isn't hashed with
+	// [EthereumHasher].
+	// Returns ErrEmptyProof if the trie is empty.
+	GetEthereumProof(ctx context.Context, key []byte) ([][]byte, error)
+
 	// NewView returns a new view on top of this Trie where the passed changes
 	// have been applied.
 	NewView(
@@ -191,6 +205,52 @@ func getProof(t Trie, key []byte) (*Proof, error) {
 	return proof, nil
 }
 
+// Returns a proof that each of [keys] is in or not in trie [t].
+// Assumes [t] doesn't change while this function is running.
+func getMultiProof(t Trie, keys [][]byte) (*MultiProof, error) {
+	root := t.getRoot()
+	if root.IsNothing() {
+		return nil, ErrEmptyProof
+	}
+
+	keys = slices.Clone(keys)
+	slices.SortFunc(keys, bytes.Compare)
+	keys = slices.CompactFunc(keys, bytes.Equal)
+
+	var (
+		rootNode = root.Value()
+		proof    = &MultiProof{
+			Nodes: []ProofNode{rootNode.asProofNode()},
+			Keys:  make([]ProvenKey, len(keys)),
+		}
+		included = set.Of(rootNode.key)
+	)
+	for i, key := range keys {
+		keyProof, err := getProof(t, key)
+		if err != nil {
+			return nil, err
+		}
+		proof.Keys[i] = ProvenKey{
+			Key:   keyProof.Key,
+			Value: keyProof.Value,
+		}
+
+		// Paths of different keys share their nodes near the root.
+		for _, proofNode := range keyProof.Path {
+			if included.Contains(proofNode.Key) {
+				continue
+			}
+			included.Add(proofNode.Key)
+			proof.Nodes = append(proof.Nodes, proofNode)
+		}
+	}
+
+	slices.SortFunc(proof.Nodes, func(a, b ProofNode) int {
+		return a.Key.Compare(b.Key)
+	})
+	return proof, nil
+}
+
 // GetRangeProof returns a range proof for (at least part of) the key range [start, end].
 // The returned proof's [KeyValues] has at most [maxLength] values.
 // [maxLength] must be > 0.
@@ -50,9 +50,18 @@ func newValueNodeDB(
 	}
 }
 
+// Write writes [n] to [batch] and the cache.
+// If [n] is nil, [key] is deleted.
 func (db *valueNodeDB) Write(batch database.KeyValueWriterDeleter, key Key, n *node) error {
+	db.Cache(key, n)
+	return db.Stage(batch, key, n)
+}
+
+// Stage writes [n] to [batch] without updating the cache. The cache must be
+// updated with Cache when [batch] is written.
+// If [n] is nil, [key] is deleted.
+func (db *valueNodeDB) Stage(batch database.KeyValueWriterDeleter, key Key, n *node) error {
 	db.metrics.DatabaseNodeWrite()
-	db.nodeCache.Put(key, n)
 	prefixedKey := addPrefixToKey(db.bufferPool, valueNodePrefix, key.Bytes())
 	defer db.bufferPool.Put(prefixedKey)
 
@@ -62,6 +71,12 @@ func (db *valueNodeDB) Write(batch database.KeyValueWriterDeleter, key Key, n *n
 	return batch.Put(*prefixedKey, n.bytes())
 }
 
+// Cache records [n] as the node with [key].
+// If [n] is nil, [key] is recorded as deleted.
+func (db *valueNodeDB) Cache(key Key, n *node) {
+	db.nodeCache.Put(key, n)
+}
+
 func (db *valueNodeDB) newIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
 	prefixedStart := addPrefixToKey(db.bufferPool, valueNodePrefix, start)
 	defer db.bufferPool.Put(prefixedStart)
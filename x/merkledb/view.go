@@ -37,6 +37,7 @@ var (
 	ErrNoChanges              = errors.New("no changes provided")
 	ErrParentNotDatabase      = errors.New("parent trie is not database")
 	ErrNodesAlreadyCalculated = errors.New("cannot modify the trie after the node changes have been calculated")
+	ErrReadOnly               = errors.New("view is read-only")
 )
 
 type view struct {
@@ -99,6 +100,10 @@ type view struct {
 	root maybe.Maybe[*node]
 
 	tokenSize int
+
+	// If true, this view represents a historical state of [db] and can't be
+	// committed.
+	readOnly bool
 }
 
 // NewView returns a new view on top of this view where the passed changes
@@ -284,7 +289,7 @@ func (v *view) hashChangedNodes(ctx context.Context) {
 	// If there are no children, we can avoid allocating [keyBuffer].
 	root := v.root.Value()
 	if len(root.children) == 0 {
-		v.changes.rootID = v.db.hasher.HashNode(root)
+		v.changes.rootID = hashRoot(v.db.hasher, root)
 		v.db.metrics.HashCalculated()
 		return
 	}
@@ -292,12 +297,15 @@ func (v *view) hashChangedNodes(ctx context.Context) {
 	// Allocate [keyBuffer] and populate it with the root node's key.
 	keyBuffer := v.db.hashNodesKeyPool.Acquire()
 	keyBuffer = v.setKeyBuffer(root, keyBuffer)
-	v.changes.rootID, keyBuffer = v.hashChangedNode(root, keyBuffer)
+	keyBuffer = v.hashChangedNode(root, keyBuffer)
 	v.db.hashNodesKeyPool.Release(keyBuffer)
+
+	v.changes.rootID = hashRoot(v.db.hasher, root)
+	v.db.metrics.HashCalculated()
 }
 
-// Calculates the ID of all descendants of [n] which need to be recalculated,
-// and then calculates the ID of [n] itself.
+// Calculates the ID of all descendants of [n] which need to be recalculated.
+// The caller is responsible for calculating the ID of [n] itself.
 //
 // Returns a potentially expanded [keyBuffer]. By returning this value this
 // function is able to have a maximum total number of allocations shared across
@@ -305,7 +313,7 @@ func (v *view) hashChangedNodes(ctx context.Context) {
 //
 // Invariant: [keyBuffer] must be populated with [n]'s key and have sufficient
 // length to contain any of [n]'s child keys.
-func (v *view) hashChangedNode(n *node, keyBuffer []byte) (ids.ID, []byte) {
+func (v *view) hashChangedNode(n *node, keyBuffer []byte) []byte {
 	var (
 		// childBuffer is allocated on the stack.
 		childBuffer = make([]byte, 1)
@@ -371,7 +379,7 @@ func (v *view) hashChangedNode(n *node, keyBuffer []byte) (ids.ID, []byte) {
 		// If there are no children of the childNode, we can avoid constructing
 		// the buffer for the child keys.
 		if len(childNode.children) == 0 {
-			childEntry.id = v.db.hasher.HashNode(childNode)
+			hashChild(v.db.hasher, childEntry, childNode)
 			v.db.metrics.HashCalculated()
 			continue
 		}
@@ -381,8 +389,11 @@ func (v *view) hashChangedNode(n *node, keyBuffer []byte) (ids.ID, []byte) {
 			wg.Add(1)
 			go func(wg *sync.WaitGroup, childEntry *child, childNode *node, childKeyBuffer []byte) {
 				childKeyBuffer = v.setKeyBuffer(childNode, childKeyBuffer)
-				childEntry.id, childKeyBuffer = v.hashChangedNode(childNode, childKeyBuffer)
+				childKeyBuffer = v.hashChangedNode(childNode, childKeyBuffer)
 				v.db.hashNodesKeyPool.Release(childKeyBuffer)
+
+				hashChild(v.db.hasher, childEntry, childNode)
+				v.db.metrics.HashCalculated()
 				wg.Done()
 			}(wg.wg, childEntry, childNode, childKeyBuffer)
 		} else {
@@ -391,16 +402,16 @@ func (v *view) hashChangedNode(n *node, keyBuffer []byte) (ids.ID, []byte) {
 			// We can skip copying the key here because [keyBuffer] is already
 			// constructed to be childNode's key.
 			keyBuffer = v.setLengthForChildren(childNode, keyBuffer)
-			childEntry.id, keyBuffer = v.hashChangedNode(childNode, keyBuffer)
+			keyBuffer = v.hashChangedNode(childNode, keyBuffer)
+
+			hashChild(v.db.hasher, childEntry, childNode)
+			v.db.metrics.HashCalculated()
 		}
 	}
 
 	// Wait until all descendants of [n] have been updated.
 	wg.Wait()
-
-	// The IDs [n]'s descendants are up to date so we can calculate [n]'s ID.
-	v.db.metrics.HashCalculated()
-	return v.db.hasher.HashNode(n), keyBuffer
+	return keyBuffer
 }
 
 // setKeyBuffer expands [keyBuffer] to have sufficient size for any of [n]'s
@@ -450,6 +461,48 @@ func (v *view) GetProof(ctx context.Context, key []byte) (*Proof, error) {
 	return result, nil
 }
 
+// GetMultiProof returns a proof that each of [keys] is in or not in trie [t].
+func (v *view) GetMultiProof(ctx context.Context, keys [][]byte) (*MultiProof, error) {
+	_, span := v.db.infoTracer.Start(ctx, "MerkleDB.view.GetMultiProof")
+	defer span.End()
+
+	if err := v.applyValueChanges(ctx); err != nil {
+		return nil, err
+	}
+
+	result, err := getMultiProof(v, keys)
+	if err != nil {
+		return nil, err
+	}
+	if v.isInvalid() {
+		return nil, ErrInvalid
+	}
+	return result, nil
+}
+
+// GetEthereumProof returns a proof that [key] is in or not in trie [t] in the
+// format of Ethereum's eth_getProof.
+func (v *view) GetEthereumProof(ctx context.Context, key []byte) ([][]byte, error) {
+	_, span := v.db.infoTracer.Start(ctx, "MerkleDB.view.GetEthereumProof")
+	defer span.End()
+
+	if _, ok := v.db.hasher.(*ethereumHasher); !ok {
+		return nil, ErrNotEthereumHasher
+	}
+	if err := v.applyValueChanges(ctx); err != nil {
+		return nil, err
+	}
+
+	result, err := getEthereumProof(v, key)
+	if err != nil {
+		return nil, err
+	}
+	if v.isInvalid() {
+		return nil, ErrInvalid
+	}
+	return result, nil
+}
+
 // GetRangeProof returns a range proof for (at least part of) the key range [start, end].
 // The returned proof's [KeyValues] has at most [maxLength] values.
 // [maxLength] must be > 0.
@@ -490,6 +543,10 @@ func (v *view) CommitToDB(ctx context.Context) error {
 // this view to its parent, and so on until committing to the db.
 // Assumes [v.db.commitLock] is held.
 func (v *view) commitToDB(ctx context.Context) error {
+	if v.readOnly {
+		return ErrReadOnly
+	}
+
 	v.commitLock.Lock()
 	defer v.commitLock.Unlock()
 
@@ -722,6 +779,9 @@ func (v *view) compressNodePath(parent, n *node) error {
 			id:            childEntry.id,
 			hasValue:      childEntry.hasValue,
 		})
+	if err := v.recordNodeMoved(childKey, childEntry.hasValue); err != nil {
+		return err
+	}
 	return v.recordNodeChange(parent)
 }
 
@@ -782,17 +842,17 @@ func (v *view) insert(
 			commonPrefixLength = getLengthOfCommonPrefix(oldRoot.key, key, 0 /*offset*/, v.tokenSize)
 			commonPrefix       = oldRoot.key.Take(commonPrefixLength)
 			newRoot            = newNode(commonPrefix)
-			oldRootID          = v.db.hasher.HashNode(oldRoot)
 		)
-		v.db.metrics.HashCalculated()
 
-		// Call addChildWithID instead of addChild so the old root is added
-		// to the new root with the correct ID.
+		// Calculate the old root's ID as a child of the new root so it is
+		// added to the new root with the correct ID.
 		// TODO:
-		// [oldRootID] shouldn't need to be calculated here.
-		// Either oldRootID should already be calculated or will be calculated at the end with the other nodes
-		// Initialize the v.changes.rootID during newView and then use that here instead of oldRootID
-		newRoot.addChildWithID(oldRoot, v.tokenSize, oldRootID)
+		// The old root's ID shouldn't need to be calculated here.
+		// Either it should already be calculated or will be calculated at the end with the other nodes
+		// Initialize the v.changes.rootID during newView and then use that here instead
+		newRoot.addChild(oldRoot, v.tokenSize)
+		hashChild(v.db.hasher, newRoot.children[oldRoot.key.Token(newRoot.key.length, v.tokenSize)], oldRoot)
+		v.db.metrics.HashCalculated()
 		if err := v.recordNewNode(newRoot); err != nil {
 			return nil, err
 		}
@@ -812,7 +872,8 @@ func (v *view) insert(
 	// key that hasn't been matched yet
 	// Note that [key] has prefix [closestNode.key], so [key] must be longer
 	// and the following index won't OOB.
-	existingChildEntry, hasChild := closestNode.children[key.Token(closestNode.key.length, v.tokenSize)]
+	existingChildIndex := key.Token(closestNode.key.length, v.tokenSize)
+	existingChildEntry, hasChild := closestNode.children[existingChildIndex]
 	if !hasChild {
 		// there are no existing nodes along the key [key], so create a new node to insert [value]
 		newNode := newNode(key)
@@ -870,6 +931,13 @@ func (v *view) insert(
 			id:            existingChildEntry.id,
 			hasValue:      existingChildEntry.hasValue,
 		})
+	existingChildKey := closestNode.key.Extend(
+		ToToken(existingChildIndex, v.tokenSize),
+		existingChildEntry.compressedKey,
+	)
+	if err := v.recordNodeMoved(existingChildKey, existingChildEntry.hasValue); err != nil {
+		return nil, err
+	}
 
 	return nodeWithValue, v.recordNewNode(branchNode)
 }
@@ -895,6 +963,22 @@ func (v *view) recordNodeChange(after *node) error {
 	return v.recordKeyChange(after.key, after, after.hasValue(), false /* newNode */)
 }
 
+// Records that the node with [key] has a new compressed key because its parent
+// changed. The node itself is unchanged, but if the hasher hashes nodes
+// relative to their parent, its ID must be recalculated.
+// Must not be called after [applyValueChanges] has returned.
+func (v *view) recordNodeMoved(key Key, hasValue bool) error {
+	if _, ok := v.db.hasher.(pathHasher); !ok {
+		return nil
+	}
+
+	n, err := v.getNode(key, hasValue)
+	if err != nil {
+		return err
+	}
+	return v.recordNodeChange(n)
+}
+
 // Records that the node associated with the given key has been deleted.
 // Must not be called after [applyValueChanges] has returned.
 func (v *view) recordNodeDeleted(after *node, hadValue bool) error {
@@ -19,6 +19,7 @@ import (
 	"golang.org/x/exp/maps"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -138,6 +139,22 @@ type Manager struct {
 
 	stateSyncNodeIdx uint32
 	metrics          SyncMetrics
+
+	// The amount of data applied to [config.DB].
+	bytesSynced  atomic.Uint64
+	leavesSynced atomic.Uint64
+	// The time the sync was started and the fraction of the key space that
+	// was already completed at that time.
+	// [workLock] must be held when accessing [startTime] or [startFraction].
+	startTime     time.Time
+	startFraction float64
+
+	// The number of work items completed, and the time, since the completed
+	// work items were last persisted.
+	// [workLock] must be held when accessing [unpersistedWorkItems] or
+	// [lastPersistTime].
+	unpersistedWorkItems int
+	lastPersistTime      time.Time
 }
 
 // TODO remove non-config values out of this struct
@@ -152,6 +169,13 @@ type ManagerConfig struct {
 	StateSyncNodes        []ids.NodeID
 	// If not specified, [merkledb.DefaultHasher] will be used.
 	Hasher merkledb.Hasher
+	// If specified, the completed key ranges are persisted to [ProgressDB] so
+	// that a restarted sync to the same [TargetRoot] doesn't fetch them again.
+	// They are persisted periodically, and when the sync is closed or its
+	// target changes, so a sync that wasn't closed fetches the ranges
+	// completed since they were last persisted again.
+	// [ProgressDB] must not be shared with any other writer.
+	ProgressDB database.Database
 }
 
 func NewManager(config ManagerConfig, registerer prometheus.Registerer) (*Manager, error) {
@@ -203,9 +227,15 @@ func (m *Manager) Start(ctx context.Context) error {
 
 	m.config.Log.Info("starting sync", zap.Stringer("target root", m.config.TargetRoot))
 
-	// Add work item to fetch the entire key range.
-	// Note that this will be the first work item to be processed.
-	m.unprocessedWork.Insert(newWorkItem(ids.Empty, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), lowPriority, time.Now()))
+	// Add work items to fetch the key ranges that weren't completed by a
+	// previous sync to the same target root. If there was no such sync, this
+	// is the entire key range.
+	if err := m.loadProgress(); err != nil {
+		return err
+	}
+	m.startTime = time.Now()
+	m.startFraction = m.completedFraction()
+	m.lastPersistTime = m.startTime
 
 	m.syncing = true
 	ctx, m.cancelCtx = context.WithCancel(ctx)
@@ -272,6 +302,15 @@ func (m *Manager) close() {
 			m.cancelCtx()
 		}
 
+		// Persist the work completed since the progress was last persisted.
+		// If the sync wasn't started, the persisted progress wasn't loaded,
+		// so it must not be overwritten.
+		if m.syncing {
+			if err := m.persistProgress(); err != nil {
+				m.config.Log.Warn("failed to persist sync progress", zap.Error(err))
+			}
+		}
+
 		// ensure any goroutines waiting for work from the heaps gets released
 		m.unprocessedWork.Close()
 		m.unprocessedWorkCond.Signal()
@@ -541,6 +580,8 @@ func (m *Manager) handleRangeProofResponse(
 		return nil
 	}
 
+	m.recordSynced(len(responseBytes), len(rangeProof.KeyValues))
+
 	if len(rangeProof.KeyValues) > 0 {
 		largestHandledKey = maybe.Some(rangeProof.KeyValues[len(rangeProof.KeyValues)-1].Key)
 	}
@@ -610,6 +651,7 @@ func (m *Manager) handleChangeProofResponse(
 			}
 			largestHandledKey = maybe.Some(changeProof.KeyChanges[len(changeProof.KeyChanges)-1].Key)
 		}
+		m.recordSynced(len(responseBytes), len(changeProof.KeyChanges))
 
 		m.completeWorkItem(ctx, work, largestHandledKey, targetRootID, changeProof.EndProof)
 	case *pb.SyncGetChangeProofResponse_RangeProof:
@@ -642,6 +684,7 @@ func (m *Manager) handleChangeProofResponse(
 			}
 			largestHandledKey = maybe.Some(rangeProof.KeyValues[len(rangeProof.KeyValues)-1].Key)
 		}
+		m.recordSynced(len(responseBytes), len(rangeProof.KeyValues))
 
 		m.completeWorkItem(ctx, work, largestHandledKey, targetRootID, rangeProof.EndProof)
 	default:
@@ -898,7 +941,11 @@ func (m *Manager) UpdateSyncTarget(syncTargetRoot ids.ID) error {
 		// waiting on [m.unprocessedWorkCond].
 		m.unprocessedWorkCond.Signal()
 	}
-	return nil
+
+	// None of the completed ranges are synced to the new target root.
+	m.startTime = time.Now()
+	m.startFraction = 0
+	return m.persistProgress()
 }
 
 func (m *Manager) getTargetRoot() ids.ID {
@@ -972,6 +1019,10 @@ func (m *Manager) completeWorkItem(ctx context.Context, work *workItem, largestH
 		defer m.workLock.Unlock()
 
 		m.processedWork.MergeInsert(newWorkItem(rootID, work.start, largestHandledKey, work.priority, time.Now()))
+		if err := m.maybePersistProgress(); err != nil {
+			m.setError(err)
+			return
+		}
 	}
 
 	// completed the range [work.start, lastKey], log and record in the completed work heap
@@ -0,0 +1,329 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	peerLabel         = "peer"
+	validatorPeer     = "validator"
+	nonValidatorPeer  = "non_validator"
+	reasonLabel       = "reason"
+	requestQuotaLabel = "request_quota"
+	bytesQuotaLabel   = "bytes_quota"
+	concurrencyLabel  = "concurrency"
+)
+
+var (
+	// ErrQuotaExceeded is returned to a peer that has exceeded its request or
+	// byte quota.
+	ErrQuotaExceeded = &common.AppError{
+		Code:    p2p.ErrThrottled.Code,
+		Message: "proof request quota exceeded",
+	}
+	// ErrServerBusy is returned when the server is already serving as many
+	// requests as it's allowed to serve concurrently.
+	ErrServerBusy = &common.AppError{
+		Code:    p2p.ErrThrottled.Code,
+		Message: "too many concurrent proof requests",
+	}
+
+	errInvalidMaxConcurrentRequests             = errors.New("max concurrent requests must be greater than 0")
+	errInvalidMaxNonValidatorConcurrentRequests = errors.New("max non-validator concurrent requests must be in [0, max concurrent requests]")
+	errInvalidQuotaPeriod                       = errors.New("quota period must be greater than 0")
+	errInvalidPeerRequestQuota                  = errors.New("peer request quota must be greater than 0")
+	errInvalidPeerBytesQuota                    = errors.New("peer bytes quota must be greater than 0")
+
+	_ p2p.Handler = (*LimitedHandler)(nil)
+)
+
+type ServerLimiterConfig struct {
+	// The maximum number of requests served concurrently.
+	MaxConcurrentRequests int
+	// The maximum number of requests from non-validators served concurrently.
+	// The remaining [MaxConcurrentRequests] are reserved for validators.
+	MaxNonValidatorConcurrentRequests int
+	// The period over which the per-peer quotas are enforced.
+	QuotaPeriod time.Duration
+	// The maximum number of requests each peer may make during [QuotaPeriod].
+	PeerRequestQuota int
+	// The maximum number of response bytes each peer may be sent during
+	// [QuotaPeriod].
+	PeerBytesQuota int
+}
+
+func DefaultServerLimiterConfig() ServerLimiterConfig {
+	return ServerLimiterConfig{
+		MaxConcurrentRequests:             16,
+		MaxNonValidatorConcurrentRequests: 4,
+		QuotaPeriod:                       time.Minute,
+		PeerRequestQuota:                  600,
+		PeerBytesQuota:                    512 * units.MiB,
+	}
+}
+
+func (c *ServerLimiterConfig) Verify() error {
+	switch {
+	case c.MaxConcurrentRequests <= 0:
+		return errInvalidMaxConcurrentRequests
+	case c.MaxNonValidatorConcurrentRequests < 0 || c.MaxNonValidatorConcurrentRequests > c.MaxConcurrentRequests:
+		return errInvalidMaxNonValidatorConcurrentRequests
+	case c.QuotaPeriod <= 0:
+		return errInvalidQuotaPeriod
+	case c.PeerRequestQuota <= 0:
+		return errInvalidPeerRequestQuota
+	case c.PeerBytesQuota <= 0:
+		return errInvalidPeerBytesQuota
+	default:
+		return nil
+	}
+}
+
+// ServerLimiter limits the proof requests a node serves, so that syncing peers
+// can't saturate its disk.
+//
+// Each peer may make at most [PeerRequestQuota] requests and be sent at most
+// [PeerBytesQuota] response bytes over a sliding window of [QuotaPeriod].
+// Validators are prioritized over non-validators by reserving some of the
+// concurrent requests for validators.
+//
+// A ServerLimiter may be shared by multiple handlers, in which case the limits
+// apply to their requests in aggregate.
+type ServerLimiter struct {
+	config     ServerLimiterConfig
+	validators p2p.ValidatorSet
+	clock      mockable.Clock
+
+	requestsServed   *prometheus.CounterVec
+	requestsRejected *prometheus.CounterVec
+	bytesServed      *prometheus.CounterVec
+	requestsInFlight prometheus.Gauge
+
+	lock                 sync.Mutex
+	inFlight             int
+	nonValidatorInFlight int
+	currentWindow        int
+	windows              [2]quotaWindow
+}
+
+// quotaWindow is the usage of each peer during the period beginning at
+// [start].
+type quotaWindow struct {
+	start time.Time
+	usage map[ids.NodeID]quotaUsage
+}
+
+type quotaUsage struct {
+	requests float64
+	bytes    float64
+}
+
+func NewServerLimiter(
+	config ServerLimiterConfig,
+	validators p2p.ValidatorSet,
+	namespace string,
+	reg prometheus.Registerer,
+) (*ServerLimiter, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	l := &ServerLimiter{
+		config:     config,
+		validators: validators,
+		requestsServed: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "server_requests_served",
+				Help:      "cumulative amount of proof requests served",
+			},
+			[]string{peerLabel},
+		),
+		requestsRejected: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "server_requests_rejected",
+				Help:      "cumulative amount of proof requests rejected due to server limits",
+			},
+			[]string{peerLabel, reasonLabel},
+		),
+		bytesServed: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "server_bytes_served",
+				Help:      "cumulative amount of proof response bytes served",
+			},
+			[]string{peerLabel},
+		),
+		requestsInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "server_requests_in_flight",
+			Help:      "number of proof requests currently being served",
+		}),
+	}
+
+	now := l.clock.Time()
+	l.windows = [2]quotaWindow{
+		{
+			start: now,
+			usage: make(map[ids.NodeID]quotaUsage),
+		},
+		{
+			start: now.Add(-config.QuotaPeriod),
+			usage: make(map[ids.NodeID]quotaUsage),
+		},
+	}
+
+	err := errors.Join(
+		reg.Register(l.requestsServed),
+		reg.Register(l.requestsRejected),
+		reg.Register(l.bytesServed),
+		reg.Register(l.requestsInFlight),
+	)
+	return l, err
+}
+
+// acquire returns nil iff a request from [nodeID] should be served. If nil is
+// returned, release must be called once the request has been served.
+func (l *ServerLimiter) acquire(nodeID ids.NodeID, isValidator bool) *common.AppError {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	peer := peerLabelValue(isValidator)
+	usage := l.estimatedUsage(nodeID)
+	switch {
+	case usage.requests >= float64(l.config.PeerRequestQuota):
+		l.requestsRejected.WithLabelValues(peer, requestQuotaLabel).Inc()
+		return ErrQuotaExceeded
+	case usage.bytes >= float64(l.config.PeerBytesQuota):
+		l.requestsRejected.WithLabelValues(peer, bytesQuotaLabel).Inc()
+		return ErrQuotaExceeded
+	case l.inFlight >= l.config.MaxConcurrentRequests,
+		!isValidator && l.nonValidatorInFlight >= l.config.MaxNonValidatorConcurrentRequests:
+		l.requestsRejected.WithLabelValues(peer, concurrencyLabel).Inc()
+		return ErrServerBusy
+	}
+
+	l.inFlight++
+	if !isValidator {
+		l.nonValidatorInFlight++
+	}
+	l.requestsInFlight.Set(float64(l.inFlight))
+	l.addUsage(nodeID, quotaUsage{requests: 1})
+	return nil
+}
+
+// release marks a request from [nodeID] as served, with a response of
+// [numBytes] bytes.
+func (l *ServerLimiter) release(nodeID ids.NodeID, isValidator bool, numBytes int) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.inFlight--
+	if !isValidator {
+		l.nonValidatorInFlight--
+	}
+	l.requestsInFlight.Set(float64(l.inFlight))
+	l.addUsage(nodeID, quotaUsage{bytes: float64(numBytes)})
+
+	peer := peerLabelValue(isValidator)
+	l.requestsServed.WithLabelValues(peer).Inc()
+	l.bytesServed.WithLabelValues(peer).Add(float64(numBytes))
+}
+
+// Returns the usage of [nodeID] over the last [QuotaPeriod].
+//
+// This is calculated by adding the current window's usage to a weighted usage
+// of the previous window, as in [p2p.SlidingWindowThrottler].
+//
+// Assumes [l.lock] is held.
+func (l *ServerLimiter) estimatedUsage(nodeID ids.NodeID) quotaUsage {
+	// The current window becomes the previous window if the current period is
+	// over
+	now := l.clock.Time()
+	period := l.config.QuotaPeriod
+	sinceUpdate := now.Sub(l.windows[l.currentWindow].start)
+	if sinceUpdate >= 2*period {
+		l.rotate(now.Add(-period))
+	}
+	if sinceUpdate >= period {
+		l.rotate(now)
+		sinceUpdate = 0
+	}
+
+	current := l.windows[l.currentWindow].usage[nodeID]
+	previous := l.windows[1-l.currentWindow].usage[nodeID]
+	previousFraction := float64(period-sinceUpdate) / float64(period)
+	return quotaUsage{
+		requests: current.requests + previousFraction*previous.requests,
+		bytes:    current.bytes + previousFraction*previous.bytes,
+	}
+}
+
+// Assumes [l.lock] is held.
+func (l *ServerLimiter) addUsage(nodeID ids.NodeID, usage quotaUsage) {
+	currentUsage := l.windows[l.currentWindow].usage
+	total := currentUsage[nodeID]
+	total.requests += usage.requests
+	total.bytes += usage.bytes
+	currentUsage[nodeID] = total
+}
+
+// Assumes [l.lock] is held.
+func (l *ServerLimiter) rotate(t time.Time) {
+	l.currentWindow = 1 - l.currentWindow
+	l.windows[l.currentWindow] = quotaWindow{
+		start: t,
+		usage: make(map[ids.NodeID]quotaUsage),
+	}
+}
+
+func peerLabelValue(isValidator bool) string {
+	if isValidator {
+		return validatorPeer
+	}
+	return nonValidatorPeer
+}
+
+// NewLimitedHandler returns a handler that serves requests with [handler]
+// within the limits of [limiter].
+func NewLimitedHandler(handler p2p.Handler, limiter *ServerLimiter) *LimitedHandler {
+	return &LimitedHandler{
+		handler: handler,
+		limiter: limiter,
+	}
+}
+
+type LimitedHandler struct {
+	handler p2p.Handler
+	limiter *ServerLimiter
+}
+
+func (l *LimitedHandler) AppGossip(ctx context.Context, nodeID ids.NodeID, gossipBytes []byte) {
+	l.handler.AppGossip(ctx, nodeID, gossipBytes)
+}
+
+func (l *LimitedHandler) AppRequest(ctx context.Context, nodeID ids.NodeID, deadline time.Time, requestBytes []byte) ([]byte, *common.AppError) {
+	isValidator := l.limiter.validators.Has(ctx, nodeID)
+	if err := l.limiter.acquire(nodeID, isValidator); err != nil {
+		return nil, err
+	}
+
+	responseBytes, err := l.handler.AppRequest(ctx, nodeID, deadline, requestBytes)
+	l.limiter.release(nodeID, isValidator, len(responseBytes))
+	return responseBytes, err
+}
@@ -0,0 +1,252 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var _ p2p.ValidatorSet = (*testValidatorSet)(nil)
+
+type testValidatorSet struct {
+	validators set.Set[ids.NodeID]
+}
+
+func (t testValidatorSet) Has(_ context.Context, nodeID ids.NodeID) bool {
+	return t.validators.Contains(nodeID)
+}
+
+func TestServerLimiterConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*ServerLimiterConfig)
+		expectedErr error
+	}{
+		{
+			name:   "default",
+			modify: func(*ServerLimiterConfig) {},
+		},
+		{
+			name: "no non-validator requests",
+			modify: func(c *ServerLimiterConfig) {
+				c.MaxNonValidatorConcurrentRequests = 0
+			},
+		},
+		{
+			name: "invalid max concurrent requests",
+			modify: func(c *ServerLimiterConfig) {
+				c.MaxConcurrentRequests = 0
+			},
+			expectedErr: errInvalidMaxConcurrentRequests,
+		},
+		{
+			name: "too many non-validator concurrent requests",
+			modify: func(c *ServerLimiterConfig) {
+				c.MaxNonValidatorConcurrentRequests = c.MaxConcurrentRequests + 1
+			},
+			expectedErr: errInvalidMaxNonValidatorConcurrentRequests,
+		},
+		{
+			name: "negative non-validator concurrent requests",
+			modify: func(c *ServerLimiterConfig) {
+				c.MaxNonValidatorConcurrentRequests = -1
+			},
+			expectedErr: errInvalidMaxNonValidatorConcurrentRequests,
+		},
+		{
+			name: "invalid quota period",
+			modify: func(c *ServerLimiterConfig) {
+				c.QuotaPeriod = 0
+			},
+			expectedErr: errInvalidQuotaPeriod,
+		},
+		{
+			name: "invalid request quota",
+			modify: func(c *ServerLimiterConfig) {
+				c.PeerRequestQuota = 0
+			},
+			expectedErr: errInvalidPeerRequestQuota,
+		},
+		{
+			name: "invalid bytes quota",
+			modify: func(c *ServerLimiterConfig) {
+				c.PeerBytesQuota = 0
+			},
+			expectedErr: errInvalidPeerBytesQuota,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultServerLimiterConfig()
+			tt.modify(&config)
+			require.ErrorIs(t, config.Verify(), tt.expectedErr)
+		})
+	}
+}
+
+func TestServerLimiterConcurrency(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		validatorID    = ids.GenerateTestNodeID()
+		nonValidatorID = ids.GenerateTestNodeID()
+		config         = DefaultServerLimiterConfig()
+	)
+	config.MaxConcurrentRequests = 3
+	config.MaxNonValidatorConcurrentRequests = 1
+
+	limiter, err := NewServerLimiter(
+		config,
+		testValidatorSet{validators: set.Of(validatorID)},
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	// Non-validators can only use some of the concurrent requests.
+	require.Nil(limiter.acquire(nonValidatorID, false))
+	require.Equal(ErrServerBusy, limiter.acquire(nonValidatorID, false))
+
+	// The remaining concurrent requests are reserved for validators.
+	require.Nil(limiter.acquire(validatorID, true))
+	require.Nil(limiter.acquire(validatorID, true))
+	require.Equal(ErrServerBusy, limiter.acquire(validatorID, true))
+	require.Equal(float64(3), testutil.ToFloat64(limiter.requestsInFlight))
+
+	// Once the non-validator request is served, either can be served.
+	limiter.release(nonValidatorID, false, 0)
+	require.Nil(limiter.acquire(validatorID, true))
+	require.Equal(ErrServerBusy, limiter.acquire(nonValidatorID, false))
+
+	limiter.release(validatorID, true, 0)
+	require.Nil(limiter.acquire(nonValidatorID, false))
+
+	require.Equal(float64(2), testutil.ToFloat64(limiter.requestsRejected.WithLabelValues(nonValidatorPeer, concurrencyLabel)))
+	require.Equal(float64(1), testutil.ToFloat64(limiter.requestsRejected.WithLabelValues(validatorPeer, concurrencyLabel)))
+	require.Equal(float64(1), testutil.ToFloat64(limiter.requestsServed.WithLabelValues(nonValidatorPeer)))
+	require.Equal(float64(1), testutil.ToFloat64(limiter.requestsServed.WithLabelValues(validatorPeer)))
+}
+
+func TestServerLimiterRequestQuota(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		nodeID      = ids.GenerateTestNodeID()
+		otherNodeID = ids.GenerateTestNodeID()
+		config      = DefaultServerLimiterConfig()
+	)
+	config.QuotaPeriod = time.Minute
+	config.PeerRequestQuota = 2
+
+	limiter, err := NewServerLimiter(config, testValidatorSet{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	now := time.Now()
+	limiter.clock.Set(now)
+
+	for i := 0; i < config.PeerRequestQuota; i++ {
+		require.Nil(limiter.acquire(nodeID, false))
+		limiter.release(nodeID, false, 0)
+	}
+	require.Equal(ErrQuotaExceeded, limiter.acquire(nodeID, false))
+
+	// The quota is per-peer.
+	require.Nil(limiter.acquire(otherNodeID, false))
+	limiter.release(otherNodeID, false, 0)
+
+	// The previous period's requests count against the quota at the start of
+	// the next period.
+	limiter.clock.Set(now.Add(config.QuotaPeriod))
+	require.Equal(ErrQuotaExceeded, limiter.acquire(nodeID, false))
+
+	// Halfway through the period, half of the previous period's requests
+	// count against the quota.
+	limiter.clock.Set(now.Add(config.QuotaPeriod + config.QuotaPeriod/2))
+	require.Nil(limiter.acquire(nodeID, false))
+	limiter.release(nodeID, false, 0)
+	require.Equal(ErrQuotaExceeded, limiter.acquire(nodeID, false))
+
+	// Once the previous period's requests have expired, the quota is
+	// restored.
+	limiter.clock.Set(now.Add(3 * config.QuotaPeriod))
+	require.Nil(limiter.acquire(nodeID, false))
+
+	require.Equal(float64(3), testutil.ToFloat64(limiter.requestsRejected.WithLabelValues(nonValidatorPeer, requestQuotaLabel)))
+}
+
+func TestServerLimiterBytesQuota(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		nodeID = ids.GenerateTestNodeID()
+		config = DefaultServerLimiterConfig()
+	)
+	config.PeerBytesQuota = 1024
+
+	limiter, err := NewServerLimiter(config, testValidatorSet{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	now := time.Now()
+	limiter.clock.Set(now)
+
+	require.Nil(limiter.acquire(nodeID, true))
+	limiter.release(nodeID, true, 1000)
+	require.Nil(limiter.acquire(nodeID, true))
+	limiter.release(nodeID, true, 1000)
+	require.Equal(ErrQuotaExceeded, limiter.acquire(nodeID, true))
+
+	limiter.clock.Set(now.Add(2 * config.QuotaPeriod))
+	require.Nil(limiter.acquire(nodeID, true))
+
+	require.Equal(float64(2000), testutil.ToFloat64(limiter.bytesServed.WithLabelValues(validatorPeer)))
+	require.Equal(float64(1), testutil.ToFloat64(limiter.requestsRejected.WithLabelValues(validatorPeer, bytesQuotaLabel)))
+}
+
+func TestLimitedHandler(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		validatorID    = ids.GenerateTestNodeID()
+		nonValidatorID = ids.GenerateTestNodeID()
+		config         = DefaultServerLimiterConfig()
+	)
+	config.MaxNonValidatorConcurrentRequests = 0
+
+	limiter, err := NewServerLimiter(
+		config,
+		testValidatorSet{validators: set.Of(validatorID)},
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	response := []byte("response")
+	handler := NewLimitedHandler(
+		&p2p.TestHandler{
+			AppRequestF: func(context.Context, ids.NodeID, time.Time, []byte) ([]byte, *common.AppError) {
+				return response, nil
+			},
+		},
+		limiter,
+	)
+
+	got, appErr := handler.AppRequest(context.Background(), validatorID, time.Time{}, nil)
+	require.Nil(appErr)
+	require.Equal(response, got)
+
+	_, appErr = handler.AppRequest(context.Background(), nonValidatorID, time.Time{}, nil)
+	require.Equal(ErrServerBusy, appErr)
+
+	require.Zero(testutil.ToFloat64(limiter.requestsInFlight))
+	require.Equal(float64(len(response)), testutil.ToFloat64(limiter.bytesServed.WithLabelValues(validatorPeer)))
+}
@@ -0,0 +1,239 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// maxProgressSize bounds the size of the persisted progress.
+	maxProgressSize = 64 * 1024 * 1024
+
+	// The progress is persisted once this many work items were completed, or
+	// this much time passed, since it was last persisted. The completed ranges
+	// that weren't persisted are fetched again after a restart.
+	progressPersistWorkItems = 64
+	progressPersistInterval  = 10 * time.Second
+)
+
+var progressKey = []byte("progress")
+
+// Progress describes how far along a sync is.
+type Progress struct {
+	// BytesSynced is the number of bytes of proofs applied to the database.
+	BytesSynced uint64
+	// LeavesSynced is the number of key-value pairs applied to the database.
+	LeavesSynced uint64
+	// CompletedFraction is the estimated fraction of the key space that has
+	// been synced to the current target root.
+	CompletedFraction float64
+	// ETA is the estimated amount of time until the sync completes. If the
+	// remaining time can't be estimated, ETA is 0.
+	ETA time.Duration
+}
+
+// Progress returns the progress of the sync.
+func (m *Manager) Progress() Progress {
+	m.workLock.Lock()
+	completed := m.completedFraction()
+	startFraction := m.startFraction
+	startTime := m.startTime
+	m.workLock.Unlock()
+
+	progress := Progress{
+		BytesSynced:       m.bytesSynced.Load(),
+		LeavesSynced:      m.leavesSynced.Load(),
+		CompletedFraction: completed,
+	}
+
+	elapsed := time.Since(startTime)
+	newlyCompleted := completed - startFraction
+	if startTime.IsZero() || newlyCompleted <= 0 || completed >= 1 {
+		return progress
+	}
+	remaining := float64(elapsed) * (1 - completed) / newlyCompleted
+	progress.ETA = time.Duration(min(remaining, math.MaxInt64))
+	return progress
+}
+
+// recordSynced adds a successfully applied proof to the sync progress.
+func (m *Manager) recordSynced(numBytes int, numLeaves int) {
+	m.bytesSynced.Add(uint64(numBytes))
+	m.leavesSynced.Add(uint64(numLeaves))
+}
+
+// completedFraction estimates the fraction of the key space covered by the
+// completed work items.
+//
+// Assumes [m.workLock] is held.
+func (m *Manager) completedFraction() float64 {
+	var completed float64
+	m.processedWork.sortedItems.Ascend(func(item *workItem) bool {
+		start := 0.0
+		if item.start.HasValue() {
+			start = keyFraction(item.start.Value())
+		}
+		end := 1.0
+		if item.end.HasValue() {
+			end = keyFraction(item.end.Value())
+		}
+		completed += max(end-start, 0)
+		return true
+	})
+	return min(completed, 1)
+}
+
+// keyFraction maps [key] to its approximate position in the key space, in the
+// range [0, 1).
+func keyFraction(key []byte) float64 {
+	var prefix [8]byte
+	copy(prefix[:], key)
+	return float64(binary.BigEndian.Uint64(prefix[:])) / math.Exp2(64)
+}
+
+// loadProgress restores the work completed by a previous sync to the current
+// target root. The remaining key ranges are added as unprocessed work.
+//
+// If there is no persisted progress for the current target root, the entire
+// key range is added as unprocessed work.
+//
+// Assumes [m.workLock] is held.
+func (m *Manager) loadProgress() error {
+	var completed []*workItem
+	if m.config.ProgressDB != nil {
+		progressBytes, err := m.config.ProgressDB.Get(progressKey)
+		switch {
+		case err == nil:
+			root, numBytes, numLeaves, ranges, err := parseProgress(progressBytes)
+			if err != nil {
+				return err
+			}
+			if root == m.config.TargetRoot {
+				completed = ranges
+				m.bytesSynced.Store(numBytes)
+				m.leavesSynced.Store(numLeaves)
+			}
+		case err != database.ErrNotFound:
+			return err
+		}
+	}
+
+	now := time.Now()
+	next := maybe.Nothing[[]byte]()
+	for _, item := range completed {
+		if !maybe.Equal(next, item.start, bytes.Equal) {
+			m.unprocessedWork.Insert(newWorkItem(ids.Empty, next, item.start, lowPriority, now))
+		}
+		m.processedWork.MergeInsert(newWorkItem(m.config.TargetRoot, item.start, item.end, lowPriority, now))
+		next = item.end
+		if next.IsNothing() {
+			break
+		}
+	}
+	if len(completed) == 0 || next.HasValue() {
+		m.unprocessedWork.Insert(newWorkItem(ids.Empty, next, maybe.Nothing[[]byte](), lowPriority, now))
+	}
+
+	if len(completed) > 0 {
+		m.config.Log.Info("resuming sync",
+			zap.Stringer("target root", m.config.TargetRoot),
+			zap.Int("numCompletedRanges", len(completed)),
+		)
+	}
+	return nil
+}
+
+// maybePersistProgress records that a work item was completed, and persists
+// the completed work items if enough work was completed, or enough time
+// passed, since they were last persisted. This keeps the write out of the path
+// of most work items.
+//
+// Assumes [m.workLock] is held.
+func (m *Manager) maybePersistProgress() error {
+	m.unpersistedWorkItems++
+	if m.unpersistedWorkItems < progressPersistWorkItems && time.Since(m.lastPersistTime) < progressPersistInterval {
+		return nil
+	}
+	return m.persistProgress()
+}
+
+// persistProgress writes the completed work items to [m.config.ProgressDB],
+// if provided.
+//
+// Assumes [m.workLock] is held.
+func (m *Manager) persistProgress() error {
+	if m.config.ProgressDB == nil {
+		return nil
+	}
+
+	p := wrappers.Packer{MaxSize: maxProgressSize}
+	p.PackFixedBytes(m.config.TargetRoot[:])
+	p.PackLong(m.bytesSynced.Load())
+	p.PackLong(m.leavesSynced.Load())
+	p.PackInt(uint32(m.processedWork.Len()))
+	m.processedWork.sortedItems.Ascend(func(item *workItem) bool {
+		packMaybeBytes(&p, item.start)
+		packMaybeBytes(&p, item.end)
+		return true
+	})
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := m.config.ProgressDB.Put(progressKey, p.Bytes); err != nil {
+		return err
+	}
+	m.unpersistedWorkItems = 0
+	m.lastPersistTime = time.Now()
+	return nil
+}
+
+func parseProgress(progressBytes []byte) (ids.ID, uint64, uint64, []*workItem, error) {
+	p := wrappers.Packer{Bytes: progressBytes}
+	root, err := ids.ToID(p.UnpackFixedBytes(ids.IDLen))
+	if p.Err != nil {
+		return ids.Empty, 0, 0, nil, p.Err
+	}
+	if err != nil {
+		return ids.Empty, 0, 0, nil, err
+	}
+
+	var (
+		numBytes  = p.UnpackLong()
+		numLeaves = p.UnpackLong()
+		numRanges = p.UnpackInt()
+		ranges    []*workItem
+	)
+	for i := uint32(0); i < numRanges && p.Err == nil; i++ {
+		ranges = append(ranges, &workItem{
+			start: unpackMaybeBytes(&p),
+			end:   unpackMaybeBytes(&p),
+		})
+	}
+	return root, numBytes, numLeaves, ranges, p.Err
+}
+
+func packMaybeBytes(p *wrappers.Packer, value maybe.Maybe[[]byte]) {
+	p.PackBool(value.HasValue())
+	if value.HasValue() {
+		p.PackBytes(value.Value())
+	}
+}
+
+func unpackMaybeBytes(p *wrappers.Packer) maybe.Maybe[[]byte] {
+	if !p.UnpackBool() {
+		return maybe.Nothing[[]byte]()
+	}
+	return maybe.Some(p.UnpackBytes())
+}
@@ -0,0 +1,211 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p/p2ptest"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+)
+
+func TestResumeCompletedSync(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+
+	serverDB, err := generateTrie(t, r, 1000)
+	require.NoError(err)
+	root, err := serverDB.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	db, err := merkledb.New(
+		context.Background(),
+		memdb.New(),
+		newDefaultDBConfig(),
+	)
+	require.NoError(err)
+
+	progressDB := memdb.New()
+	newManager := func() *Manager {
+		ctx := context.Background()
+		syncer, err := NewManager(ManagerConfig{
+			DB:                    db,
+			RangeProofClient:      p2ptest.NewClient(t, ctx, NewGetRangeProofHandler(logging.NoLog{}, serverDB), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()),
+			ChangeProofClient:     p2ptest.NewClient(t, ctx, NewGetChangeProofHandler(logging.NoLog{}, serverDB), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()),
+			TargetRoot:            root,
+			SimultaneousWorkLimit: 5,
+			Log:                   logging.NoLog{},
+			BranchFactor:          merkledb.BranchFactor16,
+			ProgressDB:            progressDB,
+		}, prometheus.NewRegistry())
+		require.NoError(err)
+		return syncer
+	}
+
+	syncer := newManager()
+	require.NoError(syncer.Start(context.Background()))
+	require.NoError(syncer.Wait(context.Background()))
+
+	progress := syncer.Progress()
+	require.Equal(1.0, progress.CompletedFraction)
+	require.Zero(progress.ETA)
+	require.Equal(uint64(1000), progress.LeavesSynced)
+	require.NotZero(progress.BytesSynced)
+
+	// A restarted sync to the same root shouldn't fetch anything.
+	syncer = newManager()
+	require.NoError(syncer.Start(context.Background()))
+	require.NoError(syncer.Wait(context.Background()))
+	require.Equal(progress, syncer.Progress())
+	require.Zero(testutil.ToFloat64(syncer.metrics.(*metrics).requestsMade))
+}
+
+func TestLoadProgress(t *testing.T) {
+	var (
+		progressDB = memdb.New()
+		root       = ids.GenerateTestID()
+		b          = maybe.Some([]byte("b"))
+		d          = maybe.Some([]byte("d"))
+		f          = maybe.Some([]byte("f"))
+	)
+
+	// Persist [Nothing, b] and [d, f] as completed.
+	m := &Manager{
+		config: ManagerConfig{
+			TargetRoot: root,
+			ProgressDB: progressDB,
+			Log:        logging.NoLog{},
+		},
+		unprocessedWork: newWorkHeap(),
+		processedWork:   newWorkHeap(),
+	}
+	m.processedWork.MergeInsert(newWorkItem(root, maybe.Nothing[[]byte](), b, lowPriority, time.Now()))
+	m.processedWork.MergeInsert(newWorkItem(root, d, f, lowPriority, time.Now()))
+	m.recordSynced(100, 10)
+	require.NoError(t, m.persistProgress())
+
+	tests := []struct {
+		name                string
+		targetRoot          ids.ID
+		expectedUnprocessed [][2]maybe.Maybe[[]byte]
+		expectedProcessed   int
+		expectedLeaves      uint64
+	}{
+		{
+			name:       "same root",
+			targetRoot: root,
+			expectedUnprocessed: [][2]maybe.Maybe[[]byte]{
+				{b, d},
+				{f, maybe.Nothing[[]byte]()},
+			},
+			expectedProcessed: 2,
+			expectedLeaves:    10,
+		},
+		{
+			name:       "different root",
+			targetRoot: ids.GenerateTestID(),
+			expectedUnprocessed: [][2]maybe.Maybe[[]byte]{
+				{maybe.Nothing[[]byte](), maybe.Nothing[[]byte]()},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			m := &Manager{
+				config: ManagerConfig{
+					TargetRoot: test.targetRoot,
+					ProgressDB: progressDB,
+					Log:        logging.NoLog{},
+				},
+				unprocessedWork: newWorkHeap(),
+				processedWork:   newWorkHeap(),
+			}
+			require.NoError(m.loadProgress())
+
+			var unprocessed [][2]maybe.Maybe[[]byte]
+			m.unprocessedWork.sortedItems.Ascend(func(item *workItem) bool {
+				unprocessed = append(unprocessed, [2]maybe.Maybe[[]byte]{item.start, item.end})
+				return true
+			})
+			require.Equal(test.expectedUnprocessed, unprocessed)
+			require.Equal(test.expectedProcessed, m.processedWork.Len())
+			require.Equal(test.expectedLeaves, m.leavesSynced.Load())
+		})
+	}
+}
+
+func TestPersistProgressPeriodically(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		progressDB = memdb.New()
+		root       = ids.GenerateTestID()
+	)
+	m := &Manager{
+		config: ManagerConfig{
+			TargetRoot: root,
+			ProgressDB: progressDB,
+			Log:        logging.NoLog{},
+		},
+		doneChan:        make(chan struct{}),
+		unprocessedWork: newWorkHeap(),
+		processedWork:   newWorkHeap(),
+		syncing:         true,
+		lastPersistTime: time.Now(),
+	}
+	numPersistedRanges := func() int {
+		progressBytes, err := progressDB.Get(progressKey)
+		if err == database.ErrNotFound {
+			return 0
+		}
+		require.NoError(err)
+		_, _, _, ranges, err := parseProgress(progressBytes)
+		require.NoError(err)
+		return len(ranges)
+	}
+	completeWorkItem := func(i int) {
+		start := maybe.Some([]byte{byte(2 * i)})
+		end := maybe.Some([]byte{byte(2*i + 1)})
+		m.processedWork.MergeInsert(newWorkItem(root, start, end, lowPriority, time.Now()))
+		require.NoError(m.maybePersistProgress())
+	}
+
+	// The progress isn't persisted after every work item.
+	for i := 0; i < progressPersistWorkItems-1; i++ {
+		completeWorkItem(i)
+	}
+	require.Zero(numPersistedRanges())
+
+	completeWorkItem(progressPersistWorkItems - 1)
+	require.Equal(progressPersistWorkItems, numPersistedRanges())
+
+	// The progress is persisted once enough time passed.
+	completeWorkItem(progressPersistWorkItems)
+	require.Equal(progressPersistWorkItems, numPersistedRanges())
+	m.lastPersistTime = time.Now().Add(-progressPersistInterval)
+	completeWorkItem(progressPersistWorkItems + 1)
+	require.Equal(progressPersistWorkItems+2, numPersistedRanges())
+
+	// Closing the sync persists the remaining progress.
+	completeWorkItem(progressPersistWorkItems + 2)
+	m.close()
+	require.Equal(progressPersistWorkItems+3, numPersistedRanges())
+}